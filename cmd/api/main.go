@@ -10,6 +10,7 @@ import (
 	"runtime"
 	"syscall"
 	"time"
+	_ "time/tzdata" // tenant timezones must resolve on hosts without zoneinfo
 
 	"github.com/gofiber/fiber/v2"
 
@@ -18,6 +19,8 @@ import (
 	"qrmenu/internal/middleware"
 	"qrmenu/internal/platform/cache"
 	"qrmenu/internal/platform/db"
+	"qrmenu/internal/platform/events"
+	"qrmenu/internal/platform/payment"
 	"qrmenu/internal/platform/security"
 	"qrmenu/internal/repository"
 	"qrmenu/internal/transport/http"
@@ -57,17 +60,36 @@ func main() {
 		}
 	}()
 	defaultTTL := time.Duration(cfg.Redis.TTLSeconds) * time.Second
+	orderEvents := events.NewRedisBroker(rc.Client(), cfg.Redis.EventsMaxLen)
 
 	// ===== Repositories =====
 	adminRepo := repository.NewAdminRepository(gdb)
 	tenantRepo := repository.NewTenantRepository(gdb)
-	_ = tenantRepo
 	tableRepo := repository.NewTableRepository(gdb)
+	areaRepo := repository.NewTableAreaRepository(gdb)
+	tableStatusRepo := repository.NewTableStatusRepository(gdb)
 	catRepo := repository.NewCategoryRepository(gdb)
 	itemRepo := repository.NewItemRepository(gdb)
 	optRepo := repository.NewOptionRepository(gdb)
 	orderRepo := repository.NewOrderRepository(gdb)
 	menuQuery := repository.NewMenuQuery(gdb)
+	paymentRepo := repository.NewPaymentRepository(gdb)
+	kitchenRepo := repository.NewKitchenRepository(gdb)
+	sessionRepo := repository.NewTableSessionRepository(gdb)
+	billRepo := repository.NewBillRepository(gdb)
+	serviceRepo := repository.NewServiceRequestRepository(gdb)
+
+	// ===== Payment gateway =====
+	var gateway payment.Provider
+	if cfg.Payment.WebhookSecret == "" {
+		log.Fatalf("PAYMENT_WEBHOOK_SECRET must be set when APP_ENV is %q", cfg.AppEnv)
+	}
+	switch cfg.Payment.Provider {
+	case "mock":
+		gateway = payment.NewMock(cfg.Payment.WebhookSecret, cfg.Payment.CheckoutURL)
+	default:
+		log.Fatalf("unsupported PAYMENT_PROVIDER %q", cfg.Payment.Provider)
+	}
 
 	// ===== Security / JWT =====
 	jwtMaker := security.NewJWT(cfg.JWTSecret, cfg.JWTExpiresMinute)
@@ -75,11 +97,21 @@ func main() {
 	// ===== Usecases =====
 	setupUC := usecase.NewSetupUC(adminRepo, tenantRepo, jwtMaker)
 	authUC := usecase.NewAuthUC(adminRepo, jwtMaker)
-	menuUC := usecase.NewMenuUC(menuQuery, rc, defaultTTL)
+	menuUC := usecase.NewMenuUC(menuQuery, tenantRepo, rc, defaultTTL)
 	tableUC := usecase.NewTableUC(tableRepo)
-	orderUC := usecase.NewOrderUC(orderRepo)
-	adminMenuUC := usecase.NewAdminMenuUC(catRepo, itemRepo, optRepo)
-	adminOrdersUC := usecase.NewAdminOrdersUC(orderRepo)
+	orderUC := usecase.NewOrderUC(orderRepo, orderEvents, rc)
+	adminMenuUC := usecase.NewAdminMenuUC(catRepo, itemRepo, optRepo, kitchenRepo, menuUC)
+	adminOrdersUC := usecase.NewAdminOrdersUC(orderRepo, orderEvents)
+	paymentUC := usecase.NewPaymentUC(paymentRepo)
+	kitchenUC := usecase.NewKitchenUC(kitchenRepo, orderEvents)
+	tableSessionUC := usecase.NewTableSessionUC(sessionRepo, orderEvents)
+	billUC := usecase.NewBillUC(billRepo)
+	settingsUC := usecase.NewTenantSettingsUC(tenantRepo, menuUC)
+	serviceUC := usecase.NewServiceRequestUC(tableRepo, serviceRepo, rc)
+	adminTablesUC := usecase.NewAdminTablesUC(tableRepo, areaRepo, tenantRepo, cfg.GuestURLTemplate)
+	floorPlanUC := usecase.NewFloorPlanUC(areaRepo, tableRepo)
+	tableStatusUC := usecase.NewTableStatusUC(tableStatusRepo)
+	gatewayPaymentUC := usecase.NewGatewayPaymentUC(orderRepo, paymentRepo, gateway)
 
 	// ===== Handlers =====
 	setupH := handler.NewSetupHandler(setupUC)
@@ -87,12 +119,23 @@ func main() {
 	menuH := handler.NewMenuHandler(menuUC)
 	tableH := handler.NewTableHandler(tableUC)
 	orderPubH := handler.NewOrderPublicHandler(orderUC)
+	payPubH := handler.NewPaymentPublicHandler(gatewayPaymentUC)
 
 	// Admin menu handler now consumes the use case directly.
 	adminMenuH := handler.NewAdminMenuHandler(adminMenuUC)
 
 	// Admin orders handler can invoke the use case directly.
 	adminOrdersH := handler.NewAdminOrdersHandler(adminOrdersUC)
+	adminPaymentsH := handler.NewAdminPaymentsHandler(paymentUC)
+	kitchenH := handler.NewAdminKitchenHandler(kitchenUC)
+	sessionsH := handler.NewAdminTableSessionsHandler(tableSessionUC)
+	billsH := handler.NewAdminBillsHandler(billUC)
+	settingsH := handler.NewAdminSettingsHandler(settingsUC)
+	svcPubH := handler.NewServiceRequestPublicHandler(serviceUC)
+	adminSvcH := handler.NewAdminServiceRequestsHandler(serviceUC)
+	adminTablesH := handler.NewAdminTablesHandler(adminTablesUC)
+	floorPlanH := handler.NewAdminFloorPlanHandler(floorPlanUC)
+	tableStatusH := handler.NewAdminTableStatusHandler(tableStatusUC)
 
 	// ===== Fiber app =====
 	app := fiber.New(fiber.Config{
@@ -112,6 +155,17 @@ func main() {
 		OrderPub:  orderPubH,
 		AdminMenu: adminMenuH,
 		AdminOrd:  adminOrdersH,
+		AdminPay:  adminPaymentsH,
+		PayPub:    payPubH,
+		Kitchen:   kitchenH,
+		Sessions:  sessionsH,
+		Bills:     billsH,
+		Settings:  settingsH,
+		SvcPub:    svcPubH,
+		AdminSvc:  adminSvcH,
+		Tables:    adminTablesH,
+		FloorPlan: floorPlanH,
+		TableStat: tableStatusH,
 		Setup:     setupH,
 		JWTSecret: cfg.JWTSecret,
 	})
@@ -28,6 +28,10 @@ type Config struct {
 	AdminPassword    string
 	LogLevel         string
 	Redis            RedisConfig
+	Payment          PaymentConfig
+	// GuestURLTemplate is the default link printed in table QR codes ({token} is
+	// replaced by the table token); tenants may override it in their settings.
+	GuestURLTemplate string
 }
 
 type RedisConfig struct {
@@ -35,6 +39,14 @@ type RedisConfig struct {
 	Password   string
 	DB         int
 	TTLSeconds int
+	// EventsMaxLen caps the per-tenant order event stream kept for feed replay.
+	EventsMaxLen int64
+}
+
+type PaymentConfig struct {
+	Provider      string
+	WebhookSecret string
+	CheckoutURL   string
 }
 
 func getEnv(key, def string) string {
@@ -54,14 +66,23 @@ func Load() *Config {
 
 	rdDB, _ := strconv.Atoi(getEnv("REDIS_DB", "0"))
 	rdTTL, _ := strconv.Atoi(getEnv("REDIS_TTL_SECONDS", "300"))
+	rdEvents, _ := strconv.ParseInt(getEnv("REDIS_EVENTS_MAXLEN", "1000"), 10, 64)
 	dbMaxOpen, _ := strconv.Atoi(getEnv("DB_MAX_OPEN_CONNS", "25"))
 	dbMaxIdle, _ := strconv.Atoi(getEnv("DB_MAX_IDLE_CONNS", "10"))
 	dbLife, _ := strconv.Atoi(getEnv("DB_CONN_MAX_LIFETIME_SEC", "600"))
 	dbIdle, _ := strconv.Atoi(getEnv("DB_CONN_MAX_IDLE_TIME_SEC", "300"))
 
+	// Only development falls back to a well-known webhook secret; anywhere else a
+	// missing secret is left empty and rejected at startup.
+	appEnv := getEnv("APP_ENV", "development")
+	webhookSecret := os.Getenv("PAYMENT_WEBHOOK_SECRET")
+	if webhookSecret == "" && appEnv == "development" {
+		webhookSecret = "dev_webhook_secret"
+	}
+
 	return &Config{
 		AppName:          getEnv("APP_NAME", "qrmenu"),
-		AppEnv:           getEnv("APP_ENV", "development"),
+		AppEnv:           appEnv,
 		AppPort:          getEnv("APP_PORT", "8080"),
 		AllowedOrigins:   strings.Split(getEnv("APP_ALLOWED_ORIGINS", "http://localhost:3000"), ","),
 		DBHost:           getEnv("DB_HOST", "localhost"),
@@ -79,11 +100,18 @@ func Load() *Config {
 		AdminEmail:       getEnv("ADMIN_EMAIL", "admin@qrmenu.local"),
 		AdminPassword:    getEnv("ADMIN_PASSWORD", "admin123"),
 		LogLevel:         getEnv("LOG_LEVEL", "debug"), // dev=debug, prod=info
+		GuestURLTemplate: getEnv("GUEST_URL_TEMPLATE", "http://localhost:3000/t/{token}"),
 		Redis: RedisConfig{
-			Addr:       getEnv("REDIS_ADDR", "127.0.0.1:6379"),
-			Password:   getEnv("REDIS_PASSWORD", ""),
-			DB:         rdDB,
-			TTLSeconds: rdTTL,
+			Addr:         getEnv("REDIS_ADDR", "127.0.0.1:6379"),
+			Password:     getEnv("REDIS_PASSWORD", ""),
+			DB:           rdDB,
+			TTLSeconds:   rdTTL,
+			EventsMaxLen: rdEvents,
+		},
+		Payment: PaymentConfig{
+			Provider:      getEnv("PAYMENT_PROVIDER", "mock"),
+			WebhookSecret: webhookSecret,
+			CheckoutURL:   getEnv("PAYMENT_CHECKOUT_URL", "http://localhost:8080/mock-checkout"),
 		},
 	}
 }
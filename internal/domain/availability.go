@@ -0,0 +1,179 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	clockLayout = "15:04"
+	dateLayout  = "2006-01-02"
+)
+
+// AvailabilityWindow is a period in which a category or item can be ordered,
+// read in the tenant's timezone. Days are ISO weekdays (1 = Monday … 7 = Sunday)
+// and empty means every day. Start and End are "HH:MM"; both empty means all day
+// and an End before Start runs past midnight (the window belongs to the day it
+// starts on). From and Until ("YYYY-MM-DD", inclusive) bound the dates it applies.
+type AvailabilityWindow struct {
+	Days  []int  `json:"days,omitempty"`
+	Start string `json:"start,omitempty"`
+	End   string `json:"end,omitempty"`
+	From  string `json:"from,omitempty"`
+	Until string `json:"until,omitempty"`
+}
+
+// Availability is a schedule of windows; an empty schedule is always available.
+// It is stored as a JSON array.
+type Availability []AvailabilityWindow
+
+// AvailableAt reports whether t falls in any window of the schedule. t must
+// already be in the tenant's timezone.
+func (a Availability) AvailableAt(t time.Time) bool {
+	if len(a) == 0 {
+		return true
+	}
+	for _, w := range a {
+		if w.contains(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks the days, times and dates of every window.
+func (a Availability) Validate() error {
+	for i, w := range a {
+		for _, d := range w.Days {
+			if d < 1 || d > 7 {
+				return fmt.Errorf("availability[%d]: days must be 1 (Monday) to 7 (Sunday)", i)
+			}
+		}
+		if (w.Start == "") != (w.End == "") {
+			return fmt.Errorf("availability[%d]: start and end go together", i)
+		}
+		if w.Start != "" {
+			start, err1 := time.Parse(clockLayout, w.Start)
+			end, err2 := time.Parse(clockLayout, w.End)
+			if err1 != nil || err2 != nil {
+				return fmt.Errorf("availability[%d]: start and end must be HH:MM", i)
+			}
+			if start.Equal(end) {
+				return fmt.Errorf("availability[%d]: start and end must differ", i)
+			}
+		}
+		for _, d := range []string{w.From, w.Until} {
+			if _, err := time.Parse(dateLayout, d); d != "" && err != nil {
+				return fmt.Errorf("availability[%d]: from and until must be YYYY-MM-DD", i)
+			}
+		}
+		if w.From != "" && w.Until != "" && w.From > w.Until {
+			return fmt.Errorf("availability[%d]: from must not be after until", i)
+		}
+	}
+	return nil
+}
+
+func (w AvailabilityWindow) contains(t time.Time) bool {
+	if w.Start == "" {
+		return w.onDay(t)
+	}
+	start, _ := time.Parse(clockLayout, w.Start)
+	end, _ := time.Parse(clockLayout, w.End)
+	now := t.Hour()*60 + t.Minute()
+	from := start.Hour()*60 + start.Minute()
+	to := end.Hour()*60 + end.Minute()
+	switch {
+	case from < to:
+		return now >= from && now < to && w.onDay(t)
+	case now >= from:
+		return w.onDay(t)
+	case now < to:
+		return w.onDay(t.AddDate(0, 0, -1))
+	}
+	return false
+}
+
+// onDay reports whether the window applies on the calendar day of t.
+func (w AvailabilityWindow) onDay(t time.Time) bool {
+	date := t.Format(dateLayout)
+	if (w.From != "" && date < w.From) || (w.Until != "" && date > w.Until) {
+		return false
+	}
+	if len(w.Days) == 0 {
+		return true
+	}
+	day := int(t.Weekday())
+	if day == 0 {
+		day = 7
+	}
+	for _, d := range w.Days {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalJSON renders an empty schedule as [] rather than null.
+func (a Availability) MarshalJSON() ([]byte, error) {
+	if a == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal([]AvailabilityWindow(a))
+}
+
+func (a Availability) Value() (driver.Value, error) {
+	b, err := a.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (a *Availability) Scan(src any) error {
+	var b []byte
+	switch v := src.(type) {
+	case nil:
+		*a = nil
+		return nil
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		return fmt.Errorf("availability: unsupported type %T", src)
+	}
+	return json.Unmarshal(b, (*[]AvailabilityWindow)(a))
+}
+
+// timezones caches lookups by name, unknown names included, so the zone
+// database is read once per name.
+var timezones sync.Map
+
+type timezoneLookup struct {
+	loc *time.Location
+	err error
+}
+
+// LoadTimezone returns the named IANA location; an empty name is the server's
+// local time zone. For an unknown name it returns the local zone together with
+// the lookup error, which the caller reports.
+func LoadTimezone(name string) (*time.Location, error) {
+	if name == "" {
+		return time.Local, nil
+	}
+	if v, ok := timezones.Load(name); ok {
+		l := v.(timezoneLookup)
+		return l.loc, l.err
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		loc = time.Local
+	}
+	timezones.Store(name, timezoneLookup{loc, err})
+	return loc, err
+}
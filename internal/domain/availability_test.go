@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+// at builds a UTC time on the given date; 2025-11-02 is a Sunday.
+func at(date, clock string) time.Time {
+	t, err := time.Parse("2006-01-02 15:04", date+" "+clock)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestAvailableAt(t *testing.T) {
+	tests := []struct {
+		name  string
+		sched Availability
+		t     time.Time
+		want  bool
+	}{
+		{name: "empty schedule is always available", sched: nil, t: at("2025-11-03", "03:00"), want: true},
+		{name: "inside a daytime window", sched: Availability{{Start: "11:00", End: "14:00"}}, t: at("2025-11-03", "11:00"), want: true},
+		{name: "end is exclusive", sched: Availability{{Start: "11:00", End: "14:00"}}, t: at("2025-11-03", "14:00"), want: false},
+		{name: "overnight before midnight", sched: Availability{{Start: "22:00", End: "02:00"}}, t: at("2025-11-03", "23:30"), want: true},
+		{name: "overnight after midnight", sched: Availability{{Start: "22:00", End: "02:00"}}, t: at("2025-11-04", "01:30"), want: true},
+		{name: "overnight gap", sched: Availability{{Start: "22:00", End: "02:00"}}, t: at("2025-11-04", "12:00"), want: false},
+		{name: "overnight belongs to the start day", sched: Availability{{Days: []int{5}, Start: "22:00", End: "02:00"}}, t: at("2025-11-08", "01:00"), want: true},
+		{name: "overnight not started on a listed day", sched: Availability{{Days: []int{5}, Start: "22:00", End: "02:00"}}, t: at("2025-11-07", "01:00"), want: false},
+		{name: "sunday is day 7", sched: Availability{{Days: []int{7}}}, t: at("2025-11-02", "12:00"), want: true},
+		{name: "monday is day 1", sched: Availability{{Days: []int{1}}}, t: at("2025-11-03", "12:00"), want: true},
+		{name: "day not listed", sched: Availability{{Days: []int{1, 2, 3, 4, 5}}}, t: at("2025-11-02", "12:00"), want: false},
+		{name: "from is inclusive", sched: Availability{{From: "2025-12-01", Until: "2025-12-31"}}, t: at("2025-12-01", "00:00"), want: true},
+		{name: "until is inclusive", sched: Availability{{From: "2025-12-01", Until: "2025-12-31"}}, t: at("2025-12-31", "23:59"), want: true},
+		{name: "before from", sched: Availability{{From: "2025-12-01"}}, t: at("2025-11-30", "23:59"), want: false},
+		{name: "after until", sched: Availability{{Until: "2025-12-31"}}, t: at("2026-01-01", "00:00"), want: false},
+		{name: "overnight past until", sched: Availability{{Start: "22:00", End: "02:00", Until: "2025-12-31"}}, t: at("2026-01-01", "01:00"), want: true},
+		{name: "any window matches", sched: Availability{{Start: "07:00", End: "10:00"}, {Start: "17:00", End: "21:00"}}, t: at("2025-11-03", "18:00"), want: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.sched.AvailableAt(tc.t); got != tc.want {
+				t.Fatalf("AvailableAt(%s) = %v, want %v", tc.t.Format(time.RFC3339), got, tc.want)
+			}
+		})
+	}
+}
+
+func TestLoadTimezone(t *testing.T) {
+	tests := []struct {
+		name    string
+		zone    string
+		want    string
+		wantErr bool
+	}{
+		{name: "empty uses the server zone", zone: "", want: time.Local.String()},
+		{name: "known zone", zone: "UTC", want: "UTC"},
+		{name: "unknown zone falls back with an error", zone: "Mars/Olympus_Mons", want: time.Local.String(), wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			for i := 0; i < 2; i++ { // the second lookup is served from the cache
+				loc, err := LoadTimezone(tc.zone)
+				if (err != nil) != tc.wantErr {
+					t.Fatalf("LoadTimezone(%q) err = %v, want error %v", tc.zone, err, tc.wantErr)
+				}
+				if loc.String() != tc.want {
+					t.Fatalf("LoadTimezone(%q) = %s, want %s", tc.zone, loc, tc.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,235 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+type SplitMode string
+
+const (
+	SplitByItems  SplitMode = "items"
+	SplitByGuests SplitMode = "guests"
+	SplitEvenly   SplitMode = "even"
+)
+
+// Valid reports whether m is a supported split mode.
+func (m SplitMode) Valid() bool {
+	switch m {
+	case SplitByItems, SplitByGuests, SplitEvenly:
+		return true
+	}
+	return false
+}
+
+// Bill is one sub-bill of a table session, paid separately at the counter.
+// Its lines carry the share of each order (or order item) it covers, so a
+// payment against the bill is allocated to the underlying orders.
+type Bill struct {
+	ID         string     `json:"id"          db:"id"          gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	TenantID   string     `json:"tenant_id"   db:"tenant_id"   gorm:"type:uuid;index"`
+	SessionID  string     `json:"session_id"  db:"session_id"  gorm:"type:uuid;index"`
+	TableID    string     `json:"table_id"    db:"table_id"    gorm:"type:uuid;index"`
+	Mode       SplitMode  `json:"mode"        db:"mode"        gorm:"type:text"`
+	Label      string     `json:"label"       db:"label"`
+	Total      int64      `json:"total"       db:"total"`
+	PaidAmount int64      `json:"paid_amount" db:"paid_amount"`
+	PaidStatus PaidStatus `json:"paid_status" db:"paid_status" gorm:"type:text;default:'unpaid'"`
+	CreatedAt  time.Time  `json:"created_at"  db:"created_at"  gorm:"autoCreateTime"`
+
+	Lines []BillLine `json:"lines,omitempty" gorm:"foreignKey:BillID;constraint:OnDelete:CASCADE"`
+}
+
+// Outstanding returns the amount still owed on the bill.
+func (b *Bill) Outstanding() int64 {
+	if b.PaidAmount >= b.Total {
+		return 0
+	}
+	return b.Total - b.PaidAmount
+}
+
+// BillLine is the part of one order charged on a bill. OrderItemID is set when
+// the bill was split by items.
+type BillLine struct {
+	ID          string  `json:"id"                      db:"id"            gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	BillID      string  `json:"bill_id"                 db:"bill_id"       gorm:"type:uuid;index"`
+	OrderID     string  `json:"order_id"                db:"order_id"      gorm:"type:uuid;index"`
+	OrderItemID *string `json:"order_item_id,omitempty" db:"order_item_id" gorm:"type:uuid"`
+	Description string  `json:"description"             db:"description"`
+	Amount      int64   `json:"amount"                  db:"amount"`
+	PaidAmount  int64   `json:"paid_amount"             db:"paid_amount"`
+}
+
+// Outstanding returns the part of the line still owed.
+func (l *BillLine) Outstanding() int64 {
+	if l.PaidAmount >= l.Amount {
+		return 0
+	}
+	return l.Amount - l.PaidAmount
+}
+
+// SplitRequest describes how a session's outstanding orders are divided into bills.
+// Groups is used with SplitByItems; order items left out of every group go on a
+// final bill. Count is used with SplitEvenly.
+type SplitRequest struct {
+	Mode   SplitMode
+	Groups []SplitGroup
+	Count  int
+}
+
+type SplitGroup struct {
+	Label   string
+	ItemIDs []string
+}
+
+// maxEvenSplit caps the number of bills of an even split.
+const maxEvenSplit = 50
+
+// SplitOrders divides the outstanding balance of orders into unpaid bills.
+// Orders must be the session's payable (non-canceled, not fully paid) orders with
+// their items loaded. Amounts are exact: the lines of every order add up to its
+// outstanding balance, with even-split remainders going to the first bills.
+func SplitOrders(orders []Order, req SplitRequest) ([]Bill, error) {
+	var bills []Bill
+	switch req.Mode {
+	case SplitByItems:
+		var err error
+		if bills, err = splitByItems(orders, req.Groups); err != nil {
+			return nil, err
+		}
+	case SplitByGuests:
+		bills = splitByGuests(orders)
+	case SplitEvenly:
+		if req.Count < 2 || req.Count > maxEvenSplit {
+			return nil, fmt.Errorf("%w: count must be between 2 and %d", ErrInvalidSplit, maxEvenSplit)
+		}
+		bills = splitEvenly(orders, req.Count)
+	default:
+		return nil, fmt.Errorf("%w: unknown mode %q", ErrInvalidSplit, req.Mode)
+	}
+	out := bills[:0]
+	for _, b := range bills {
+		if len(b.Lines) == 0 {
+			continue
+		}
+		b.Mode, b.PaidStatus = req.Mode, Unpaid
+		for _, l := range b.Lines {
+			b.Total += l.Amount
+		}
+		out = append(out, b)
+	}
+	return out, nil
+}
+
+// splitByItems puts the chosen order items on one bill per group. Free items
+// (zero line total) may be grouped but get no bill line, since a line must
+// charge something; a group of only free items yields no bill.
+func splitByItems(orders []Order, groups []SplitGroup) ([]Bill, error) {
+	if len(groups) == 0 {
+		return nil, fmt.Errorf("%w: at least one item group is required", ErrInvalidSplit)
+	}
+	lines := map[string]BillLine{}
+	var order []string
+	for _, o := range orders {
+		if o.PaidAmount > 0 {
+			return nil, fmt.Errorf("%w: order %s is partially paid, split by guests or evenly instead", ErrInvalidSplit, o.ID)
+		}
+		for _, it := range o.Items {
+			if it.Status == ItemVoided {
+				continue
+			}
+			id := it.ID
+			lines[id] = BillLine{OrderID: o.ID, OrderItemID: &id, Description: fmt.Sprintf("%dx %s", it.Qty, it.Name), Amount: it.LineTotal}
+			order = append(order, id)
+		}
+	}
+
+	taken := map[string]bool{}
+	bills := make([]Bill, 0, len(groups)+1)
+	for i, g := range groups {
+		if len(g.ItemIDs) == 0 {
+			return nil, fmt.Errorf("%w: group %d has no items", ErrInvalidSplit, i+1)
+		}
+		b := Bill{Label: g.Label}
+		if b.Label == "" {
+			b.Label = fmt.Sprintf("Bill %d", i+1)
+		}
+		for _, id := range g.ItemIDs {
+			l, ok := lines[id]
+			if !ok {
+				return nil, fmt.Errorf("%w: item %s is not a payable line of this table", ErrInvalidSplit, id)
+			}
+			if taken[id] {
+				return nil, fmt.Errorf("%w: item %s is in more than one group", ErrInvalidSplit, id)
+			}
+			taken[id] = true
+			if l.Amount > 0 {
+				b.Lines = append(b.Lines, l)
+			}
+		}
+		bills = append(bills, b)
+	}
+
+	rest := Bill{Label: "Remaining items"}
+	for _, id := range order {
+		if !taken[id] && lines[id].Amount > 0 {
+			rest.Lines = append(rest.Lines, lines[id])
+		}
+	}
+	if len(rest.Lines) > 0 {
+		bills = append(bills, rest)
+	}
+	return bills, nil
+}
+
+func splitByGuests(orders []Order) []Bill {
+	index := map[string]int{}
+	var bills []Bill
+	for _, o := range orders {
+		if o.Outstanding() == 0 {
+			continue
+		}
+		i, ok := index[o.GuestSession]
+		if !ok {
+			i = len(bills)
+			index[o.GuestSession] = i
+			label := o.GuestSession
+			if label == "" {
+				label = fmt.Sprintf("Guest %d", i+1)
+			}
+			bills = append(bills, Bill{Label: label})
+		}
+		bills[i].Lines = append(bills[i].Lines, BillLine{
+			OrderID:     o.ID,
+			Description: fmt.Sprintf("Order %s", o.ID),
+			Amount:      o.Outstanding(),
+		})
+	}
+	return bills
+}
+
+func splitEvenly(orders []Order, n int) []Bill {
+	bills := make([]Bill, n)
+	for i := range bills {
+		bills[i].Label = fmt.Sprintf("%d/%d", i+1, n)
+	}
+	for _, o := range orders {
+		out := o.Outstanding()
+		share, rem := out/int64(n), out%int64(n)
+		for i := range bills {
+			amount := share
+			if int64(i) < rem {
+				amount++
+			}
+			if amount == 0 {
+				continue
+			}
+			bills[i].Lines = append(bills[i].Lines, BillLine{
+				OrderID:     o.ID,
+				Description: fmt.Sprintf("Share of order %s", o.ID),
+				Amount:      amount,
+			})
+		}
+	}
+	return bills
+}
@@ -0,0 +1,148 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func billTotals(bills []Bill) []int64 {
+	out := make([]int64, len(bills))
+	for i, b := range bills {
+		out[i] = b.Total
+	}
+	return out
+}
+
+func equalAmounts(a, b []int64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestSplitByItems(t *testing.T) {
+	orders := []Order{
+		{ID: "o1", Total: 45000, Items: []OrderItem{
+			{ID: "a", Name: "Latte", Qty: 1, LineTotal: 30000},
+			{ID: "b", Name: "Water", Qty: 1, LineTotal: 0},
+			{ID: "c", Name: "Cookie", Qty: 1, LineTotal: 15000},
+			{ID: "d", Name: "Cake", Qty: 1, LineTotal: 20000, Status: ItemVoided},
+		}},
+	}
+	tests := []struct {
+		name    string
+		groups  []SplitGroup
+		totals  []int64
+		lines   []int
+		wantErr bool
+	}{
+		{name: "rest goes on a final bill", groups: []SplitGroup{{ItemIDs: []string{"a"}}}, totals: []int64{30000, 15000}, lines: []int{1, 1}},
+		{name: "free item gets no line", groups: []SplitGroup{{ItemIDs: []string{"a", "b"}}, {ItemIDs: []string{"c"}}}, totals: []int64{30000, 15000}, lines: []int{1, 1}},
+		{name: "group of free items is dropped", groups: []SplitGroup{{ItemIDs: []string{"b"}}, {ItemIDs: []string{"a", "c"}}}, totals: []int64{45000}, lines: []int{2}},
+		{name: "voided item is not payable", groups: []SplitGroup{{ItemIDs: []string{"d"}}}, wantErr: true},
+		{name: "item in two groups", groups: []SplitGroup{{ItemIDs: []string{"a"}}, {ItemIDs: []string{"a"}}}, wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			bills, err := SplitOrders(orders, SplitRequest{Mode: SplitByItems, Groups: tc.groups})
+			if tc.wantErr {
+				if !errors.Is(err, ErrInvalidSplit) {
+					t.Fatalf("err = %v, want ErrInvalidSplit", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := billTotals(bills); !equalAmounts(got, tc.totals) {
+				t.Fatalf("totals = %v, want %v", got, tc.totals)
+			}
+			for i, b := range bills {
+				if len(b.Lines) != tc.lines[i] {
+					t.Errorf("bill %d has %d lines, want %d", i, len(b.Lines), tc.lines[i])
+				}
+				for _, l := range b.Lines {
+					if l.Amount <= 0 {
+						t.Errorf("bill %d has a line of %d", i, l.Amount)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestSplitByItemsRejectsPartiallyPaid(t *testing.T) {
+	orders := []Order{{ID: "o1", Total: 10000, PaidAmount: 5000, Items: []OrderItem{{ID: "a", LineTotal: 10000}}}}
+	if _, err := SplitOrders(orders, SplitRequest{Mode: SplitByItems, Groups: []SplitGroup{{ItemIDs: []string{"a"}}}}); !errors.Is(err, ErrInvalidSplit) {
+		t.Fatalf("err = %v, want ErrInvalidSplit", err)
+	}
+}
+
+func TestSplitEvenly(t *testing.T) {
+	tests := []struct {
+		name   string
+		orders []Order
+		count  int
+		totals []int64
+	}{
+		{name: "exact", orders: []Order{{ID: "o1", Total: 90000}}, count: 3, totals: []int64{30000, 30000, 30000}},
+		{name: "remainder goes to the first bills", orders: []Order{{ID: "o1", Total: 10}}, count: 3, totals: []int64{4, 3, 3}},
+		{name: "outstanding balance only", orders: []Order{{ID: "o1", Total: 10000, PaidAmount: 4000}, {ID: "o2", Total: 5}}, count: 2, totals: []int64{3003, 3002}},
+		{name: "fewer units than bills", orders: []Order{{ID: "o1", Total: 2}}, count: 3, totals: []int64{1, 1}},
+		{name: "nothing owed", orders: []Order{{ID: "o1", Total: 0}}, count: 2, totals: []int64{}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			bills, err := SplitOrders(tc.orders, SplitRequest{Mode: SplitEvenly, Count: tc.count})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := billTotals(bills); !equalAmounts(got, tc.totals) {
+				t.Fatalf("totals = %v, want %v", got, tc.totals)
+			}
+			var sum, owed int64
+			for _, b := range bills {
+				sum += b.Total
+				for _, l := range b.Lines {
+					if l.Amount <= 0 {
+						t.Errorf("bill %s has a line of %d", b.Label, l.Amount)
+					}
+				}
+			}
+			for i := range tc.orders {
+				owed += tc.orders[i].Outstanding()
+			}
+			if sum != owed {
+				t.Errorf("bills add up to %d, want %d", sum, owed)
+			}
+		})
+	}
+}
+
+func TestSplitEvenlyCount(t *testing.T) {
+	for _, n := range []int{1, maxEvenSplit + 1} {
+		if _, err := SplitOrders([]Order{{ID: "o1", Total: 100}}, SplitRequest{Mode: SplitEvenly, Count: n}); !errors.Is(err, ErrInvalidSplit) {
+			t.Errorf("count %d: err = %v, want ErrInvalidSplit", n, err)
+		}
+	}
+}
+
+func TestSplitByGuestsSkipsSettledOrders(t *testing.T) {
+	orders := []Order{
+		{ID: "o1", GuestSession: "g1", Total: 10000},
+		{ID: "o2", GuestSession: "g2", Total: 0},
+		{ID: "o3", GuestSession: "g1", Total: 5000},
+	}
+	bills, err := SplitOrders(orders, SplitRequest{Mode: SplitByGuests})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := billTotals(bills); !equalAmounts(got, []int64{15000}) {
+		t.Fatalf("totals = %v, want [15000]", got)
+	}
+}
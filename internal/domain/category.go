@@ -1,9 +1,12 @@
 package domain
 
 type Category struct {
-	ID       string `json:"id"         db:"id"         gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
-	TenantID string `json:"tenant_id"  db:"tenant_id"  gorm:"type:uuid;index"`
-	Name     string `json:"name"       db:"name"       gorm:"not null"`
-	Sort     int    `json:"sort"       db:"sort"       gorm:"default:0"`
-	IsActive bool   `json:"is_active"  db:"is_active"  gorm:"default:true;index"`
+	ID        string  `json:"id"         db:"id"         gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	TenantID  string  `json:"tenant_id"  db:"tenant_id"  gorm:"type:uuid;index"`
+	Name      string  `json:"name"       db:"name"       gorm:"not null"`
+	Sort      int     `json:"sort"       db:"sort"       gorm:"default:0"`
+	IsActive  bool    `json:"is_active"  db:"is_active"  gorm:"default:true;index"`
+	StationID *string `json:"station_id" db:"station_id" gorm:"type:uuid"`
+	// Availability limits when the category's items can be ordered.
+	Availability Availability `json:"availability" db:"availability" gorm:"type:jsonb;default:'[]'"`
 }
@@ -0,0 +1,81 @@
+package domain
+
+import "errors"
+
+// Business rule violations returned by use cases and repositories.
+// Handlers translate them into HTTP status codes and error codes.
+var (
+	ErrInvalidQuantity       = errors.New("item quantity must be positive")
+	ErrItemUnavailable       = errors.New("menu item not found or inactive")
+	ErrUnknownOption         = errors.New("option does not belong to item")
+	ErrUnknownOptionValue    = errors.New("option value does not belong to option")
+	ErrInvalidOptionValue    = errors.New("option selection must be a value id, a list of value ids or value quantities keyed by value id")
+	ErrMissingRequiredOption = errors.New("required option not selected")
+	ErrTooManyOptionValues   = errors.New("too many values selected for option")
+	ErrTooFewOptionValues    = errors.New("not enough values selected for option")
+	ErrOptionValueQtyLimit   = errors.New("option value selected more times than allowed")
+	ErrItemNotAvailableNow   = errors.New("item is not available at this time")
+
+	ErrItemNotFound        = errors.New("menu item not found")
+	ErrOptionNotFound      = errors.New("item option not found")
+	ErrInvalidOption       = errors.New("invalid option")
+	ErrOptionValueNotFound = errors.New("option value not found")
+	ErrCategoryNotFound    = errors.New("category not found")
+	ErrAttachmentNotFound  = errors.New("option attachment not found")
+
+	ErrOrderNotFound           = errors.New("order not found")
+	ErrInvalidOrderStatus      = errors.New("unknown order status")
+	ErrInvalidStatusTransition = errors.New("order status transition not allowed")
+	ErrOrderItemNotFound       = errors.New("order item not found")
+	ErrInvalidItemStatus       = errors.New("unknown order item status")
+	ErrInvalidItemTransition   = errors.New("order item status transition not allowed")
+	ErrOrderClosed             = errors.New("order is already done or canceled")
+	ErrOrderNotAmendable       = errors.New("order can no longer be changed by the guest")
+
+	ErrInvalidSettings = errors.New("invalid setting")
+
+	ErrTableNotFound            = errors.New("table not found")
+	ErrTableInUse               = errors.New("table has orders, deactivate it instead")
+	ErrTableCodeTaken           = errors.New("another table already uses this code")
+	ErrAreaNotFound             = errors.New("table area not found")
+	ErrTableOffPlan             = errors.New("table position is outside the area's floor plan")
+	ErrInvalidTableStatus       = errors.New("invalid table status")
+	ErrOrderNotMovable          = errors.New("order cannot be moved")
+	ErrTableSessionLocked       = errors.New("table is being billed, new orders are not accepted")
+	ErrSessionNotFound          = errors.New("table session not found")
+	ErrInvalidSessionTransition = errors.New("table session status change not allowed")
+	ErrSessionHasBalance        = errors.New("table session still has an outstanding balance")
+
+	ErrServiceRequestNotFound   = errors.New("service request not found")
+	ErrInvalidServiceRequest    = errors.New("unknown service request type")
+	ErrInvalidServiceTransition = errors.New("service request status change not allowed")
+	ErrServiceRequestThrottled  = errors.New("this request was sent moments ago, please wait before sending it again")
+	ErrServiceNoteTooLong       = errors.New("note must be at most 500 characters")
+
+	ErrBillNotFound      = errors.New("bill not found")
+	ErrInvalidSplit      = errors.New("invalid bill split")
+	ErrBillsHavePayments = errors.New("bills of this session already have payments")
+
+	ErrInvalidIdempotencyKey = errors.New("idempotency key must be at most 255 characters")
+	ErrIdempotencyKeyReused  = errors.New("idempotency key was used with a different payload")
+	ErrIdempotencyInProgress = errors.New("a request with this idempotency key is still being processed")
+
+	ErrInvalidPaymentMethod  = errors.New("unknown payment method")
+	ErrInvalidPaymentAmount  = errors.New("payment amount must be positive")
+	ErrInsufficientTender    = errors.New("amount tendered is less than the amount paid")
+	ErrPaymentExceedsBalance = errors.New("payment exceeds the outstanding balance")
+	ErrNothingToPay          = errors.New("no outstanding balance")
+	ErrOrderNotPayable       = errors.New("order cannot be paid")
+
+	ErrUnknownPaymentProvider  = errors.New("unknown payment provider")
+	ErrInvalidWebhookSignature = errors.New("invalid webhook signature")
+	ErrPaymentIntentNotFound   = errors.New("payment intent not found")
+	ErrPaymentAmountMismatch   = errors.New("reported amount does not match the payment intent")
+
+	ErrInvalidEventID = errors.New("invalid event id")
+
+	ErrStationNotFound     = errors.New("kitchen station not found")
+	ErrTicketNotFound      = errors.New("kitchen ticket not found")
+	ErrTicketClosed        = errors.New("order is no longer in the kitchen")
+	ErrInvalidTicketStatus = errors.New("unknown ticket status")
+)
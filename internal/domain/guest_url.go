@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// GuestURL builds the link encoded in a table's QR code from the tenant's
+// template, or from fallback when the tenant has none. {token} is replaced with
+// the table token and {tenant} with the tenant code.
+func (t *Tenant) GuestURL(fallback, token string) string {
+	tpl := t.GuestURLTemplate
+	if tpl == "" {
+		tpl = fallback
+	}
+	return strings.NewReplacer("{token}", url.PathEscape(token), "{tenant}", url.PathEscape(t.Code)).Replace(tpl)
+}
+
+// ValidateGuestURLTemplate checks that tpl is an absolute http(s) URL containing {token}.
+func ValidateGuestURLTemplate(tpl string) error {
+	if !strings.Contains(tpl, "{token}") {
+		return fmt.Errorf("guest_url_template must contain {token}")
+	}
+	u, err := url.Parse(strings.NewReplacer("{token}", "x", "{tenant}", "x").Replace(tpl))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("guest_url_template must be an absolute http(s) URL")
+	}
+	return nil
+}
@@ -12,4 +12,7 @@ type Item struct {
 	PhotoURL    *string           `json:"photo_url,omitempty" db:"photo_url"`
 	Flags       datatypes.JSONMap `json:"flags,omitempty"     db:"flags"     gorm:"type:jsonb"`
 	IsActive    bool              `json:"is_active"    db:"is_active"     gorm:"default:true;index"`
+	StationID   *string           `json:"station_id"   db:"station_id"   gorm:"type:uuid"`
+	// Availability limits when the item can be ordered, on top of its category's.
+	Availability Availability `json:"availability" db:"availability" gorm:"type:jsonb;default:'[]'"`
 }
@@ -1,9 +1,65 @@
 package domain
 
+import "fmt"
+
+// Option types: a size or level takes a single value, an addon any number of values.
+const (
+	OptionTypeSize  = "size"
+	OptionTypeAddon = "addon"
+	OptionTypeLevel = "level"
+)
+
+// ValidOptionType reports whether t is a known option type.
+func ValidOptionType(t string) bool {
+	switch t {
+	case OptionTypeSize, OptionTypeAddon, OptionTypeLevel:
+		return true
+	}
+	return false
+}
+
+// ItemOption is a tenant-wide modifier group (e.g. "Sugar level") offered on the
+// items and categories it is attached to. MinSelect and MaxSelect bound how many
+// values (counting each value's quantity) a guest picks; MaxSelect 0 means no
+// limit for add-ons.
 type ItemOption struct {
-	ID       string `json:"id"       db:"id"       gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
-	ItemID   string `json:"item_id"  db:"item_id"  gorm:"type:uuid;index"`
-	Name     string `json:"name"     db:"name"     gorm:"not null"`
-	Type     string `json:"type"     db:"type"     gorm:"not null"`
-	Required bool   `json:"required" db:"required" gorm:"default:false"`
+	ID        string `json:"id"         db:"id"         gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	TenantID  string `json:"tenant_id"  db:"tenant_id"  gorm:"type:uuid;index"`
+	Name      string `json:"name"       db:"name"       gorm:"not null"`
+	Type      string `json:"type"       db:"type"       gorm:"not null"`
+	Required  bool   `json:"required"   db:"required"   gorm:"default:false"`
+	MinSelect int    `json:"min_select" db:"min_select" gorm:"default:0"`
+	MaxSelect int    `json:"max_select" db:"max_select" gorm:"default:0"`
+	Sort      int    `json:"sort"       db:"sort"       gorm:"default:0"`
+}
+
+// MinChoices is the fewest values a guest must pick; a required option needs at least one.
+func (o *ItemOption) MinChoices() int {
+	if o.Required && o.MinSelect < 1 {
+		return 1
+	}
+	return o.MinSelect
+}
+
+// MaxChoices is the most values a guest may pick, 0 meaning no limit. Sizes
+// and levels always take a single value.
+func (o *ItemOption) MaxChoices() int {
+	if o.Type != OptionTypeAddon {
+		return 1
+	}
+	return o.MaxSelect
+}
+
+// CheckLimits validates the selection bounds of the option.
+func (o *ItemOption) CheckLimits() error {
+	if o.MinSelect < 0 || o.MaxSelect < 0 {
+		return fmt.Errorf("%w: min_select and max_select must not be negative", ErrInvalidOption)
+	}
+	if o.Type != OptionTypeAddon && (o.MinSelect > 1 || o.MaxSelect > 1) {
+		return fmt.Errorf("%w: %s options take a single value", ErrInvalidOption, o.Type)
+	}
+	if max := o.MaxChoices(); max > 0 && o.MinChoices() > max {
+		return fmt.Errorf("%w: min_select must not exceed max_select", ErrInvalidOption)
+	}
+	return nil
 }
@@ -1,8 +1,14 @@
 package domain
 
+// ItemOptionValue is one value of an option. A guest may pick it up to MaxQty
+// times (e.g. double cheese); IsDefault values are preselected when an order
+// line leaves the option out.
 type ItemOptionValue struct {
 	ID         string `json:"id"          db:"id"          gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
 	OptionID   string `json:"option_id"   db:"option_id"   gorm:"type:uuid;index"`
 	Label      string `json:"label"       db:"label"       gorm:"not null"`
 	DeltaPrice int64  `json:"delta_price" db:"delta_price" gorm:"default:0"`
+	MaxQty     int    `json:"max_qty"     db:"max_qty"     gorm:"default:1"`
+	IsDefault  bool   `json:"is_default"  db:"is_default"  gorm:"default:false"`
+	Sort       int    `json:"sort"        db:"sort"        gorm:"default:0"`
 }
@@ -0,0 +1,36 @@
+package domain
+
+import "time"
+
+// KitchenStation is a preparation area (grill, bar, dessert...) that receives
+// its own tickets on the kitchen display.
+type KitchenStation struct {
+	ID        string    `json:"id"         db:"id"         gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	TenantID  string    `json:"tenant_id"  db:"tenant_id"  gorm:"type:uuid;index"`
+	Name      string    `json:"name"       db:"name"       gorm:"not null"`
+	Sort      int       `json:"sort"       db:"sort"       gorm:"default:0"`
+	IsActive  bool      `json:"is_active"  db:"is_active"  gorm:"default:true"`
+	CreatedAt time.Time `json:"created_at" db:"created_at" gorm:"autoCreateTime"`
+}
+
+type TicketStatus string
+
+const (
+	TicketOpen   TicketStatus = "open"
+	TicketBumped TicketStatus = "bumped"
+)
+
+// KitchenTicket groups the lines of one order that are prepared at one station.
+// Lines whose item and category have no station share a ticket with a nil StationID.
+type KitchenTicket struct {
+	ID        string       `json:"id"                   db:"id"         gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	TenantID  string       `json:"tenant_id"            db:"tenant_id"  gorm:"type:uuid;index"`
+	OrderID   string       `json:"order_id"             db:"order_id"   gorm:"type:uuid;index"`
+	TableID   string       `json:"table_id"             db:"table_id"   gorm:"type:uuid"`
+	StationID *string      `json:"station_id"           db:"station_id" gorm:"type:uuid;index"`
+	Status    TicketStatus `json:"status"               db:"status"     gorm:"type:text;default:'open';index"`
+	BumpedAt  *time.Time   `json:"bumped_at,omitempty"  db:"bumped_at"`
+	CreatedAt time.Time    `json:"created_at"           db:"created_at" gorm:"autoCreateTime"`
+
+	Items []OrderItem `json:"items,omitempty" gorm:"foreignKey:TicketID"`
+}
@@ -1,7 +1,45 @@
 package domain
 
+import "time"
+
 type MenuResponse struct {
-	Tenant     string     `json:"tenant"`
-	Categories []Category `json:"categories"`
-	Items      []Item     `json:"items"`
+	Tenant     string         `json:"tenant"`
+	Timezone   string         `json:"timezone"`
+	Categories []MenuCategory `json:"categories"`
+	Items      []MenuItem     `json:"items"`
+}
+
+// MenuCategory is a category as published to guests.
+type MenuCategory struct {
+	Category
+	Available bool `json:"available"`
+}
+
+// MenuItem is an item as published to guests, with everything needed to build an order line.
+type MenuItem struct {
+	Item
+	Options   []MenuItemOption `json:"options"`
+	Available bool             `json:"available"`
+}
+
+// MenuItemOption is an item option together with its selectable values.
+type MenuItemOption struct {
+	ItemOption
+	Values []ItemOptionValue `json:"values"`
+}
+
+// MarkAvailability flags which categories and items can be ordered at now,
+// which must already be in the menu's timezone. An item is available only when
+// both its own and its category's schedules allow it.
+func (m *MenuResponse) MarkAvailability(now time.Time) {
+	open := make(map[string]bool, len(m.Categories))
+	for i := range m.Categories {
+		c := &m.Categories[i]
+		c.Available = c.Availability.AvailableAt(now)
+		open[c.ID] = c.Available
+	}
+	for i := range m.Items {
+		it := &m.Items[i]
+		it.Available = open[it.CategoryID] && it.Availability.AvailableAt(now)
+	}
 }
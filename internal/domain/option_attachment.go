@@ -0,0 +1,37 @@
+package domain
+
+import "time"
+
+// OptionAttachment offers an option on one item or on every item of a category
+// (exactly one of ItemID and CategoryID is set). Non-nil overrides replace the
+// option's own selection rules and sort for that item or category; an item
+// attachment wins over its category's attachment of the same option.
+type OptionAttachment struct {
+	ID         string    `json:"id"          db:"id"          gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	TenantID   string    `json:"tenant_id"   db:"tenant_id"   gorm:"type:uuid;index"`
+	OptionID   string    `json:"option_id"   db:"option_id"   gorm:"type:uuid;index"`
+	ItemID     *string   `json:"item_id"     db:"item_id"     gorm:"type:uuid;index"`
+	CategoryID *string   `json:"category_id" db:"category_id" gorm:"type:uuid;index"`
+	Required   *bool     `json:"required"    db:"required"`
+	MinSelect  *int      `json:"min_select"  db:"min_select"`
+	MaxSelect  *int      `json:"max_select"  db:"max_select"`
+	Sort       *int      `json:"sort"        db:"sort"`
+	CreatedAt  time.Time `json:"created_at"  db:"created_at"  gorm:"autoCreateTime"`
+}
+
+// Apply returns o with the attachment's overrides.
+func (a *OptionAttachment) Apply(o ItemOption) ItemOption {
+	if a.Required != nil {
+		o.Required = *a.Required
+	}
+	if a.MinSelect != nil {
+		o.MinSelect = *a.MinSelect
+	}
+	if a.MaxSelect != nil {
+		o.MaxSelect = *a.MaxSelect
+	}
+	if a.Sort != nil {
+		o.Sort = *a.Sort
+	}
+	return o
+}
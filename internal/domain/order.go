@@ -17,29 +17,77 @@ const (
 
 type PaidStatus string
 const (
-	Unpaid PaidStatus = "unpaid"
-	Paid   PaidStatus = "paid"
+	Unpaid        PaidStatus = "unpaid"
+	PartiallyPaid PaidStatus = "partially_paid"
+	Paid          PaidStatus = "paid"
 )
 
 type Order struct {
 	ID           string      `json:"id"               db:"id"               gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
 	TenantID     string      `json:"tenant_id"        db:"tenant_id"        gorm:"type:uuid;index"`
 	TableID      string      `json:"table_id"         db:"table_id"         gorm:"type:uuid;index"`
+	SessionID    *string     `json:"session_id,omitempty" db:"session_id"   gorm:"type:uuid;index"`
 	GuestSession string      `json:"guest_session_id,omitempty" db:"guest_session_id"`
 	Note         *string     `json:"note,omitempty"   db:"note"`
 	Status       OrderStatus `json:"status"           db:"status"           gorm:"type:text;default:'waiting';index"`
 	PaidStatus   PaidStatus  `json:"paid_status"      db:"paid_status"      gorm:"type:text;default:'unpaid';index"`
+	Subtotal     int64       `json:"subtotal"         db:"subtotal"         gorm:"default:0"`
+	OptionsTotal int64       `json:"options_total"    db:"options_total"    gorm:"default:0"`
+	Total        int64       `json:"total"            db:"total"            gorm:"default:0"`
+	PaidAmount   int64       `json:"paid_amount"      db:"paid_amount"      gorm:"default:0"`
 	CreatedAt    time.Time   `json:"created_at"       db:"created_at"       gorm:"autoCreateTime"`
 
-	Items []OrderItem `json:"items,omitempty" gorm:"foreignKey:OrderID;constraint:OnDelete:CASCADE"`
+	Items   []OrderItem          `json:"items,omitempty"   gorm:"foreignKey:OrderID;constraint:OnDelete:CASCADE"`
+	History []OrderStatusHistory `json:"history,omitempty" gorm:"foreignKey:OrderID;constraint:OnDelete:CASCADE"`
 }
 
 type OrderItem struct {
-	ID        string            `json:"id"        db:"id"        gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
-	OrderID   string            `json:"order_id"  db:"order_id"  gorm:"type:uuid;index"`
-	ItemID    string            `json:"item_id"   db:"item_id"   gorm:"type:uuid;index"`
-	Name      string            `json:"name"      db:"name"`
-	Qty       int               `json:"qty"       db:"qty"`
-	UnitPrice int64             `json:"unit_price" db:"unit_price"`
-	Options   datatypes.JSONMap `json:"options,omitempty" db:"options" gorm:"type:jsonb"`
+	ID           string            `json:"id"        db:"id"        gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	OrderID      string            `json:"order_id"  db:"order_id"  gorm:"type:uuid;index"`
+	TicketID     *string           `json:"ticket_id,omitempty" db:"ticket_id" gorm:"type:uuid;index"`
+	ItemID       string            `json:"item_id"   db:"item_id"   gorm:"type:uuid;index"`
+	Name         string            `json:"name"      db:"name"`
+	Qty          int               `json:"qty"       db:"qty"`
+	UnitPrice    int64             `json:"unit_price" db:"unit_price"`
+	OptionsPrice int64             `json:"options_price" db:"options_price" gorm:"default:0"`
+	LineTotal    int64             `json:"line_total"    db:"line_total"    gorm:"default:0"`
+	Options      datatypes.JSONMap `json:"options,omitempty" db:"options" gorm:"type:jsonb"`
+	Status       OrderItemStatus   `json:"status"        db:"status"        gorm:"type:text;default:'queued'"`
+	VoidReason   *string           `json:"void_reason,omitempty" db:"void_reason"`
+
+	Selections []OrderItemSelection `json:"selections,omitempty" gorm:"foreignKey:OrderItemID;constraint:OnDelete:CASCADE"`
+}
+
+// Outstanding is the amount still owed on the order.
+func (o *Order) Outstanding() int64 {
+	if o.PaidAmount >= o.Total {
+		return 0
+	}
+	return o.Total - o.PaidAmount
+}
+
+// PaidStatusFor derives the paid status from the amount paid against the order total.
+func PaidStatusFor(paid, total int64) PaidStatus {
+	switch {
+	case paid <= 0:
+		return Unpaid
+	case paid < total:
+		return PartiallyPaid
+	default:
+		return Paid
+	}
+}
+
+// OrderItemSelection snapshots an option value chosen for an order line so the
+// kitchen and cashier keep seeing the label and price even if the menu changes later.
+// DeltaPrice is per unit of the value; Qty units are charged per item.
+type OrderItemSelection struct {
+	ID          string `json:"id"            db:"id"            gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	OrderItemID string `json:"order_item_id" db:"order_item_id" gorm:"type:uuid;index"`
+	OptionID    string `json:"option_id"     db:"option_id"     gorm:"type:uuid"`
+	OptionName  string `json:"option_name"   db:"option_name"`
+	ValueID     string `json:"value_id"      db:"value_id"      gorm:"type:uuid"`
+	ValueLabel  string `json:"value_label"   db:"value_label"`
+	Qty         int    `json:"qty"           db:"qty"           gorm:"default:1"`
+	DeltaPrice  int64  `json:"delta_price"   db:"delta_price"`
 }
@@ -0,0 +1,22 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// CheckGuestAmendable reports whether the guest who placed o may still add
+// items, change quantities or cancel it: the order must be waiting, unpaid and
+// younger than the tenant's amendment window.
+func (o *Order) CheckGuestAmendable(window time.Duration, now time.Time) error {
+	if o.Status != OrderWaiting {
+		return fmt.Errorf("%w: order is %s", ErrOrderNotAmendable, o.Status)
+	}
+	if o.PaidAmount > 0 {
+		return fmt.Errorf("%w: order already has payments", ErrOrderNotAmendable)
+	}
+	if now.Sub(o.CreatedAt) > window {
+		return fmt.Errorf("%w: the %s change window has passed", ErrOrderNotAmendable, window)
+	}
+	return nil
+}
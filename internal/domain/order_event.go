@@ -0,0 +1,48 @@
+package domain
+
+import "time"
+
+type OrderEventType string
+
+const (
+	OrderEventCreated       OrderEventType = "order.created"
+	OrderEventStatusChanged OrderEventType = "order.status_changed"
+	OrderEventItemChanged   OrderEventType = "order.item_changed"
+	OrderEventAmended       OrderEventType = "order.amended"
+	OrderEventMoved         OrderEventType = "order.moved"
+)
+
+// OrderEvent is pushed to live order feeds. ID is assigned by the event broker
+// when the event is published and is used by clients to resume a feed.
+type OrderEvent struct {
+	ID         string         `json:"id"`
+	Type       OrderEventType `json:"type"`
+	TenantID   string         `json:"tenant_id"`
+	OrderID    string         `json:"order_id"`
+	TableID    string         `json:"table_id"`
+	Status     OrderStatus    `json:"status"`
+	PaidStatus PaidStatus     `json:"paid_status"`
+	Total      int64          `json:"total"`
+	OccurredAt time.Time      `json:"occurred_at"`
+
+	// Set on order.item_changed events.
+	ItemID     string          `json:"item_id,omitempty"`
+	ItemStatus OrderItemStatus `json:"item_status,omitempty"`
+
+	// Set on order.moved events: the table the order was moved from.
+	FromTableID string `json:"from_table_id,omitempty"`
+}
+
+// NewOrderEvent snapshots the current state of o as an event of type t.
+func NewOrderEvent(t OrderEventType, o *Order) OrderEvent {
+	return OrderEvent{
+		Type:       t,
+		TenantID:   o.TenantID,
+		OrderID:    o.ID,
+		TableID:    o.TableID,
+		Status:     o.Status,
+		PaidStatus: o.PaidStatus,
+		Total:      o.Total,
+		OccurredAt: time.Now(),
+	}
+}
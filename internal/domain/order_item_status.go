@@ -0,0 +1,99 @@
+package domain
+
+// OrderItemStatus tracks the preparation of a single order line.
+type OrderItemStatus string
+
+const (
+	ItemQueued  OrderItemStatus = "queued"
+	ItemCooking OrderItemStatus = "cooking"
+	ItemReady   OrderItemStatus = "ready"
+	ItemServed  OrderItemStatus = "served"
+	ItemVoided  OrderItemStatus = "voided"
+)
+
+// itemTransitions lists the statuses a line may move to. Served and voided are
+// terminal; a ready line can go back to cooking when its ticket is recalled.
+var itemTransitions = map[OrderItemStatus][]OrderItemStatus{
+	ItemQueued:  {ItemCooking, ItemReady, ItemServed, ItemVoided},
+	ItemCooking: {ItemReady, ItemServed, ItemVoided},
+	ItemReady:   {ItemServed, ItemCooking, ItemVoided},
+	ItemServed:  nil,
+	ItemVoided:  nil,
+}
+
+// Valid reports whether s is a known line status.
+func (s OrderItemStatus) Valid() bool {
+	_, ok := itemTransitions[s]
+	return ok
+}
+
+// CanTransitionTo reports whether a line in status s may move to next.
+func (s OrderItemStatus) CanTransitionTo(next OrderItemStatus) bool {
+	for _, allowed := range itemTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// OrderItemStatusChange describes a requested line status change and who asked for it.
+type OrderItemStatusChange struct {
+	To        OrderItemStatus
+	ChangedBy string
+	Reason    string
+}
+
+// DeriveOrderStatus rolls the line statuses up into an order status, ignoring
+// voided lines: canceled when every line is voided, done when all are served,
+// delivering when all are ready (or served), processing once any line has
+// started. Finished and canceled orders keep their status.
+func DeriveOrderStatus(current OrderStatus, items []OrderItem) OrderStatus {
+	if len(items) == 0 || current == OrderDone || current == OrderCanceled {
+		return current
+	}
+	var active, started, ready, served int
+	for _, it := range items {
+		switch it.Status {
+		case ItemVoided:
+			continue
+		case ItemCooking:
+			started++
+		case ItemReady:
+			started++
+			ready++
+		case ItemServed:
+			started++
+			ready++
+			served++
+		}
+		active++
+	}
+	switch {
+	case active == 0:
+		return OrderCanceled
+	case served == active:
+		return OrderDone
+	case ready == active:
+		return OrderDelivering
+	case started > 0 || current != OrderWaiting:
+		return OrderProcessing
+	default:
+		return OrderWaiting
+	}
+}
+
+// CascadeLineStatus returns the status an order's unfinished lines take when
+// staff move the whole order to s, and the line statuses that change. Served and
+// voided lines keep their status; ok is false when s does not affect the lines.
+func CascadeLineStatus(s OrderStatus) (to OrderItemStatus, from []OrderItemStatus, ok bool) {
+	switch s {
+	case OrderDelivering:
+		return ItemReady, []OrderItemStatus{ItemQueued, ItemCooking}, true
+	case OrderDone:
+		return ItemServed, []OrderItemStatus{ItemQueued, ItemCooking, ItemReady}, true
+	case OrderCanceled:
+		return ItemVoided, []OrderItemStatus{ItemQueued, ItemCooking, ItemReady}, true
+	}
+	return "", nil, false
+}
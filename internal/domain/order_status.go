@@ -0,0 +1,83 @@
+package domain
+
+import "time"
+
+// orderTransitions is the order lifecycle: the statuses an order may move to
+// from each status. Done and canceled are terminal; a delivering order goes back
+// to processing when a kitchen ticket is recalled.
+var orderTransitions = map[OrderStatus][]OrderStatus{
+	OrderWaiting:    {OrderProcessing, OrderCanceled},
+	OrderProcessing: {OrderDelivering, OrderCanceled},
+	OrderDelivering: {OrderDone, OrderProcessing},
+	OrderDone:       nil,
+	OrderCanceled:   nil,
+}
+
+// Valid reports whether s is a known order status.
+func (s OrderStatus) Valid() bool {
+	_, ok := orderTransitions[s]
+	return ok
+}
+
+// CanTransitionTo reports whether an order in status s may move to next.
+func (s OrderStatus) CanTransitionTo(next OrderStatus) bool {
+	for _, allowed := range orderTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// StatusPath returns the shortest sequence of allowed transitions leading from
+// one status to another (excluding from), or nil when to is unreachable.
+func StatusPath(from, to OrderStatus) []OrderStatus {
+	if from == to {
+		return nil
+	}
+	prev := map[OrderStatus]OrderStatus{from: from}
+	queue := []OrderStatus{from}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for _, next := range orderTransitions[cur] {
+			if _, seen := prev[next]; seen {
+				continue
+			}
+			prev[next] = cur
+			if next == to {
+				var path []OrderStatus
+				for s := to; s != from; s = prev[s] {
+					path = append([]OrderStatus{s}, path...)
+				}
+				return path
+			}
+			queue = append(queue, next)
+		}
+	}
+	return nil
+}
+
+// OrderStatusChange describes a requested status transition and who asked for it.
+type OrderStatusChange struct {
+	To        OrderStatus
+	ChangedBy string
+	Reason    string
+}
+
+// OrderStatusHistory records one applied status transition of an order. A move
+// to another table is recorded with an unchanged status and FromTableID/ToTableID set.
+type OrderStatusHistory struct {
+	ID          string      `json:"id"          db:"id"          gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	OrderID     string      `json:"order_id"    db:"order_id"    gorm:"type:uuid;index"`
+	TenantID    string      `json:"tenant_id"   db:"tenant_id"   gorm:"type:uuid;index"`
+	FromStatus  OrderStatus `json:"from_status" db:"from_status" gorm:"type:text"`
+	ToStatus    OrderStatus `json:"to_status"   db:"to_status"   gorm:"type:text"`
+	FromTableID *string     `json:"from_table_id,omitempty" db:"from_table_id" gorm:"type:uuid"`
+	ToTableID   *string     `json:"to_table_id,omitempty"   db:"to_table_id"   gorm:"type:uuid"`
+	ChangedBy   *string     `json:"changed_by,omitempty" db:"changed_by"`
+	Reason      *string     `json:"reason,omitempty"     db:"reason"`
+	CreatedAt   time.Time   `json:"created_at"  db:"created_at"  gorm:"autoCreateTime"`
+}
+
+func (OrderStatusHistory) TableName() string { return "order_status_history" }
@@ -0,0 +1,72 @@
+package domain
+
+import "testing"
+
+func equalStatuses(a, b []OrderStatus) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestCanTransitionTo(t *testing.T) {
+	tests := []struct {
+		from, to OrderStatus
+		want     bool
+	}{
+		{OrderWaiting, OrderProcessing, true},
+		{OrderWaiting, OrderCanceled, true},
+		{OrderProcessing, OrderDelivering, true},
+		{OrderProcessing, OrderCanceled, true},
+		{OrderDelivering, OrderDone, true},
+		{OrderDelivering, OrderProcessing, true},
+		{OrderWaiting, OrderDelivering, false},
+		{OrderWaiting, OrderDone, false},
+		{OrderProcessing, OrderWaiting, false},
+		{OrderDelivering, OrderCanceled, false},
+		{OrderDone, OrderWaiting, false},
+		{OrderDone, OrderCanceled, false},
+		{OrderCanceled, OrderDelivering, false},
+		{OrderCanceled, OrderWaiting, false},
+		{OrderWaiting, OrderWaiting, false},
+		{OrderStatus("unknown"), OrderProcessing, false},
+	}
+	for _, tc := range tests {
+		t.Run(string(tc.from)+"->"+string(tc.to), func(t *testing.T) {
+			if got := tc.from.CanTransitionTo(tc.to); got != tc.want {
+				t.Fatalf("%s.CanTransitionTo(%s) = %v, want %v", tc.from, tc.to, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestStatusPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to OrderStatus
+		want     []OrderStatus
+	}{
+		{name: "same status", from: OrderProcessing, to: OrderProcessing, want: nil},
+		{name: "single step", from: OrderWaiting, to: OrderProcessing, want: []OrderStatus{OrderProcessing}},
+		{name: "waiting to done", from: OrderWaiting, to: OrderDone, want: []OrderStatus{OrderProcessing, OrderDelivering, OrderDone}},
+		{name: "waiting to delivering", from: OrderWaiting, to: OrderDelivering, want: []OrderStatus{OrderProcessing, OrderDelivering}},
+		{name: "shortest cancel", from: OrderWaiting, to: OrderCanceled, want: []OrderStatus{OrderCanceled}},
+		{name: "cancel through recall", from: OrderDelivering, to: OrderCanceled, want: []OrderStatus{OrderProcessing, OrderCanceled}},
+		{name: "done is terminal", from: OrderDone, to: OrderWaiting, want: nil},
+		{name: "canceled is terminal", from: OrderCanceled, to: OrderDelivering, want: nil},
+		{name: "no way back to waiting", from: OrderDelivering, to: OrderWaiting, want: nil},
+		{name: "unknown status", from: OrderStatus("unknown"), to: OrderDone, want: nil},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := StatusPath(tc.from, tc.to); !equalStatuses(got, tc.want) {
+				t.Fatalf("StatusPath(%s, %s) = %v, want %v", tc.from, tc.to, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package domain
+
+// OrderMove is one order moved to another table.
+type OrderMove struct {
+	Order       Order  `json:"order"`
+	FromTableID string `json:"from_table_id"`
+}
+
+// TableTransfer is the result of moving orders to a table, or merging a table
+// into it: the moved orders and the target table's tab that now holds them.
+type TableTransfer struct {
+	Moves []OrderMove `json:"moves"`
+	Tab   TableTab    `json:"tab"`
+}
@@ -0,0 +1,83 @@
+package domain
+
+import "time"
+
+type PaymentMethod string
+
+const (
+	PaymentCash     PaymentMethod = "cash"
+	PaymentCard     PaymentMethod = "card"
+	PaymentQRIS     PaymentMethod = "qris"
+	PaymentTransfer PaymentMethod = "transfer"
+)
+
+// Valid reports whether m is an accepted payment method.
+func (m PaymentMethod) Valid() bool {
+	switch m {
+	case PaymentCash, PaymentCard, PaymentQRIS, PaymentTransfer:
+		return true
+	}
+	return false
+}
+
+// Payment is one tender received by the cashier. A single payment can settle
+// several orders of the same table; Allocations record how much went to each.
+type Payment struct {
+	ID         string        `json:"id"          db:"id"          gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	TenantID   string        `json:"tenant_id"   db:"tenant_id"   gorm:"type:uuid;index"`
+	TableID    string        `json:"table_id"    db:"table_id"    gorm:"type:uuid;index"`
+	BillID     *string       `json:"bill_id,omitempty"     db:"bill_id"     gorm:"type:uuid;index"`
+	Method     PaymentMethod `json:"method"      db:"method"      gorm:"type:text"`
+	Amount     int64         `json:"amount"      db:"amount"`
+	Tendered   int64         `json:"tendered"    db:"tendered"`
+	Change     int64         `json:"change"      db:"change"`
+	Reference  *string       `json:"reference,omitempty"   db:"reference"`
+	ReceivedBy *string       `json:"received_by,omitempty" db:"received_by"`
+	CreatedAt  time.Time     `json:"created_at"  db:"created_at"  gorm:"autoCreateTime"`
+
+	Allocations []PaymentAllocation `json:"allocations,omitempty" gorm:"foreignKey:PaymentID;constraint:OnDelete:CASCADE"`
+}
+
+// PaymentAllocation is the part of a payment applied to one order.
+type PaymentAllocation struct {
+	ID        string `json:"id"         db:"id"         gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	PaymentID string `json:"payment_id" db:"payment_id" gorm:"type:uuid;index"`
+	OrderID   string `json:"order_id"   db:"order_id"   gorm:"type:uuid;index"`
+	Amount    int64  `json:"amount"     db:"amount"`
+}
+
+// PaymentRequest is what the cashier submits to settle one or more orders.
+// Amount defaults to the outstanding balance; Tendered defaults to Amount.
+type PaymentRequest struct {
+	Method     PaymentMethod
+	Amount     int64
+	Tendered   int64
+	Reference  string
+	ReceivedBy string
+}
+
+type PaymentIntentStatus string
+
+const (
+	IntentPending   PaymentIntentStatus = "pending"
+	IntentSucceeded PaymentIntentStatus = "succeeded"
+	IntentFailed    PaymentIntentStatus = "failed"
+	IntentExpired   PaymentIntentStatus = "expired"
+)
+
+// PaymentIntent tracks a guest payment started at an online payment provider.
+// When the provider confirms it, a Payment is recorded and linked via PaymentID.
+type PaymentIntent struct {
+	ID          string              `json:"id"           db:"id"           gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	TenantID    string              `json:"tenant_id"    db:"tenant_id"    gorm:"type:uuid;index"`
+	OrderID     string              `json:"order_id"     db:"order_id"     gorm:"type:uuid;index"`
+	Provider    string              `json:"provider"     db:"provider"     gorm:"uniqueIndex:idx_payment_intents_provider_ref"`
+	ProviderRef string              `json:"provider_ref" db:"provider_ref" gorm:"uniqueIndex:idx_payment_intents_provider_ref"`
+	Method      PaymentMethod       `json:"method"       db:"method"       gorm:"type:text"`
+	Amount      int64               `json:"amount"       db:"amount"`
+	Status      PaymentIntentStatus `json:"status"       db:"status"       gorm:"type:text;default:'pending'"`
+	CheckoutURL string              `json:"checkout_url" db:"checkout_url"`
+	PaymentID   *string             `json:"payment_id,omitempty" db:"payment_id" gorm:"type:uuid"`
+	CreatedAt   time.Time           `json:"created_at"   db:"created_at"   gorm:"autoCreateTime"`
+	UpdatedAt   time.Time           `json:"updated_at"   db:"updated_at"   gorm:"autoUpdateTime"`
+}
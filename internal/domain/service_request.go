@@ -0,0 +1,57 @@
+package domain
+
+import "time"
+
+type ServiceRequestType string
+
+const (
+	ServiceCallWaiter  ServiceRequestType = "call_waiter"
+	ServiceRequestBill ServiceRequestType = "request_bill"
+	ServiceCutlery     ServiceRequestType = "need_cutlery"
+	ServiceOther       ServiceRequestType = "other"
+)
+
+// Valid reports whether t is a known service request type.
+func (t ServiceRequestType) Valid() bool {
+	switch t {
+	case ServiceCallWaiter, ServiceRequestBill, ServiceCutlery, ServiceOther:
+		return true
+	}
+	return false
+}
+
+type ServiceRequestStatus string
+
+const (
+	ServiceOpen         ServiceRequestStatus = "open"
+	ServiceAcknowledged ServiceRequestStatus = "acknowledged"
+	ServiceResolved     ServiceRequestStatus = "resolved"
+)
+
+// CanTransitionTo reports whether staff may move a request from s to next.
+// Requests can be resolved without being acknowledged first.
+func (s ServiceRequestStatus) CanTransitionTo(next ServiceRequestStatus) bool {
+	switch s {
+	case ServiceOpen:
+		return next == ServiceAcknowledged || next == ServiceResolved
+	case ServiceAcknowledged:
+		return next == ServiceResolved
+	}
+	return false
+}
+
+// ServiceRequest is a guest's call for staff from a table (waiter, bill, cutlery...).
+type ServiceRequest struct {
+	ID             string               `json:"id"                        db:"id"              gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	TenantID       string               `json:"tenant_id"                 db:"tenant_id"       gorm:"type:uuid;index"`
+	TableID        string               `json:"table_id"                  db:"table_id"        gorm:"type:uuid;index"`
+	Type           ServiceRequestType   `json:"type"                      db:"type"            gorm:"type:text"`
+	Note           *string              `json:"note,omitempty"            db:"note"`
+	GuestSession   string               `json:"guest_session_id,omitempty" db:"guest_session_id"`
+	Status         ServiceRequestStatus `json:"status"                    db:"status"          gorm:"type:text;default:'open';index"`
+	CreatedAt      time.Time            `json:"created_at"                db:"created_at"      gorm:"autoCreateTime"`
+	AcknowledgedAt *time.Time           `json:"acknowledged_at,omitempty" db:"acknowledged_at"`
+	AcknowledgedBy *string              `json:"acknowledged_by,omitempty" db:"acknowledged_by" gorm:"type:uuid"`
+	ResolvedAt     *time.Time           `json:"resolved_at,omitempty"     db:"resolved_at"`
+	ResolvedBy     *string              `json:"resolved_by,omitempty"     db:"resolved_by"     gorm:"type:uuid"`
+}
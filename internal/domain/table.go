@@ -1,10 +1,21 @@
 package domain
 
 type Table struct {
-	ID       string `json:"id"         db:"id"         gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
-	TenantID string `json:"tenant_id"  db:"tenant_id"  gorm:"type:uuid;index"`
-	Code     string `json:"code"       db:"code"       gorm:"index"`
-	Name     string `json:"name"       db:"name"`
-	Token    string `json:"token"      db:"token"      gorm:"uniqueIndex;not null"`
-	IsActive bool   `json:"is_active"  db:"is_active"  gorm:"default:true;index"`
+	ID       string  `json:"id"         db:"id"         gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	TenantID string  `json:"tenant_id"  db:"tenant_id"  gorm:"type:uuid;index"`
+	AreaID   *string `json:"area_id"    db:"area_id"    gorm:"type:uuid;index"`
+	Code     string  `json:"code"       db:"code"       gorm:"index"`
+	Name     string  `json:"name"       db:"name"`
+	Token    string  `json:"token"      db:"token"      gorm:"uniqueIndex;not null"`
+	Capacity int     `json:"capacity"   db:"capacity"   gorm:"default:0"`
+	IsActive bool    `json:"is_active"  db:"is_active"  gorm:"default:true;index"`
+
+	// Floor plan placement within the area's plan, in plan units. A table
+	// without a position is not drawn on the plan.
+	Shape    TableShape `json:"shape"    db:"shape"    gorm:"type:text;default:'square'"`
+	PosX     *int       `json:"pos_x"    db:"pos_x"`
+	PosY     *int       `json:"pos_y"    db:"pos_y"`
+	Width    int        `json:"width"    db:"width"    gorm:"default:80"`
+	Height   int        `json:"height"   db:"height"   gorm:"default:80"`
+	Rotation int        `json:"rotation" db:"rotation" gorm:"default:0"`
 }
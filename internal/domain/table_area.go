@@ -0,0 +1,127 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// TableArea groups a tenant's tables (indoor, terrace, VIP). PlanWidth and
+// PlanHeight size the area's floor plan canvas in plan units (0 = no plan).
+type TableArea struct {
+	ID         string    `json:"id"          db:"id"          gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	TenantID   string    `json:"tenant_id"   db:"tenant_id"   gorm:"type:uuid;index"`
+	Name       string    `json:"name"        db:"name"`
+	Sort       int       `json:"sort"        db:"sort"        gorm:"default:0"`
+	PlanWidth  int       `json:"plan_width"  db:"plan_width"  gorm:"default:0"`
+	PlanHeight int       `json:"plan_height" db:"plan_height" gorm:"default:0"`
+	CreatedAt  time.Time `json:"created_at"  db:"created_at"  gorm:"autoCreateTime"`
+}
+
+// Contains reports whether the point (x, y) lies on the area's floor plan.
+// A zero plan dimension leaves that axis unbounded.
+func (a TableArea) Contains(x, y int) bool {
+	return (a.PlanWidth == 0 || x < a.PlanWidth) && (a.PlanHeight == 0 || y < a.PlanHeight)
+}
+
+type TableShape string
+
+const (
+	ShapeSquare    TableShape = "square"
+	ShapeRound     TableShape = "round"
+	ShapeRectangle TableShape = "rectangle"
+)
+
+// Valid reports whether s is a known table shape.
+func (s TableShape) Valid() bool {
+	switch s {
+	case ShapeSquare, ShapeRound, ShapeRectangle:
+		return true
+	}
+	return false
+}
+
+// TablePlacement moves one table on the floor plan. Nil fields are left unchanged;
+// ClearPosition takes the table off the plan.
+type TablePlacement struct {
+	TableID       string
+	AreaID        *string
+	ClearArea     bool
+	PosX, PosY    *int
+	ClearPosition bool
+	Width, Height *int
+	Rotation      *int
+	Shape         *TableShape
+}
+
+// Fields validates the placement and returns the table columns it updates.
+func (p TablePlacement) Fields() (map[string]any, error) {
+	f := map[string]any{}
+	switch {
+	case p.ClearArea:
+		f["area_id"] = nil
+	case p.AreaID != nil:
+		f["area_id"] = *p.AreaID
+	}
+	switch {
+	case p.ClearPosition:
+		f["pos_x"], f["pos_y"] = nil, nil
+	case p.PosX != nil || p.PosY != nil:
+		if p.PosX == nil || p.PosY == nil || *p.PosX < 0 || *p.PosY < 0 {
+			return nil, fmt.Errorf("table %s: pos_x and pos_y must be given together and be >= 0", p.TableID)
+		}
+		f["pos_x"], f["pos_y"] = *p.PosX, *p.PosY
+	}
+	if p.Width != nil {
+		if *p.Width <= 0 {
+			return nil, fmt.Errorf("table %s: width must be positive", p.TableID)
+		}
+		f["width"] = *p.Width
+	}
+	if p.Height != nil {
+		if *p.Height <= 0 {
+			return nil, fmt.Errorf("table %s: height must be positive", p.TableID)
+		}
+		f["height"] = *p.Height
+	}
+	if p.Rotation != nil {
+		f["rotation"] = ((*p.Rotation % 360) + 360) % 360
+	}
+	if p.Shape != nil {
+		if !p.Shape.Valid() {
+			return nil, fmt.Errorf("table %s: shape must be square, round or rectangle", p.TableID)
+		}
+		f["shape"] = *p.Shape
+	}
+	return f, nil
+}
+
+// FloorPlan is a tenant's areas with the tables placed in them.
+type FloorPlan struct {
+	Areas      []FloorPlanArea `json:"areas"`
+	Unassigned []Table         `json:"unassigned"`
+}
+
+type FloorPlanArea struct {
+	TableArea
+	Tables []Table `json:"tables"`
+}
+
+// NewFloorPlan groups tables under their areas, keeping the given order.
+func NewFloorPlan(areas []TableArea, tables []Table) FloorPlan {
+	plan := FloorPlan{Areas: make([]FloorPlanArea, len(areas)), Unassigned: []Table{}}
+	idx := make(map[string]int, len(areas))
+	for i, a := range areas {
+		plan.Areas[i] = FloorPlanArea{TableArea: a, Tables: []Table{}}
+		idx[a.ID] = i
+	}
+	for _, t := range tables {
+		if t.AreaID != nil {
+			if i, ok := idx[*t.AreaID]; ok {
+				plan.Areas[i].Tables = append(plan.Areas[i].Tables, t)
+				continue
+			}
+		}
+		plan.Unassigned = append(plan.Unassigned, t)
+	}
+	return plan
+}
@@ -0,0 +1,49 @@
+package domain
+
+import "time"
+
+type TableSessionStatus string
+
+const (
+	SessionOpen    TableSessionStatus = "open"
+	SessionBilling TableSessionStatus = "billing"
+	SessionClosed  TableSessionStatus = "closed"
+)
+
+// TableSession is one sitting at a table (an open tab). It is opened by the
+// first guest order, locked while the bill is being settled and closed once paid.
+// A table has at most one session that is not closed.
+type TableSession struct {
+	ID        string             `json:"id"                   db:"id"         gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	TenantID  string             `json:"tenant_id"            db:"tenant_id"  gorm:"type:uuid;index"`
+	TableID   string             `json:"table_id"             db:"table_id"   gorm:"type:uuid;uniqueIndex:idx_table_sessions_active_table,where:status <> 'closed'"`
+	Status    TableSessionStatus `json:"status"               db:"status"     gorm:"type:text;default:'open';index"`
+	OpenedAt  time.Time          `json:"opened_at"            db:"opened_at"  gorm:"autoCreateTime"`
+	BillingAt *time.Time         `json:"billing_at,omitempty" db:"billing_at"`
+	ClosedAt  *time.Time         `json:"closed_at,omitempty"  db:"closed_at"`
+	ClosedBy  *string            `json:"closed_by,omitempty"  db:"closed_by"`
+}
+
+// TableTab is the running bill of a session: its orders and money totals.
+// Canceled orders are listed but not charged.
+type TableTab struct {
+	Session     TableSession `json:"session"`
+	Orders      []Order      `json:"orders"`
+	Total       int64        `json:"total"`
+	Paid        int64        `json:"paid"`
+	Outstanding int64        `json:"outstanding"`
+}
+
+// NewTableTab sums the orders of a session into a tab.
+func NewTableTab(s TableSession, orders []Order) TableTab {
+	tab := TableTab{Session: s, Orders: orders}
+	for i := range orders {
+		if orders[i].Status == OrderCanceled {
+			continue
+		}
+		tab.Total += orders[i].Total
+		tab.Paid += orders[i].PaidAmount
+		tab.Outstanding += orders[i].Outstanding()
+	}
+	return tab
+}
@@ -0,0 +1,116 @@
+package domain
+
+import "time"
+
+// TableStatus is the occupancy state of a table shown to staff.
+type TableStatus string
+
+const (
+	TableFree            TableStatus = "free"
+	TableOrdering        TableStatus = "ordering"
+	TableEating          TableStatus = "eating"
+	TableAwaitingPayment TableStatus = "awaiting_payment"
+	TableNeedsCleaning   TableStatus = "needs_cleaning"
+)
+
+// Valid reports whether s is a known table status.
+func (s TableStatus) Valid() bool {
+	switch s {
+	case TableFree, TableOrdering, TableEating, TableAwaitingPayment, TableNeedsCleaning:
+		return true
+	}
+	return false
+}
+
+// TableStatusOverride is a status set by staff for a table. It holds only while
+// the table stays in the sitting (SessionID, the active or last session) and
+// derived status (BaseStatus) it was set against; any change makes it stale.
+type TableStatusOverride struct {
+	TableID    string      `json:"table_id"    db:"table_id"    gorm:"type:uuid;primaryKey"`
+	TenantID   string      `json:"tenant_id"   db:"tenant_id"   gorm:"type:uuid;index"`
+	Status     TableStatus `json:"status"      db:"status"      gorm:"type:text"`
+	BaseStatus TableStatus `json:"base_status" db:"base_status" gorm:"type:text"`
+	SessionID  *string     `json:"session_id,omitempty" db:"session_id" gorm:"type:uuid"`
+	SetBy      *string     `json:"set_by,omitempty"     db:"set_by"     gorm:"type:uuid"`
+	CreatedAt  time.Time   `json:"created_at"  db:"created_at"  gorm:"autoCreateTime"`
+}
+
+// TableActivity is what is known about a table's current (or last) sitting.
+type TableActivity struct {
+	Table Table
+	// Session is the table's active session, or its most recent closed one.
+	Session *TableSession
+	// Orders counts the non-canceled orders of an active session, Unserved
+	// those still waiting, processing or delivering.
+	Orders, Unserved   int
+	Total, Outstanding int64
+	// BillRequested is set while a guest's request_bill call is unresolved.
+	BillRequested bool
+	Override      *TableStatusOverride
+}
+
+// TableOccupancy is one row of the staff table overview.
+type TableOccupancy struct {
+	Table         Table       `json:"table"`
+	Status        TableStatus `json:"status"`
+	DerivedStatus TableStatus `json:"derived_status"`
+	Overridden    bool        `json:"overridden"`
+	SessionID     *string     `json:"session_id,omitempty"`
+	Since         *time.Time  `json:"since,omitempty"`
+	OpenOrders    int         `json:"open_orders"`
+	RunningTotal  int64       `json:"running_total"`
+	Outstanding   int64       `json:"outstanding"`
+}
+
+// DeriveTableStatus computes the status of a table from its orders and payments:
+//   - no sitting yet: free; last sitting closed: needs_cleaning
+//   - active sitting being billed, or a bill requested: awaiting_payment
+//   - orders still being prepared or served, or none yet: ordering
+//   - everything served: eating
+func DeriveTableStatus(a TableActivity) TableStatus {
+	s := a.Session
+	switch {
+	case s == nil:
+		return TableFree
+	case s.Status == SessionClosed:
+		return TableNeedsCleaning
+	case s.Status == SessionBilling || a.BillRequested:
+		return TableAwaitingPayment
+	case a.Orders == 0 || a.Unserved > 0:
+		return TableOrdering
+	default:
+		return TableEating
+	}
+}
+
+// NewTableOccupancy derives the table's status and applies a staff override
+// that still matches the current sitting and derived status.
+func NewTableOccupancy(a TableActivity) TableOccupancy {
+	derived := DeriveTableStatus(a)
+	occ := TableOccupancy{Table: a.Table, Status: derived, DerivedStatus: derived}
+	var sessionID *string
+	if a.Session != nil {
+		sessionID = &a.Session.ID
+		if a.Session.Status != SessionClosed {
+			occ.SessionID = sessionID
+			occ.Since = &a.Session.OpenedAt
+			occ.OpenOrders = a.Orders
+			occ.RunningTotal = a.Total
+			occ.Outstanding = a.Outstanding
+		} else {
+			occ.Since = a.Session.ClosedAt
+		}
+	}
+	if o := a.Override; o != nil && o.BaseStatus == derived && sameID(o.SessionID, sessionID) {
+		occ.Status = o.Status
+		occ.Overridden = true
+	}
+	return occ
+}
+
+func sameID(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -13,4 +13,14 @@ type Tenant struct {
 	LogoURL   *string            `json:"logo_url,omitempty" db:"logo_url"`
 	Theme     datatypes.JSONMap  `json:"theme,omitempty"    db:"theme"    gorm:"type:jsonb"`
 	CreatedAt time.Time          `json:"created_at"         db:"created_at" gorm:"autoCreateTime"`
+
+	// AmendWindowSeconds is how long after placing an order a guest may still change
+	// or cancel it while it is waiting (0 disables guest changes).
+	AmendWindowSeconds int `json:"amend_window_seconds" db:"amend_window_seconds" gorm:"default:300"`
+	// GuestURLTemplate is the link table QR codes point to, with {token} (and
+	// optionally {tenant}) placeholders; empty uses the server default.
+	GuestURLTemplate string `json:"guest_url_template" db:"guest_url_template" gorm:"default:''"`
+	// Timezone is the IANA zone menu availability schedules are read in; empty
+	// uses the server's time zone.
+	Timezone string `json:"timezone" db:"timezone" gorm:"default:''"`
 }
@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"qrmenu/internal/domain"
+	"qrmenu/internal/platform/logging"
+)
+
+// AdminBillsUseCase models the bill splitting operations used by the admin bills HTTP adapter.
+type AdminBillsUseCase interface {
+	Split(tenantID, tableID string, req domain.SplitRequest) ([]domain.Bill, error)
+	ListByTable(tenantID, tableID string) ([]domain.Bill, error)
+	Get(tenantID, id string) (*domain.Bill, error)
+	Clear(tenantID, tableID string) error
+	Pay(tenantID, id string, req domain.PaymentRequest) (*domain.Payment, error)
+}
+
+// AdminBillsHandler exposes split bills of a table and their payments.
+type AdminBillsHandler struct {
+	uc AdminBillsUseCase
+}
+
+// NewAdminBillsHandler wires the bill use case into a HTTP handler instance.
+func NewAdminBillsHandler(uc AdminBillsUseCase) *AdminBillsHandler {
+	return &AdminBillsHandler{uc: uc}
+}
+
+// splitReq is the JSON body of POST /admin/tables/:id/bills.
+type splitReq struct {
+	Mode   string `json:"mode"`
+	Count  int    `json:"count"`
+	Groups []struct {
+		Label   string   `json:"label"`
+		ItemIDs []string `json:"item_ids"`
+	} `json:"groups"`
+}
+
+func (r splitReq) toDomain() domain.SplitRequest {
+	req := domain.SplitRequest{Mode: domain.SplitMode(r.Mode), Count: r.Count}
+	for _, g := range r.Groups {
+		req.Groups = append(req.Groups, domain.SplitGroup{Label: g.Label, ItemIDs: g.ItemIDs})
+	}
+	return req
+}
+
+// POST /admin/tables/:id/bills
+func (h *AdminBillsHandler) Split(c *fiber.Ctx) error {
+	tenantID, _ := c.Locals("tenant_id").(string)
+	tableID := c.Params("id")
+
+	var body splitReq
+	if err := c.BodyParser(&body); err != nil {
+		logging.HandlerError(c, "AdminBills.Split", "failed to parse body", fiber.StatusBadRequest, "invalid_body", err, "tenant_id", tenantID, "table_id", tableID)
+		return fiber.ErrBadRequest
+	}
+
+	bills, err := h.uc.Split(tenantID, tableID, body.toDomain())
+	if err != nil {
+		return h.fail(c, "AdminBills.Split", err, "tenant_id", tenantID, "table_id", tableID)
+	}
+	logging.HandlerInfo(c, "AdminBills.Split", "bills created", fiber.StatusCreated, "bills_split", "tenant_id", tenantID, "table_id", tableID, "bills", len(bills))
+	return c.Status(fiber.StatusCreated).JSON(bills)
+}
+
+// GET /admin/tables/:id/bills
+func (h *AdminBillsHandler) List(c *fiber.Ctx) error {
+	tenantID, _ := c.Locals("tenant_id").(string)
+	tableID := c.Params("id")
+
+	bills, err := h.uc.ListByTable(tenantID, tableID)
+	if err != nil {
+		return h.fail(c, "AdminBills.List", err, "tenant_id", tenantID, "table_id", tableID)
+	}
+	logging.HandlerInfo(c, "AdminBills.List", "bills listed", fiber.StatusOK, "bills_listed", "tenant_id", tenantID, "table_id", tableID, "count", len(bills))
+	return c.JSON(bills)
+}
+
+// DELETE /admin/tables/:id/bills
+func (h *AdminBillsHandler) Clear(c *fiber.Ctx) error {
+	tenantID, _ := c.Locals("tenant_id").(string)
+	tableID := c.Params("id")
+
+	if err := h.uc.Clear(tenantID, tableID); err != nil {
+		return h.fail(c, "AdminBills.Clear", err, "tenant_id", tenantID, "table_id", tableID)
+	}
+	logging.HandlerInfo(c, "AdminBills.Clear", "bills cleared", fiber.StatusNoContent, "bills_cleared", "tenant_id", tenantID, "table_id", tableID)
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// GET /admin/bills/:id
+func (h *AdminBillsHandler) Get(c *fiber.Ctx) error {
+	tenantID, _ := c.Locals("tenant_id").(string)
+	id := c.Params("id")
+
+	b, err := h.uc.Get(tenantID, id)
+	if err != nil {
+		return h.fail(c, "AdminBills.Get", err, "tenant_id", tenantID, "bill_id", id)
+	}
+	logging.HandlerInfo(c, "AdminBills.Get", "bill returned", fiber.StatusOK, "bill_returned", "tenant_id", tenantID, "bill_id", id)
+	return c.JSON(b)
+}
+
+// POST /admin/bills/:id/payments
+func (h *AdminBillsHandler) Pay(c *fiber.Ctx) error {
+	tenantID, _ := c.Locals("tenant_id").(string)
+	adminID, _ := c.Locals("admin_id").(string)
+	id := c.Params("id")
+
+	var body paymentReq
+	if err := c.BodyParser(&body); err != nil {
+		logging.HandlerError(c, "AdminBills.Pay", "failed to parse body", fiber.StatusBadRequest, "invalid_body", err, "tenant_id", tenantID, "bill_id", id)
+		return fiber.ErrBadRequest
+	}
+
+	p, err := h.uc.Pay(tenantID, id, body.toDomain(adminID))
+	if err != nil {
+		return h.fail(c, "AdminBills.Pay", err, "tenant_id", tenantID, "bill_id", id)
+	}
+	logging.HandlerInfo(c, "AdminBills.Pay", "bill payment recorded", fiber.StatusCreated, "bill_payment_recorded", "tenant_id", tenantID, "bill_id", id, "payment_id", p.ID)
+	return c.Status(fiber.StatusCreated).JSON(p)
+}
+
+func (h *AdminBillsHandler) fail(c *fiber.Ctx, scope string, err error, kv ...any) error {
+	if code, errCode, ok := lookupDomainError(err); ok {
+		logging.HandlerError(c, scope, "bill request rejected", code, errCode, err, kv...)
+		return c.Status(code).JSON(domainErrorBody(errCode, err))
+	}
+	logging.HandlerError(c, scope, "service error", fiber.StatusBadRequest, "bill_request_failed", err, kv...)
+	return fiber.ErrBadRequest
+}
@@ -0,0 +1,188 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+
+	"qrmenu/internal/domain"
+	"qrmenu/internal/platform/logging"
+)
+
+// FloorPlanUseCase models the area and floor plan operations used by the admin HTTP adapter.
+type FloorPlanUseCase interface {
+	ListAreas(tenantID string) ([]domain.TableArea, error)
+	CreateArea(tenantID string, body map[string]any) (*domain.TableArea, error)
+	PatchArea(tenantID, id string, body map[string]any) (*domain.TableArea, error)
+	DeleteArea(tenantID, id string) error
+	Plan(tenantID string) (*domain.FloorPlan, error)
+	Place(tenantID string, placements []domain.TablePlacement) (*domain.FloorPlan, error)
+}
+
+// AdminFloorPlanHandler exposes table areas and the floor plan.
+type AdminFloorPlanHandler struct {
+	uc FloorPlanUseCase
+}
+
+// NewAdminFloorPlanHandler wires the floor plan use case into a HTTP handler instance.
+func NewAdminFloorPlanHandler(uc FloorPlanUseCase) *AdminFloorPlanHandler {
+	return &AdminFloorPlanHandler{uc: uc}
+}
+
+// GET /admin/areas
+func (h *AdminFloorPlanHandler) ListAreas(c *fiber.Ctx) error {
+	tenantID, _ := c.Locals("tenant_id").(string)
+
+	xs, err := h.uc.ListAreas(tenantID)
+	if err != nil {
+		logging.HandlerError(c, "AdminFloorPlan.ListAreas", "service error", fiber.StatusBadRequest, "areas_list_failed", err, "tenant_id", tenantID)
+		return fiber.ErrBadRequest
+	}
+	logging.HandlerInfo(c, "AdminFloorPlan.ListAreas", "areas listed", fiber.StatusOK, "areas_listed", "tenant_id", tenantID, "count", len(xs))
+	return c.JSON(xs)
+}
+
+// POST /admin/areas  {"name": "Terrace", "sort": 1, "plan_width": 1200, "plan_height": 800}
+func (h *AdminFloorPlanHandler) CreateArea(c *fiber.Ctx) error {
+	tenantID, _ := c.Locals("tenant_id").(string)
+
+	var payload map[string]any
+	if err := c.BodyParser(&payload); err != nil {
+		logging.HandlerError(c, "AdminFloorPlan.CreateArea", "failed to parse body", fiber.StatusBadRequest, "invalid_body", err, "tenant_id", tenantID)
+		return fiber.ErrBadRequest
+	}
+
+	a, err := h.uc.CreateArea(tenantID, payload)
+	if err != nil {
+		return h.fail(c, "AdminFloorPlan.CreateArea", "area_create_failed", err, "tenant_id", tenantID)
+	}
+	logging.HandlerInfo(c, "AdminFloorPlan.CreateArea", "area created", fiber.StatusCreated, "area_created", "tenant_id", tenantID, "area_id", a.ID)
+	return c.Status(fiber.StatusCreated).JSON(a)
+}
+
+// PATCH /admin/areas/:id
+func (h *AdminFloorPlanHandler) PatchArea(c *fiber.Ctx) error {
+	tenantID, _ := c.Locals("tenant_id").(string)
+	id := c.Params("id")
+
+	var payload map[string]any
+	if err := c.BodyParser(&payload); err != nil {
+		logging.HandlerError(c, "AdminFloorPlan.PatchArea", "failed to parse body", fiber.StatusBadRequest, "invalid_body", err, "tenant_id", tenantID, "area_id", id)
+		return fiber.ErrBadRequest
+	}
+
+	a, err := h.uc.PatchArea(tenantID, id, payload)
+	if err != nil {
+		return h.fail(c, "AdminFloorPlan.PatchArea", "area_patch_failed", err, "tenant_id", tenantID, "area_id", id)
+	}
+	logging.HandlerInfo(c, "AdminFloorPlan.PatchArea", "area patched", fiber.StatusOK, "area_patched", "tenant_id", tenantID, "area_id", id)
+	return c.JSON(a)
+}
+
+// DELETE /admin/areas/:id
+func (h *AdminFloorPlanHandler) DeleteArea(c *fiber.Ctx) error {
+	tenantID, _ := c.Locals("tenant_id").(string)
+	id := c.Params("id")
+
+	if err := h.uc.DeleteArea(tenantID, id); err != nil {
+		return h.fail(c, "AdminFloorPlan.DeleteArea", "area_delete_failed", err, "tenant_id", tenantID, "area_id", id)
+	}
+	logging.HandlerInfo(c, "AdminFloorPlan.DeleteArea", "area deleted", fiber.StatusNoContent, "area_deleted", "tenant_id", tenantID, "area_id", id)
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// GET /admin/floor-plan
+func (h *AdminFloorPlanHandler) Plan(c *fiber.Ctx) error {
+	tenantID, _ := c.Locals("tenant_id").(string)
+
+	plan, err := h.uc.Plan(tenantID)
+	if err != nil {
+		logging.HandlerError(c, "AdminFloorPlan.Plan", "service error", fiber.StatusBadRequest, "floor_plan_failed", err, "tenant_id", tenantID)
+		return fiber.ErrBadRequest
+	}
+	logging.HandlerInfo(c, "AdminFloorPlan.Plan", "floor plan returned", fiber.StatusOK, "floor_plan_returned", "tenant_id", tenantID, "areas", len(plan.Areas))
+	return c.JSON(plan)
+}
+
+// placementReq is one table of a floor plan update. area_id, pos_x and pos_y
+// distinguish an explicit null (clear) from an omitted key (keep).
+type placementReq struct {
+	ID       string             `json:"id"`
+	AreaID   json.RawMessage    `json:"area_id"`
+	PosX     json.RawMessage    `json:"pos_x"`
+	PosY     json.RawMessage    `json:"pos_y"`
+	Width    *int               `json:"width"`
+	Height   *int               `json:"height"`
+	Rotation *int               `json:"rotation"`
+	Shape    *domain.TableShape `json:"shape"`
+}
+
+func (p placementReq) toDomain() (domain.TablePlacement, error) {
+	out := domain.TablePlacement{TableID: p.ID, Width: p.Width, Height: p.Height, Rotation: p.Rotation, Shape: p.Shape}
+	if p.ID == "" {
+		return out, fmt.Errorf("id is required")
+	}
+	if isJSONNull(p.AreaID) {
+		out.ClearArea = true
+	} else if len(p.AreaID) > 0 {
+		if err := json.Unmarshal(p.AreaID, &out.AreaID); err != nil {
+			return out, fmt.Errorf("area_id must be a string")
+		}
+	}
+	if isJSONNull(p.PosX) && isJSONNull(p.PosY) {
+		out.ClearPosition = true
+		return out, nil
+	}
+	for _, pos := range []struct {
+		raw json.RawMessage
+		dst **int
+	}{{p.PosX, &out.PosX}, {p.PosY, &out.PosY}} {
+		if len(pos.raw) > 0 {
+			if err := json.Unmarshal(pos.raw, pos.dst); err != nil {
+				return out, fmt.Errorf("pos_x and pos_y must be whole numbers")
+			}
+		}
+	}
+	return out, nil
+}
+
+func isJSONNull(raw json.RawMessage) bool { return string(raw) == "null" }
+
+// PUT /admin/floor-plan  {"tables": [{"id": "...", "area_id": "...", "pos_x": 120, "pos_y": 40, "shape": "round"}]}
+func (h *AdminFloorPlanHandler) Place(c *fiber.Ctx) error {
+	tenantID, _ := c.Locals("tenant_id").(string)
+
+	var body struct {
+		Tables []placementReq `json:"tables"`
+	}
+	if err := c.BodyParser(&body); err != nil {
+		logging.HandlerError(c, "AdminFloorPlan.Place", "failed to parse body", fiber.StatusBadRequest, "invalid_body", err, "tenant_id", tenantID)
+		return fiber.ErrBadRequest
+	}
+	placements := make([]domain.TablePlacement, 0, len(body.Tables))
+	for _, p := range body.Tables {
+		pl, err := p.toDomain()
+		if err != nil {
+			logging.HandlerError(c, "AdminFloorPlan.Place", "invalid placement", fiber.StatusBadRequest, "invalid_body", err, "tenant_id", tenantID)
+			return fiber.ErrBadRequest
+		}
+		placements = append(placements, pl)
+	}
+
+	plan, err := h.uc.Place(tenantID, placements)
+	if err != nil {
+		return h.fail(c, "AdminFloorPlan.Place", "floor_plan_update_failed", err, "tenant_id", tenantID)
+	}
+	logging.HandlerInfo(c, "AdminFloorPlan.Place", "floor plan updated", fiber.StatusOK, "floor_plan_updated", "tenant_id", tenantID, "tables", len(placements))
+	return c.JSON(plan)
+}
+
+func (h *AdminFloorPlanHandler) fail(c *fiber.Ctx, scope, errCode string, err error, kv ...any) error {
+	if code, domainCode, ok := lookupDomainError(err); ok {
+		logging.HandlerError(c, scope, "floor plan request rejected", code, domainCode, err, kv...)
+		return c.Status(code).JSON(domainErrorBody(domainCode, err))
+	}
+	logging.HandlerError(c, scope, "service error", fiber.StatusBadRequest, errCode, err, kv...)
+	return fiber.ErrBadRequest
+}
@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"qrmenu/internal/domain"
+	"qrmenu/internal/platform/logging"
+)
+
+// AdminKitchenUseCase models the station and kitchen display operations used by the admin kitchen HTTP adapter.
+type AdminKitchenUseCase interface {
+	ListStations(tenantID string) ([]domain.KitchenStation, error)
+	CreateStation(tenantID string, body map[string]any) (*domain.KitchenStation, error)
+	PatchStation(tenantID, id string, body map[string]any) (*domain.KitchenStation, error)
+	DeleteStation(tenantID, id string) error
+
+	ListTickets(tenantID, stationID, status string) ([]domain.KitchenTicket, error)
+	Bump(tenantID, id, adminID string) (*domain.KitchenTicket, error)
+	Recall(tenantID, id, adminID string) (*domain.KitchenTicket, error)
+}
+
+// AdminKitchenHandler exposes kitchen station management and the KDS endpoints.
+type AdminKitchenHandler struct {
+	uc AdminKitchenUseCase
+}
+
+// NewAdminKitchenHandler wires the kitchen use case into a HTTP handler instance.
+func NewAdminKitchenHandler(uc AdminKitchenUseCase) *AdminKitchenHandler {
+	return &AdminKitchenHandler{uc: uc}
+}
+
+// ListStations returns the tenant's kitchen stations.
+func (h *AdminKitchenHandler) ListStations(c *fiber.Ctx) error {
+	tenantID, _ := c.Locals("tenant_id").(string)
+
+	xs, err := h.uc.ListStations(tenantID)
+	if err != nil {
+		logging.HandlerError(c, "AdminKitchen.ListStations", "service error", fiber.StatusBadRequest, "stations_list_failed", err, "tenant_id", tenantID)
+		return fiber.ErrBadRequest
+	}
+	logging.HandlerInfo(c, "AdminKitchen.ListStations", "stations listed", fiber.StatusOK, "stations_listed", "tenant_id", tenantID, "count", len(xs))
+	return c.JSON(xs)
+}
+
+// CreateStation adds a kitchen station.
+func (h *AdminKitchenHandler) CreateStation(c *fiber.Ctx) error {
+	tenantID, _ := c.Locals("tenant_id").(string)
+
+	var payload map[string]any
+	if err := c.BodyParser(&payload); err != nil {
+		logging.HandlerError(c, "AdminKitchen.CreateStation", "failed to parse body", fiber.StatusBadRequest, "invalid_body", err, "tenant_id", tenantID)
+		return fiber.ErrBadRequest
+	}
+
+	st, err := h.uc.CreateStation(tenantID, payload)
+	if err != nil {
+		logging.HandlerError(c, "AdminKitchen.CreateStation", "service error", fiber.StatusBadRequest, "station_create_failed", err, "tenant_id", tenantID)
+		return fiber.ErrBadRequest
+	}
+	logging.HandlerInfo(c, "AdminKitchen.CreateStation", "station created", fiber.StatusCreated, "station_created", "tenant_id", tenantID, "station_id", st.ID)
+	return c.Status(fiber.StatusCreated).JSON(st)
+}
+
+// PatchStation renames, reorders or (de)activates a station.
+func (h *AdminKitchenHandler) PatchStation(c *fiber.Ctx) error {
+	tenantID, _ := c.Locals("tenant_id").(string)
+	id := c.Params("id")
+
+	var payload map[string]any
+	if err := c.BodyParser(&payload); err != nil {
+		logging.HandlerError(c, "AdminKitchen.PatchStation", "failed to parse body", fiber.StatusBadRequest, "invalid_body", err, "tenant_id", tenantID, "station_id", id)
+		return fiber.ErrBadRequest
+	}
+
+	st, err := h.uc.PatchStation(tenantID, id, payload)
+	if err != nil {
+		if code, errCode, ok := lookupDomainError(err); ok {
+			logging.HandlerError(c, "AdminKitchen.PatchStation", "station rejected", code, errCode, err, "tenant_id", tenantID, "station_id", id)
+			return c.Status(code).JSON(domainErrorBody(errCode, err))
+		}
+		logging.HandlerError(c, "AdminKitchen.PatchStation", "service error", fiber.StatusBadRequest, "station_patch_failed", err, "tenant_id", tenantID, "station_id", id)
+		return fiber.ErrBadRequest
+	}
+	logging.HandlerInfo(c, "AdminKitchen.PatchStation", "station patched", fiber.StatusOK, "station_patched", "tenant_id", tenantID, "station_id", id)
+	return c.JSON(st)
+}
+
+// DeleteStation removes a station.
+func (h *AdminKitchenHandler) DeleteStation(c *fiber.Ctx) error {
+	tenantID, _ := c.Locals("tenant_id").(string)
+	id := c.Params("id")
+
+	if err := h.uc.DeleteStation(tenantID, id); err != nil {
+		if code, errCode, ok := lookupDomainError(err); ok {
+			logging.HandlerError(c, "AdminKitchen.DeleteStation", "station rejected", code, errCode, err, "tenant_id", tenantID, "station_id", id)
+			return c.Status(code).JSON(domainErrorBody(errCode, err))
+		}
+		logging.HandlerError(c, "AdminKitchen.DeleteStation", "service error", fiber.StatusBadRequest, "station_delete_failed", err, "tenant_id", tenantID, "station_id", id)
+		return fiber.ErrBadRequest
+	}
+	logging.HandlerInfo(c, "AdminKitchen.DeleteStation", "station deleted", fiber.StatusNoContent, "station_deleted", "tenant_id", tenantID, "station_id", id)
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// GET /admin/kds/tickets?station_id=&status=open
+// The status defaults to open; pass status=all to include bumped tickets.
+func (h *AdminKitchenHandler) ListTickets(c *fiber.Ctx) error {
+	tenantID, _ := c.Locals("tenant_id").(string)
+	stationID := c.Query("station_id")
+	status := c.Query("status", string(domain.TicketOpen))
+	if status == "all" {
+		status = ""
+	}
+
+	xs, err := h.uc.ListTickets(tenantID, stationID, status)
+	if err != nil {
+		if code, errCode, ok := lookupDomainError(err); ok {
+			logging.HandlerError(c, "AdminKitchen.ListTickets", "query rejected", code, errCode, err, "tenant_id", tenantID, "station_id", stationID)
+			return c.Status(code).JSON(domainErrorBody(errCode, err))
+		}
+		logging.HandlerError(c, "AdminKitchen.ListTickets", "service error", fiber.StatusBadRequest, "tickets_list_failed", err, "tenant_id", tenantID, "station_id", stationID)
+		return fiber.ErrBadRequest
+	}
+	logging.HandlerInfo(c, "AdminKitchen.ListTickets", "tickets listed", fiber.StatusOK, "tickets_listed", "tenant_id", tenantID, "station_id", stationID, "count", len(xs))
+	return c.JSON(xs)
+}
+
+// POST /admin/kds/tickets/:id/bump
+func (h *AdminKitchenHandler) Bump(c *fiber.Ctx) error {
+	return h.setTicketStatus(c, "AdminKitchen.Bump", h.uc.Bump)
+}
+
+// POST /admin/kds/tickets/:id/recall
+func (h *AdminKitchenHandler) Recall(c *fiber.Ctx) error {
+	return h.setTicketStatus(c, "AdminKitchen.Recall", h.uc.Recall)
+}
+
+func (h *AdminKitchenHandler) setTicketStatus(c *fiber.Ctx, scope string, action func(tenantID, id, adminID string) (*domain.KitchenTicket, error)) error {
+	tenantID, _ := c.Locals("tenant_id").(string)
+	adminID, _ := c.Locals("admin_id").(string)
+	id := c.Params("id")
+
+	t, err := action(tenantID, id, adminID)
+	if err != nil {
+		if code, errCode, ok := lookupDomainError(err); ok {
+			logging.HandlerError(c, scope, "ticket update rejected", code, errCode, err, "tenant_id", tenantID, "ticket_id", id)
+			return c.Status(code).JSON(domainErrorBody(errCode, err))
+		}
+		logging.HandlerError(c, scope, "service error", fiber.StatusBadRequest, "ticket_update_failed", err, "tenant_id", tenantID, "ticket_id", id)
+		return fiber.ErrBadRequest
+	}
+	logging.HandlerInfo(c, scope, "ticket updated", fiber.StatusOK, "ticket_updated", "tenant_id", tenantID, "ticket_id", id, "status", t.Status)
+	return c.JSON(t)
+}
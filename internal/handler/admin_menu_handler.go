@@ -23,12 +23,19 @@ type AdminMenuUseCase interface {
 	DeleteItem(tenantID, id string) error
 	ToggleOOS(tenantID, id string, isActive bool) (*domain.Item, error)
 
+	ListOptions(tenantID string) ([]domain.ItemOption, error)
+	CreateOption(tenantID string, body map[string]any) (*domain.ItemOption, error)
 	ListItemOptions(itemID, tenantID string) ([]domain.ItemOption, error)
 	CreateItemOption(itemID, tenantID string, body map[string]any) (*domain.ItemOption, error)
+	PatchItemOption(optionID, tenantID string, body map[string]any) (*domain.ItemOption, error)
+	DeleteItemOption(optionID, tenantID string) error
 	ListOptionValues(optionID, tenantID string) ([]domain.ItemOptionValue, error)
 	CreateOptionValue(optionID, tenantID string, body map[string]any) (*domain.ItemOptionValue, error)
-
-	GenerateTableQR(tableID, tenantID string) (string, error)
+	PatchOptionValue(optionID, valueID, tenantID string, body map[string]any) (*domain.ItemOptionValue, error)
+	DeleteOptionValue(optionID, valueID, tenantID string) error
+	ListAttachments(optionID, tenantID string) ([]domain.OptionAttachment, error)
+	Attach(optionID, tenantID string, body map[string]any) (*domain.OptionAttachment, error)
+	Detach(optionID, attachmentID, tenantID string) error
 }
 
 // AdminMenuHandler exposes HTTP handlers that orchestrate admin menu use cases.
@@ -43,33 +50,47 @@ func NewAdminMenuHandler(uc AdminMenuUseCase) *AdminMenuHandler {
 
 // categoryResponse describes the JSON payload returned for category endpoints.
 type categoryResponse struct {
-	ID       string `json:"id"`
-	TenantID string `json:"tenant_id"`
-	Name     string `json:"name"`
-	Sort     int    `json:"sort"`
-	IsActive bool   `json:"is_active"`
+	ID           string              `json:"id"`
+	TenantID     string              `json:"tenant_id"`
+	Name         string              `json:"name"`
+	Sort         int                 `json:"sort"`
+	IsActive     bool                `json:"is_active"`
+	StationID    *string             `json:"station_id"`
+	Availability domain.Availability `json:"availability"`
 }
 
 // itemResponse describes the JSON payload returned for item endpoints.
 type itemResponse struct {
-	ID          string            `json:"id"`
-	TenantID    string            `json:"tenant_id"`
-	CategoryID  string            `json:"category_id"`
-	Name        string            `json:"name"`
-	Description *string           `json:"description"`
-	Price       int64             `json:"price"`
-	PhotoURL    *string           `json:"photo_url"`
-	Flags       datatypes.JSONMap `json:"flags,omitempty"`
-	IsActive    bool              `json:"is_active"`
+	ID           string              `json:"id"`
+	TenantID     string              `json:"tenant_id"`
+	CategoryID   string              `json:"category_id"`
+	Name         string              `json:"name"`
+	Description  *string             `json:"description"`
+	Price        int64               `json:"price"`
+	PhotoURL     *string             `json:"photo_url"`
+	Flags        datatypes.JSONMap   `json:"flags,omitempty"`
+	IsActive     bool                `json:"is_active"`
+	StationID    *string             `json:"station_id"`
+	Availability domain.Availability `json:"availability"`
 }
 
 // optionResponse describes the JSON payload returned for item option endpoints.
 type optionResponse struct {
-	ID       string `json:"id"`
-	ItemID   string `json:"item_id"`
-	Name     string `json:"name"`
-	Type     string `json:"type"`
-	Required bool   `json:"required"`
+	ID        string `json:"id"`
+	TenantID  string `json:"tenant_id"`
+	Name      string `json:"name"`
+	Type      string `json:"type"`
+	Required  bool   `json:"required"`
+	MinSelect int    `json:"min_select"`
+	MaxSelect int    `json:"max_select"`
+	Sort      int    `json:"sort"`
+}
+
+// itemOptionResponse is an option returned by the item-scoped option endpoints;
+// item_id is the item of the route, kept for clients of per-item options.
+type itemOptionResponse struct {
+	optionResponse
+	ItemID string `json:"item_id"`
 }
 
 // optionValueResponse describes the JSON payload returned for option value endpoints.
@@ -78,6 +99,21 @@ type optionValueResponse struct {
 	OptionID   string `json:"option_id"`
 	Label      string `json:"label"`
 	DeltaPrice int64  `json:"delta_price"`
+	MaxQty     int    `json:"max_qty"`
+	IsDefault  bool   `json:"is_default"`
+	Sort       int    `json:"sort"`
+}
+
+// attachmentResponse describes the JSON payload returned for option attachment endpoints.
+type attachmentResponse struct {
+	ID         string  `json:"id"`
+	OptionID   string  `json:"option_id"`
+	ItemID     *string `json:"item_id"`
+	CategoryID *string `json:"category_id"`
+	Required   *bool   `json:"required"`
+	MinSelect  *int    `json:"min_select"`
+	MaxSelect  *int    `json:"max_select"`
+	Sort       *int    `json:"sort"`
 }
 
 // ListCategories returns all categories owned by the authenticated tenant.
@@ -111,8 +147,7 @@ func (h *AdminMenuHandler) CreateCategory(c *fiber.Ctx) error {
 
 	cat, err := h.uc.CreateCategory(tenantID, payload)
 	if err != nil {
-		logging.HandlerError(c, "AdminMenu.CreateCategory", "service error", fiber.StatusBadRequest, "category_create_failed", err, "tenant_id", tenantID)
-		return fiber.ErrBadRequest
+		return h.fail(c, "AdminMenu.CreateCategory", "category_create_failed", err, "tenant_id", tenantID)
 	}
 
 	resp := newCategoryResponse(*cat)
@@ -133,8 +168,7 @@ func (h *AdminMenuHandler) ReplaceCategory(c *fiber.Ctx) error {
 
 	cat, err := h.uc.ReplaceCategory(tenantID, categoryID, payload)
 	if err != nil {
-		logging.HandlerError(c, "AdminMenu.ReplaceCategory", "service error", fiber.StatusBadRequest, "category_replace_failed", err, "tenant_id", tenantID, "category_id", categoryID)
-		return fiber.ErrBadRequest
+		return h.fail(c, "AdminMenu.ReplaceCategory", "category_replace_failed", err, "tenant_id", tenantID, "category_id", categoryID)
 	}
 
 	resp := newCategoryResponse(*cat)
@@ -301,6 +335,45 @@ func (h *AdminMenuHandler) ToggleOOS(c *fiber.Ctx) error {
 	return c.JSON(resp)
 }
 
+// ListOptions returns the tenant's options (modifier groups), attached or not.
+func (h *AdminMenuHandler) ListOptions(c *fiber.Ctx) error {
+	tenantID, _ := c.Locals("tenant_id").(string)
+
+	opts, err := h.uc.ListOptions(tenantID)
+	if err != nil {
+		logging.HandlerError(c, "AdminMenu.ListOptions", "service error", fiber.StatusBadRequest, "options_list_failed", err, "tenant_id", tenantID)
+		return fiber.ErrBadRequest
+	}
+
+	resp := make([]optionResponse, 0, len(opts))
+	for _, opt := range opts {
+		resp = append(resp, newOptionResponse(opt))
+	}
+
+	logging.HandlerInfo(c, "AdminMenu.ListOptions", "options listed", fiber.StatusOK, "options_listed", "tenant_id", tenantID, "count", len(resp))
+	return c.JSON(resp)
+}
+
+// CreateOption persists a new option that can then be attached to items and categories.
+func (h *AdminMenuHandler) CreateOption(c *fiber.Ctx) error {
+	tenantID, _ := c.Locals("tenant_id").(string)
+
+	var payload map[string]any
+	if err := c.BodyParser(&payload); err != nil {
+		logging.HandlerError(c, "AdminMenu.CreateOption", "failed to parse body", fiber.StatusBadRequest, "invalid_body", err, "tenant_id", tenantID)
+		return fiber.ErrBadRequest
+	}
+
+	opt, err := h.uc.CreateOption(tenantID, payload)
+	if err != nil {
+		return h.fail(c, "AdminMenu.CreateOption", "option_create_failed", err, "tenant_id", tenantID)
+	}
+
+	resp := newOptionResponse(*opt)
+	logging.HandlerInfo(c, "AdminMenu.CreateOption", "option created", fiber.StatusCreated, "option_created", "tenant_id", tenantID, "option_id", resp.ID)
+	return c.Status(fiber.StatusCreated).JSON(resp)
+}
+
 // ListItemOptions returns all modifer options for a given item.
 func (h *AdminMenuHandler) ListItemOptions(c *fiber.Ctx) error {
 	tenantID, _ := c.Locals("tenant_id").(string)
@@ -308,13 +381,12 @@ func (h *AdminMenuHandler) ListItemOptions(c *fiber.Ctx) error {
 
 	opts, err := h.uc.ListItemOptions(itemID, tenantID)
 	if err != nil {
-		logging.HandlerError(c, "AdminMenu.ListItemOptions", "service error", fiber.StatusBadRequest, "options_list_failed", err, "tenant_id", tenantID, "item_id", itemID)
-		return fiber.ErrBadRequest
+		return h.fail(c, "AdminMenu.ListItemOptions", "options_list_failed", err, "tenant_id", tenantID, "item_id", itemID)
 	}
 
-	resp := make([]optionResponse, 0, len(opts))
+	resp := make([]itemOptionResponse, 0, len(opts))
 	for _, opt := range opts {
-		resp = append(resp, newOptionResponse(opt))
+		resp = append(resp, itemOptionResponse{newOptionResponse(opt), itemID})
 	}
 
 	logging.HandlerInfo(c, "AdminMenu.ListItemOptions", "item options listed", fiber.StatusOK, "options_listed", "tenant_id", tenantID, "item_id", itemID, "count", len(resp))
@@ -334,11 +406,10 @@ func (h *AdminMenuHandler) CreateItemOption(c *fiber.Ctx) error {
 
 	opt, err := h.uc.CreateItemOption(itemID, tenantID, payload)
 	if err != nil {
-		logging.HandlerError(c, "AdminMenu.CreateItemOption", "service error", fiber.StatusBadRequest, "option_create_failed", err, "tenant_id", tenantID, "item_id", itemID)
-		return fiber.ErrBadRequest
+		return h.fail(c, "AdminMenu.CreateItemOption", "option_create_failed", err, "tenant_id", tenantID, "item_id", itemID)
 	}
 
-	resp := newOptionResponse(*opt)
+	resp := itemOptionResponse{newOptionResponse(*opt), itemID}
 	logging.HandlerInfo(c, "AdminMenu.CreateItemOption", "item option created", fiber.StatusCreated, "option_created", "tenant_id", tenantID, "item_id", itemID, "option_id", resp.ID)
 	return c.Status(fiber.StatusCreated).JSON(resp)
 }
@@ -376,8 +447,7 @@ func (h *AdminMenuHandler) CreateOptionValue(c *fiber.Ctx) error {
 
 	val, err := h.uc.CreateOptionValue(optionID, tenantID, payload)
 	if err != nil {
-		logging.HandlerError(c, "AdminMenu.CreateOptionValue", "service error", fiber.StatusBadRequest, "option_value_create_failed", err, "tenant_id", tenantID, "option_id", optionID)
-		return fiber.ErrBadRequest
+		return h.fail(c, "AdminMenu.CreateOptionValue", "option_value_create_failed", err, "tenant_id", tenantID, "option_id", optionID)
 	}
 
 	resp := newOptionValueResponse(*val)
@@ -385,55 +455,181 @@ func (h *AdminMenuHandler) CreateOptionValue(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusCreated).JSON(resp)
 }
 
-// GenerateQR returns a QR code URL for a table belonging to the tenant.
-func (h *AdminMenuHandler) GenerateQR(c *fiber.Ctx) error {
+// PatchItemOption renames, retypes or reorders an option.
+func (h *AdminMenuHandler) PatchItemOption(c *fiber.Ctx) error {
+	tenantID, _ := c.Locals("tenant_id").(string)
+	optionID := c.Params("option_id")
+
+	var payload map[string]any
+	if err := c.BodyParser(&payload); err != nil {
+		logging.HandlerError(c, "AdminMenu.PatchItemOption", "failed to parse body", fiber.StatusBadRequest, "invalid_body", err, "tenant_id", tenantID, "option_id", optionID)
+		return fiber.ErrBadRequest
+	}
+
+	opt, err := h.uc.PatchItemOption(optionID, tenantID, payload)
+	if err != nil {
+		return h.fail(c, "AdminMenu.PatchItemOption", "option_patch_failed", err, "tenant_id", tenantID, "option_id", optionID)
+	}
+
+	resp := newOptionResponse(*opt)
+	logging.HandlerInfo(c, "AdminMenu.PatchItemOption", "item option patched", fiber.StatusOK, "option_patched", "tenant_id", tenantID, "option_id", optionID)
+	return c.JSON(resp)
+}
+
+// DeleteItemOption removes an option and its values.
+func (h *AdminMenuHandler) DeleteItemOption(c *fiber.Ctx) error {
+	tenantID, _ := c.Locals("tenant_id").(string)
+	optionID := c.Params("option_id")
+
+	if err := h.uc.DeleteItemOption(optionID, tenantID); err != nil {
+		return h.fail(c, "AdminMenu.DeleteItemOption", "option_delete_failed", err, "tenant_id", tenantID, "option_id", optionID)
+	}
+
+	logging.HandlerInfo(c, "AdminMenu.DeleteItemOption", "item option deleted", fiber.StatusNoContent, "option_deleted", "tenant_id", tenantID, "option_id", optionID)
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// PatchOptionValue relabels, reprices or reorders an option value.
+func (h *AdminMenuHandler) PatchOptionValue(c *fiber.Ctx) error {
+	tenantID, _ := c.Locals("tenant_id").(string)
+	optionID := c.Params("option_id")
+	valueID := c.Params("value_id")
+
+	var payload map[string]any
+	if err := c.BodyParser(&payload); err != nil {
+		logging.HandlerError(c, "AdminMenu.PatchOptionValue", "failed to parse body", fiber.StatusBadRequest, "invalid_body", err, "tenant_id", tenantID, "option_id", optionID, "value_id", valueID)
+		return fiber.ErrBadRequest
+	}
+
+	val, err := h.uc.PatchOptionValue(optionID, valueID, tenantID, payload)
+	if err != nil {
+		return h.fail(c, "AdminMenu.PatchOptionValue", "option_value_patch_failed", err, "tenant_id", tenantID, "option_id", optionID, "value_id", valueID)
+	}
+
+	resp := newOptionValueResponse(*val)
+	logging.HandlerInfo(c, "AdminMenu.PatchOptionValue", "option value patched", fiber.StatusOK, "option_value_patched", "tenant_id", tenantID, "option_id", optionID, "value_id", valueID)
+	return c.JSON(resp)
+}
+
+// DeleteOptionValue removes a value from an option.
+func (h *AdminMenuHandler) DeleteOptionValue(c *fiber.Ctx) error {
 	tenantID, _ := c.Locals("tenant_id").(string)
-	tableID := c.Params("id")
+	optionID := c.Params("option_id")
+	valueID := c.Params("value_id")
+
+	if err := h.uc.DeleteOptionValue(optionID, valueID, tenantID); err != nil {
+		return h.fail(c, "AdminMenu.DeleteOptionValue", "option_value_delete_failed", err, "tenant_id", tenantID, "option_id", optionID, "value_id", valueID)
+	}
 
-	url, err := h.uc.GenerateTableQR(tableID, tenantID)
+	logging.HandlerInfo(c, "AdminMenu.DeleteOptionValue", "option value deleted", fiber.StatusNoContent, "option_value_deleted", "tenant_id", tenantID, "option_id", optionID, "value_id", valueID)
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// ListAttachments returns the items and categories an option is attached to.
+func (h *AdminMenuHandler) ListAttachments(c *fiber.Ctx) error {
+	tenantID, _ := c.Locals("tenant_id").(string)
+	optionID := c.Params("option_id")
+
+	atts, err := h.uc.ListAttachments(optionID, tenantID)
 	if err != nil {
-		logging.HandlerError(c, "AdminMenu.GenerateQR", "service error", fiber.StatusBadRequest, "qr_generate_failed", err, "tenant_id", tenantID, "table_id", tableID)
+		return h.fail(c, "AdminMenu.ListAttachments", "attachments_list_failed", err, "tenant_id", tenantID, "option_id", optionID)
+	}
+
+	resp := make([]attachmentResponse, 0, len(atts))
+	for _, a := range atts {
+		resp = append(resp, newAttachmentResponse(a))
+	}
+
+	logging.HandlerInfo(c, "AdminMenu.ListAttachments", "option attachments listed", fiber.StatusOK, "attachments_listed", "tenant_id", tenantID, "option_id", optionID, "count", len(resp))
+	return c.JSON(resp)
+}
+
+// Attach offers an option on an item or category, with optional overrides.
+func (h *AdminMenuHandler) Attach(c *fiber.Ctx) error {
+	tenantID, _ := c.Locals("tenant_id").(string)
+	optionID := c.Params("option_id")
+
+	var payload map[string]any
+	if err := c.BodyParser(&payload); err != nil {
+		logging.HandlerError(c, "AdminMenu.Attach", "failed to parse body", fiber.StatusBadRequest, "invalid_body", err, "tenant_id", tenantID, "option_id", optionID)
 		return fiber.ErrBadRequest
 	}
 
-	logging.HandlerInfo(c, "AdminMenu.GenerateQR", "qr generated", fiber.StatusOK, "qr_generated", "tenant_id", tenantID, "table_id", tableID)
-	return c.JSON(fiber.Map{"url": url})
+	att, err := h.uc.Attach(optionID, tenantID, payload)
+	if err != nil {
+		return h.fail(c, "AdminMenu.Attach", "option_attach_failed", err, "tenant_id", tenantID, "option_id", optionID)
+	}
+
+	resp := newAttachmentResponse(*att)
+	logging.HandlerInfo(c, "AdminMenu.Attach", "option attached", fiber.StatusOK, "option_attached", "tenant_id", tenantID, "option_id", optionID, "attachment_id", resp.ID)
+	return c.JSON(resp)
+}
+
+// Detach stops offering an option on an item or category.
+func (h *AdminMenuHandler) Detach(c *fiber.Ctx) error {
+	tenantID, _ := c.Locals("tenant_id").(string)
+	optionID := c.Params("option_id")
+	attachmentID := c.Params("attachment_id")
+
+	if err := h.uc.Detach(optionID, attachmentID, tenantID); err != nil {
+		return h.fail(c, "AdminMenu.Detach", "option_detach_failed", err, "tenant_id", tenantID, "option_id", optionID, "attachment_id", attachmentID)
+	}
+
+	logging.HandlerInfo(c, "AdminMenu.Detach", "option detached", fiber.StatusNoContent, "option_detached", "tenant_id", tenantID, "option_id", optionID, "attachment_id", attachmentID)
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// fail answers with the status and code of a known domain error, or 400.
+func (h *AdminMenuHandler) fail(c *fiber.Ctx, scope, errCode string, err error, kv ...any) error {
+	if code, domainCode, ok := lookupDomainError(err); ok {
+		logging.HandlerError(c, scope, "menu request rejected", code, domainCode, err, kv...)
+		return c.Status(code).JSON(domainErrorBody(domainCode, err))
+	}
+	logging.HandlerError(c, scope, "service error", fiber.StatusBadRequest, errCode, err, kv...)
+	return fiber.ErrBadRequest
 }
 
 // newCategoryResponse converts a domain category into its JSON representation.
 func newCategoryResponse(cat domain.Category) categoryResponse {
 	return categoryResponse{
-		ID:       cat.ID,
-		TenantID: cat.TenantID,
-		Name:     cat.Name,
-		Sort:     cat.Sort,
-		IsActive: cat.IsActive,
+		ID:           cat.ID,
+		TenantID:     cat.TenantID,
+		Name:         cat.Name,
+		Sort:         cat.Sort,
+		IsActive:     cat.IsActive,
+		StationID:    cat.StationID,
+		Availability: cat.Availability,
 	}
 }
 
 // newItemResponse converts a domain item into its JSON representation.
 func newItemResponse(item domain.Item) itemResponse {
 	return itemResponse{
-		ID:          item.ID,
-		TenantID:    item.TenantID,
-		CategoryID:  item.CategoryID,
-		Name:        item.Name,
-		Description: item.Description,
-		Price:       item.Price,
-		PhotoURL:    item.PhotoURL,
-		Flags:       item.Flags,
-		IsActive:    item.IsActive,
+		ID:           item.ID,
+		TenantID:     item.TenantID,
+		CategoryID:   item.CategoryID,
+		Name:         item.Name,
+		Description:  item.Description,
+		Price:        item.Price,
+		PhotoURL:     item.PhotoURL,
+		Flags:        item.Flags,
+		IsActive:     item.IsActive,
+		StationID:    item.StationID,
+		Availability: item.Availability,
 	}
 }
 
 // newOptionResponse converts a domain option into its JSON representation.
 func newOptionResponse(opt domain.ItemOption) optionResponse {
 	return optionResponse{
-		ID:       opt.ID,
-		ItemID:   opt.ItemID,
-		Name:     opt.Name,
-		Type:     opt.Type,
-		Required: opt.Required,
+		ID:        opt.ID,
+		TenantID:  opt.TenantID,
+		Name:      opt.Name,
+		Type:      opt.Type,
+		Required:  opt.Required,
+		MinSelect: opt.MinSelect,
+		MaxSelect: opt.MaxSelect,
+		Sort:      opt.Sort,
 	}
 }
 
@@ -444,5 +640,22 @@ func newOptionValueResponse(val domain.ItemOptionValue) optionValueResponse {
 		OptionID:   val.OptionID,
 		Label:      val.Label,
 		DeltaPrice: val.DeltaPrice,
+		MaxQty:     val.MaxQty,
+		IsDefault:  val.IsDefault,
+		Sort:       val.Sort,
+	}
+}
+
+// newAttachmentResponse converts a domain option attachment into its JSON representation.
+func newAttachmentResponse(a domain.OptionAttachment) attachmentResponse {
+	return attachmentResponse{
+		ID:         a.ID,
+		OptionID:   a.OptionID,
+		ItemID:     a.ItemID,
+		CategoryID: a.CategoryID,
+		Required:   a.Required,
+		MinSelect:  a.MinSelect,
+		MaxSelect:  a.MaxSelect,
+		Sort:       a.Sort,
 	}
 }
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"context"
 	"errors"
 	"qrmenu/internal/domain"
 	"qrmenu/internal/platform/logging"
@@ -14,8 +15,11 @@ type OrdersPage = repository.OrdersPage
 
 // AdminOrdersQuery dikonsumsi handler; diimplementasikan oleh usecase.AdminOrdersUC
 type AdminOrdersQuery interface {
-	List(tenantID, status, cursor string) (OrdersPage, error)
-	UpdateStatus(tenantID, id, status string) (*domain.Order, error)
+	List(tenantID, status, areaID, cursor string) (OrdersPage, error)
+	UpdateStatus(tenantID, id, status, adminID, reason string) (*domain.Order, error)
+	Get(tenantID, id string) (*domain.Order, error)
+	UpdateItemStatus(tenantID, orderID, itemID, status, adminID, reason string) (*domain.Order, error)
+	Stream(ctx context.Context, tenantID, lastEventID string) (<-chan domain.OrderEvent, error)
 }
 
 type AdminOrdersHandler struct{ q AdminOrdersQuery }
@@ -26,24 +30,45 @@ func NewAdminOrdersHandler(q AdminOrdersQuery) *AdminOrdersHandler { return &Adm
 func (h *AdminOrdersHandler) List(c *fiber.Ctx) error {
 	tenantID, _ := c.Locals("tenant_id").(string)
 	status := c.Query("status")
+	areaID := c.Query("area_id")
 	cursor := c.Query("cursor")
 
-	page, err := h.q.List(tenantID, status, cursor)
+	page, err := h.q.List(tenantID, status, areaID, cursor)
 	if err != nil {
-		logging.HandlerError(c, "AdminOrders.List", "query failed", fiber.StatusBadRequest, "orders_query_failed", err, "tenant_id", tenantID, "status", status, "cursor", cursor)
+		logging.HandlerError(c, "AdminOrders.List", "query failed", fiber.StatusBadRequest, "orders_query_failed", err, "tenant_id", tenantID, "status", status, "area_id", areaID, "cursor", cursor)
 		return fiber.ErrBadRequest
 	}
 	logging.HandlerInfo(c, "AdminOrders.List", "orders retrieved", fiber.StatusOK, "orders_listed", "tenant_id", tenantID, "status", status, "count", len(page.Data))
 	return c.JSON(page)
 }
 
+// GET /admin/orders/:id
+func (h *AdminOrdersHandler) Get(c *fiber.Ctx) error {
+	tenantID, _ := c.Locals("tenant_id").(string)
+	id := c.Params("id")
+
+	ord, err := h.q.Get(tenantID, id)
+	if err != nil {
+		if code, errCode, ok := lookupDomainError(err); ok {
+			logging.HandlerError(c, "AdminOrders.Get", "order lookup failed", code, errCode, err, "tenant_id", tenantID, "order_id", id)
+			return c.Status(code).JSON(domainErrorBody(errCode, err))
+		}
+		logging.HandlerError(c, "AdminOrders.Get", "query failed", fiber.StatusBadRequest, "order_query_failed", err, "tenant_id", tenantID, "order_id", id)
+		return fiber.ErrBadRequest
+	}
+	logging.HandlerInfo(c, "AdminOrders.Get", "order retrieved", fiber.StatusOK, "order_found", "tenant_id", tenantID, "order_id", id)
+	return c.JSON(ord)
+}
+
 // PATCH /admin/orders/:id/status
 func (h *AdminOrdersHandler) PatchStatus(c *fiber.Ctx) error {
 	tenantID, _ := c.Locals("tenant_id").(string)
+	adminID, _ := c.Locals("admin_id").(string)
 	id := c.Params("id")
 
 	var body struct {
 		Status string `json:"status"`
+		Reason string `json:"reason"`
 	}
 	if err := c.BodyParser(&body); err != nil {
 		logging.HandlerError(c, "AdminOrders.PatchStatus", "failed to parse body", fiber.StatusBadRequest, "invalid_body", err, "tenant_id", tenantID, "order_id", id)
@@ -54,11 +79,71 @@ func (h *AdminOrdersHandler) PatchStatus(c *fiber.Ctx) error {
 		return fiber.ErrBadRequest
 	}
 
-	ord, err := h.q.UpdateStatus(tenantID, id, body.Status)
+	ord, err := h.q.UpdateStatus(tenantID, id, body.Status, adminID, body.Reason)
 	if err != nil {
+		if code, errCode, ok := lookupDomainError(err); ok {
+			logging.HandlerError(c, "AdminOrders.PatchStatus", "transition rejected", code, errCode, err, "tenant_id", tenantID, "order_id", id, "status", body.Status)
+			return c.Status(code).JSON(domainErrorBody(errCode, err))
+		}
 		logging.HandlerError(c, "AdminOrders.PatchStatus", "update failed", fiber.StatusBadRequest, "order_status_update_failed", err, "tenant_id", tenantID, "order_id", id, "status", body.Status)
 		return fiber.ErrBadRequest
 	}
 	logging.HandlerInfo(c, "AdminOrders.PatchStatus", "status updated", fiber.StatusOK, "status_updated", "tenant_id", tenantID, "order_id", id, "status", body.Status)
 	return c.JSON(ord)
 }
+
+// PATCH /admin/orders/:id/items/:item_id/status
+// Body: { "status": "queued|cooking|ready|served|voided", "reason": "..." }
+func (h *AdminOrdersHandler) PatchItemStatus(c *fiber.Ctx) error {
+	tenantID, _ := c.Locals("tenant_id").(string)
+	adminID, _ := c.Locals("admin_id").(string)
+	id := c.Params("id")
+	itemID := c.Params("item_id")
+
+	var body struct {
+		Status string `json:"status"`
+		Reason string `json:"reason"`
+	}
+	if err := c.BodyParser(&body); err != nil {
+		logging.HandlerError(c, "AdminOrders.PatchItemStatus", "failed to parse body", fiber.StatusBadRequest, "invalid_body", err, "tenant_id", tenantID, "order_id", id, "order_item_id", itemID)
+		return fiber.ErrBadRequest
+	}
+	if body.Status == "" {
+		logging.HandlerError(c, "AdminOrders.PatchItemStatus", "status missing", fiber.StatusBadRequest, "status_missing", errors.New("status required"), "tenant_id", tenantID, "order_id", id, "order_item_id", itemID)
+		return fiber.ErrBadRequest
+	}
+
+	ord, err := h.q.UpdateItemStatus(tenantID, id, itemID, body.Status, adminID, body.Reason)
+	if err != nil {
+		if code, errCode, ok := lookupDomainError(err); ok {
+			logging.HandlerError(c, "AdminOrders.PatchItemStatus", "transition rejected", code, errCode, err, "tenant_id", tenantID, "order_id", id, "order_item_id", itemID, "status", body.Status)
+			return c.Status(code).JSON(domainErrorBody(errCode, err))
+		}
+		logging.HandlerError(c, "AdminOrders.PatchItemStatus", "update failed", fiber.StatusBadRequest, "order_item_update_failed", err, "tenant_id", tenantID, "order_id", id, "order_item_id", itemID, "status", body.Status)
+		return fiber.ErrBadRequest
+	}
+	logging.HandlerInfo(c, "AdminOrders.PatchItemStatus", "item status updated", fiber.StatusOK, "order_item_updated", "tenant_id", tenantID, "order_id", id, "order_item_id", itemID, "status", body.Status)
+	return c.JSON(ord)
+}
+
+// GET /admin/orders/stream (Server-Sent Events)
+// Resumes after the Last-Event-ID header (sent by EventSource on reconnect) or ?last_event_id=.
+func (h *AdminOrdersHandler) Stream(c *fiber.Ctx) error {
+	tenantID, _ := c.Locals("tenant_id").(string)
+	lastID := c.Get("Last-Event-ID", c.Query("last_event_id"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events, err := h.q.Stream(ctx, tenantID, lastID)
+	if err != nil {
+		cancel()
+		if code, errCode, ok := lookupDomainError(err); ok {
+			logging.HandlerError(c, "AdminOrders.Stream", "stream rejected", code, errCode, err, "tenant_id", tenantID, "last_event_id", lastID)
+			return c.Status(code).JSON(domainErrorBody(errCode, err))
+		}
+		logging.HandlerError(c, "AdminOrders.Stream", "subscribe failed", fiber.StatusServiceUnavailable, "order_stream_failed", err, "tenant_id", tenantID)
+		return fiber.ErrServiceUnavailable
+	}
+
+	logging.HandlerInfo(c, "AdminOrders.Stream", "order stream opened", fiber.StatusOK, "order_stream_opened", "tenant_id", tenantID, "last_event_id", lastID)
+	return streamOrderEvents(c, events, cancel)
+}
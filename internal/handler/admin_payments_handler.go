@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"qrmenu/internal/domain"
+	"qrmenu/internal/platform/logging"
+)
+
+// AdminPaymentsUseCase models the cashier operations used by the admin payments HTTP adapter.
+type AdminPaymentsUseCase interface {
+	PayOrder(tenantID, orderID string, req domain.PaymentRequest) (*domain.Payment, error)
+	PayTable(tenantID, tableID string, orderIDs []string, req domain.PaymentRequest) (*domain.Payment, error)
+	ListOrderPayments(tenantID, orderID string) ([]domain.Payment, error)
+}
+
+// AdminPaymentsHandler exposes HTTP handlers for recording and listing payments.
+type AdminPaymentsHandler struct {
+	uc AdminPaymentsUseCase
+}
+
+// NewAdminPaymentsHandler wires the payments use case into a HTTP handler instance.
+func NewAdminPaymentsHandler(uc AdminPaymentsUseCase) *AdminPaymentsHandler {
+	return &AdminPaymentsHandler{uc: uc}
+}
+
+// paymentReq is the JSON body accepted by the payment endpoints.
+type paymentReq struct {
+	Method    string   `json:"method"`
+	Amount    int64    `json:"amount"`
+	Tendered  int64    `json:"tendered"`
+	Reference string   `json:"reference"`
+	OrderIDs  []string `json:"order_ids"`
+}
+
+func (r paymentReq) toDomain(adminID string) domain.PaymentRequest {
+	return domain.PaymentRequest{
+		Method:     domain.PaymentMethod(r.Method),
+		Amount:     r.Amount,
+		Tendered:   r.Tendered,
+		Reference:  r.Reference,
+		ReceivedBy: adminID,
+	}
+}
+
+// PayOrder records a payment for a single order.
+func (h *AdminPaymentsHandler) PayOrder(c *fiber.Ctx) error {
+	tenantID, _ := c.Locals("tenant_id").(string)
+	adminID, _ := c.Locals("admin_id").(string)
+	orderID := c.Params("id")
+
+	var body paymentReq
+	if err := c.BodyParser(&body); err != nil {
+		logging.HandlerError(c, "AdminPayments.PayOrder", "failed to parse body", fiber.StatusBadRequest, "invalid_body", err, "tenant_id", tenantID, "order_id", orderID)
+		return fiber.ErrBadRequest
+	}
+
+	p, err := h.uc.PayOrder(tenantID, orderID, body.toDomain(adminID))
+	if err != nil {
+		if code, errCode, ok := lookupDomainError(err); ok {
+			logging.HandlerError(c, "AdminPayments.PayOrder", "payment rejected", code, errCode, err, "tenant_id", tenantID, "order_id", orderID)
+			return c.Status(code).JSON(domainErrorBody(errCode, err))
+		}
+		logging.HandlerError(c, "AdminPayments.PayOrder", "service error", fiber.StatusBadRequest, "payment_failed", err, "tenant_id", tenantID, "order_id", orderID)
+		return fiber.ErrBadRequest
+	}
+
+	logging.HandlerInfo(c, "AdminPayments.PayOrder", "payment recorded", fiber.StatusCreated, "payment_recorded", "tenant_id", tenantID, "order_id", orderID, "payment_id", p.ID)
+	return c.Status(fiber.StatusCreated).JSON(p)
+}
+
+// PayTable records one payment for several orders of a table.
+func (h *AdminPaymentsHandler) PayTable(c *fiber.Ctx) error {
+	tenantID, _ := c.Locals("tenant_id").(string)
+	adminID, _ := c.Locals("admin_id").(string)
+	tableID := c.Params("id")
+
+	var body paymentReq
+	if err := c.BodyParser(&body); err != nil {
+		logging.HandlerError(c, "AdminPayments.PayTable", "failed to parse body", fiber.StatusBadRequest, "invalid_body", err, "tenant_id", tenantID, "table_id", tableID)
+		return fiber.ErrBadRequest
+	}
+
+	p, err := h.uc.PayTable(tenantID, tableID, body.OrderIDs, body.toDomain(adminID))
+	if err != nil {
+		if code, errCode, ok := lookupDomainError(err); ok {
+			logging.HandlerError(c, "AdminPayments.PayTable", "payment rejected", code, errCode, err, "tenant_id", tenantID, "table_id", tableID)
+			return c.Status(code).JSON(domainErrorBody(errCode, err))
+		}
+		logging.HandlerError(c, "AdminPayments.PayTable", "service error", fiber.StatusBadRequest, "payment_failed", err, "tenant_id", tenantID, "table_id", tableID)
+		return fiber.ErrBadRequest
+	}
+
+	logging.HandlerInfo(c, "AdminPayments.PayTable", "table payment recorded", fiber.StatusCreated, "table_payment_recorded", "tenant_id", tenantID, "table_id", tableID, "payment_id", p.ID, "orders", len(p.Allocations))
+	return c.Status(fiber.StatusCreated).JSON(p)
+}
+
+// ListOrderPayments returns every payment that was applied to an order.
+func (h *AdminPaymentsHandler) ListOrderPayments(c *fiber.Ctx) error {
+	tenantID, _ := c.Locals("tenant_id").(string)
+	orderID := c.Params("id")
+
+	xs, err := h.uc.ListOrderPayments(tenantID, orderID)
+	if err != nil {
+		logging.HandlerError(c, "AdminPayments.ListOrderPayments", "service error", fiber.StatusBadRequest, "payments_list_failed", err, "tenant_id", tenantID, "order_id", orderID)
+		return fiber.ErrBadRequest
+	}
+
+	logging.HandlerInfo(c, "AdminPayments.ListOrderPayments", "payments listed", fiber.StatusOK, "payments_listed", "tenant_id", tenantID, "order_id", orderID, "count", len(xs))
+	return c.JSON(xs)
+}
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"qrmenu/internal/domain"
+	"qrmenu/internal/platform/logging"
+)
+
+// AdminServiceRequestsUseCase models the staff side of table service requests.
+type AdminServiceRequestsUseCase interface {
+	List(tenantID, status string) ([]domain.ServiceRequest, error)
+	Acknowledge(tenantID, id, adminID string) (*domain.ServiceRequest, error)
+	Resolve(tenantID, id, adminID string) (*domain.ServiceRequest, error)
+}
+
+// AdminServiceRequestsHandler lists guest service requests and lets staff handle them.
+type AdminServiceRequestsHandler struct {
+	uc AdminServiceRequestsUseCase
+}
+
+// NewAdminServiceRequestsHandler wires the service request use case into a HTTP handler instance.
+func NewAdminServiceRequestsHandler(uc AdminServiceRequestsUseCase) *AdminServiceRequestsHandler {
+	return &AdminServiceRequestsHandler{uc: uc}
+}
+
+// GET /admin/service-requests?status=
+// Without status the open and acknowledged requests are returned; status=all returns every request.
+func (h *AdminServiceRequestsHandler) List(c *fiber.Ctx) error {
+	tenantID, _ := c.Locals("tenant_id").(string)
+	status := c.Query("status")
+
+	xs, err := h.uc.List(tenantID, status)
+	if err != nil {
+		logging.HandlerError(c, "AdminServiceRequests.List", "service error", fiber.StatusBadRequest, "service_requests_list_failed", err, "tenant_id", tenantID, "status", status)
+		return fiber.ErrBadRequest
+	}
+	logging.HandlerInfo(c, "AdminServiceRequests.List", "service requests listed", fiber.StatusOK, "service_requests_listed", "tenant_id", tenantID, "count", len(xs))
+	return c.JSON(xs)
+}
+
+// POST /admin/service-requests/:id/ack
+func (h *AdminServiceRequestsHandler) Acknowledge(c *fiber.Ctx) error {
+	return h.setStatus(c, "AdminServiceRequests.Acknowledge", h.uc.Acknowledge)
+}
+
+// POST /admin/service-requests/:id/resolve
+func (h *AdminServiceRequestsHandler) Resolve(c *fiber.Ctx) error {
+	return h.setStatus(c, "AdminServiceRequests.Resolve", h.uc.Resolve)
+}
+
+func (h *AdminServiceRequestsHandler) setStatus(c *fiber.Ctx, scope string, action func(tenantID, id, adminID string) (*domain.ServiceRequest, error)) error {
+	tenantID, _ := c.Locals("tenant_id").(string)
+	adminID, _ := c.Locals("admin_id").(string)
+	id := c.Params("id")
+
+	sr, err := action(tenantID, id, adminID)
+	if err != nil {
+		if code, errCode, ok := lookupDomainError(err); ok {
+			logging.HandlerError(c, scope, "update rejected", code, errCode, err, "tenant_id", tenantID, "request_id", id)
+			return c.Status(code).JSON(domainErrorBody(errCode, err))
+		}
+		logging.HandlerError(c, scope, "service error", fiber.StatusBadRequest, "service_request_update_failed", err, "tenant_id", tenantID, "request_id", id)
+		return fiber.ErrBadRequest
+	}
+	logging.HandlerInfo(c, scope, "service request updated", fiber.StatusOK, "service_request_updated", "tenant_id", tenantID, "request_id", id, "status", sr.Status)
+	return c.JSON(sr)
+}
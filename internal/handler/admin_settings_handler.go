@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"qrmenu/internal/domain"
+	"qrmenu/internal/platform/logging"
+)
+
+// AdminSettingsUseCase models the tenant settings operations used by the admin settings HTTP adapter.
+type AdminSettingsUseCase interface {
+	Get(tenantID string) (*domain.Tenant, error)
+	Patch(tenantID string, body map[string]any) (*domain.Tenant, error)
+}
+
+// AdminSettingsHandler exposes the tenant-wide settings.
+type AdminSettingsHandler struct {
+	uc AdminSettingsUseCase
+}
+
+// NewAdminSettingsHandler wires the tenant settings use case into a HTTP handler instance.
+func NewAdminSettingsHandler(uc AdminSettingsUseCase) *AdminSettingsHandler {
+	return &AdminSettingsHandler{uc: uc}
+}
+
+// GET /admin/settings
+func (h *AdminSettingsHandler) Get(c *fiber.Ctx) error {
+	tenantID, _ := c.Locals("tenant_id").(string)
+
+	t, err := h.uc.Get(tenantID)
+	if err != nil {
+		logging.HandlerError(c, "AdminSettings.Get", "service error", fiber.StatusBadRequest, "settings_failed", err, "tenant_id", tenantID)
+		return fiber.ErrBadRequest
+	}
+	logging.HandlerInfo(c, "AdminSettings.Get", "settings returned", fiber.StatusOK, "settings_returned", "tenant_id", tenantID)
+	return c.JSON(t)
+}
+
+// PATCH /admin/settings
+func (h *AdminSettingsHandler) Patch(c *fiber.Ctx) error {
+	tenantID, _ := c.Locals("tenant_id").(string)
+
+	var payload map[string]any
+	if err := c.BodyParser(&payload); err != nil {
+		logging.HandlerError(c, "AdminSettings.Patch", "failed to parse body", fiber.StatusBadRequest, "invalid_body", err, "tenant_id", tenantID)
+		return fiber.ErrBadRequest
+	}
+
+	t, err := h.uc.Patch(tenantID, payload)
+	if err != nil {
+		if code, errCode, ok := lookupDomainError(err); ok {
+			logging.HandlerError(c, "AdminSettings.Patch", "settings rejected", code, errCode, err, "tenant_id", tenantID)
+			return c.Status(code).JSON(domainErrorBody(errCode, err))
+		}
+		logging.HandlerError(c, "AdminSettings.Patch", "service error", fiber.StatusBadRequest, "settings_patch_failed", err, "tenant_id", tenantID)
+		return fiber.ErrBadRequest
+	}
+	logging.HandlerInfo(c, "AdminSettings.Patch", "settings patched", fiber.StatusOK, "settings_patched", "tenant_id", tenantID)
+	return c.JSON(t)
+}
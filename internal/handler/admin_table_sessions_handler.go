@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"qrmenu/internal/domain"
+	"qrmenu/internal/platform/logging"
+)
+
+// AdminTableSessionsUseCase models the table session operations used by the admin HTTP adapter.
+type AdminTableSessionsUseCase interface {
+	CurrentTab(tenantID, tableID string) (*domain.TableTab, error)
+	SessionTab(tenantID, sessionID string) (*domain.TableTab, error)
+	RequestBill(tenantID, tableID string) (*domain.TableSession, error)
+	Reopen(tenantID, tableID string) (*domain.TableSession, error)
+	Close(tenantID, tableID, adminID string, force bool) (*domain.TableSession, error)
+	StartFresh(tenantID, tableID, adminID string, force bool) (*domain.TableSession, error)
+	MoveOrders(tenantID string, orderIDs []string, toTableID, adminID, reason string) (*domain.TableTransfer, error)
+	Merge(tenantID, fromTableID, toTableID, adminID, reason string) (*domain.TableTransfer, error)
+}
+
+// AdminTableSessionsHandler exposes table tabs and their billing lifecycle.
+type AdminTableSessionsHandler struct {
+	uc AdminTableSessionsUseCase
+}
+
+// NewAdminTableSessionsHandler wires the table session use case into a HTTP handler instance.
+func NewAdminTableSessionsHandler(uc AdminTableSessionsUseCase) *AdminTableSessionsHandler {
+	return &AdminTableSessionsHandler{uc: uc}
+}
+
+// GET /admin/tables/:id/session
+func (h *AdminTableSessionsHandler) Current(c *fiber.Ctx) error {
+	tenantID, _ := c.Locals("tenant_id").(string)
+	tableID := c.Params("id")
+
+	tab, err := h.uc.CurrentTab(tenantID, tableID)
+	if err != nil {
+		return h.fail(c, "AdminTableSessions.Current", err, "tenant_id", tenantID, "table_id", tableID)
+	}
+	logging.HandlerInfo(c, "AdminTableSessions.Current", "tab returned", fiber.StatusOK, "tab_returned", "tenant_id", tenantID, "table_id", tableID, "session_id", tab.Session.ID)
+	return c.JSON(tab)
+}
+
+// GET /admin/sessions/:id
+func (h *AdminTableSessionsHandler) Get(c *fiber.Ctx) error {
+	tenantID, _ := c.Locals("tenant_id").(string)
+	id := c.Params("id")
+
+	tab, err := h.uc.SessionTab(tenantID, id)
+	if err != nil {
+		return h.fail(c, "AdminTableSessions.Get", err, "tenant_id", tenantID, "session_id", id)
+	}
+	logging.HandlerInfo(c, "AdminTableSessions.Get", "tab returned", fiber.StatusOK, "tab_returned", "tenant_id", tenantID, "session_id", id)
+	return c.JSON(tab)
+}
+
+// POST /admin/tables/:id/session/bill
+func (h *AdminTableSessionsHandler) RequestBill(c *fiber.Ctx) error {
+	return h.transition(c, "AdminTableSessions.RequestBill", h.uc.RequestBill)
+}
+
+// POST /admin/tables/:id/session/reopen
+func (h *AdminTableSessionsHandler) Reopen(c *fiber.Ctx) error {
+	return h.transition(c, "AdminTableSessions.Reopen", h.uc.Reopen)
+}
+
+// closeReq is the optional JSON body of the close and start endpoints.
+type closeReq struct {
+	Force bool `json:"force"`
+}
+
+// POST /admin/tables/:id/session/close  {"force": false}
+func (h *AdminTableSessionsHandler) Close(c *fiber.Ctx) error {
+	return h.closeWith(c, "AdminTableSessions.Close", fiber.StatusOK, h.uc.Close)
+}
+
+// POST /admin/tables/:id/sessions  {"force": false}
+// Closes the current session (if any) and opens a fresh one.
+func (h *AdminTableSessionsHandler) Start(c *fiber.Ctx) error {
+	return h.closeWith(c, "AdminTableSessions.Start", fiber.StatusCreated, h.uc.StartFresh)
+}
+
+func (h *AdminTableSessionsHandler) transition(c *fiber.Ctx, scope string, action func(tenantID, tableID string) (*domain.TableSession, error)) error {
+	tenantID, _ := c.Locals("tenant_id").(string)
+	tableID := c.Params("id")
+
+	s, err := action(tenantID, tableID)
+	if err != nil {
+		return h.fail(c, scope, err, "tenant_id", tenantID, "table_id", tableID)
+	}
+	logging.HandlerInfo(c, scope, "session updated", fiber.StatusOK, "session_updated", "tenant_id", tenantID, "table_id", tableID, "session_id", s.ID, "status", s.Status)
+	return c.JSON(s)
+}
+
+func (h *AdminTableSessionsHandler) closeWith(c *fiber.Ctx, scope string, status int, action func(tenantID, tableID, adminID string, force bool) (*domain.TableSession, error)) error {
+	tenantID, _ := c.Locals("tenant_id").(string)
+	adminID, _ := c.Locals("admin_id").(string)
+	tableID := c.Params("id")
+
+	var req closeReq
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			logging.HandlerError(c, scope, "failed to parse body", fiber.StatusBadRequest, "invalid_body", err, "tenant_id", tenantID, "table_id", tableID)
+			return fiber.ErrBadRequest
+		}
+	}
+
+	s, err := action(tenantID, tableID, adminID, req.Force)
+	if err != nil {
+		return h.fail(c, scope, err, "tenant_id", tenantID, "table_id", tableID)
+	}
+	logging.HandlerInfo(c, scope, "session updated", status, "session_updated", "tenant_id", tenantID, "table_id", tableID, "session_id", s.ID, "status", s.Status)
+	return c.Status(status).JSON(s)
+}
+
+// POST /admin/tables/:id/orders  {"order_ids": ["..."], "reason": "guests changed tables"}
+// Moves the orders to table :id.
+func (h *AdminTableSessionsHandler) MoveOrders(c *fiber.Ctx) error {
+	tenantID, _ := c.Locals("tenant_id").(string)
+	adminID, _ := c.Locals("admin_id").(string)
+	tableID := c.Params("id")
+
+	var body struct {
+		OrderIDs []string `json:"order_ids"`
+		Reason   string   `json:"reason"`
+	}
+	if err := c.BodyParser(&body); err != nil {
+		logging.HandlerError(c, "AdminTableSessions.MoveOrders", "failed to parse body", fiber.StatusBadRequest, "invalid_body", err, "tenant_id", tenantID, "table_id", tableID)
+		return fiber.ErrBadRequest
+	}
+
+	res, err := h.uc.MoveOrders(tenantID, body.OrderIDs, tableID, adminID, body.Reason)
+	if err != nil {
+		return h.fail(c, "AdminTableSessions.MoveOrders", err, "tenant_id", tenantID, "table_id", tableID)
+	}
+	logging.HandlerInfo(c, "AdminTableSessions.MoveOrders", "orders moved", fiber.StatusOK, "orders_moved", "tenant_id", tenantID, "table_id", tableID, "orders", len(res.Moves))
+	return c.JSON(res)
+}
+
+// POST /admin/tables/:id/merge  {"from_table_id": "...", "reason": "tables pushed together"}
+// Moves the other table's running tab onto table :id.
+func (h *AdminTableSessionsHandler) Merge(c *fiber.Ctx) error {
+	tenantID, _ := c.Locals("tenant_id").(string)
+	adminID, _ := c.Locals("admin_id").(string)
+	tableID := c.Params("id")
+
+	var body struct {
+		FromTableID string `json:"from_table_id"`
+		Reason      string `json:"reason"`
+	}
+	if err := c.BodyParser(&body); err != nil {
+		logging.HandlerError(c, "AdminTableSessions.Merge", "failed to parse body", fiber.StatusBadRequest, "invalid_body", err, "tenant_id", tenantID, "table_id", tableID)
+		return fiber.ErrBadRequest
+	}
+
+	res, err := h.uc.Merge(tenantID, body.FromTableID, tableID, adminID, body.Reason)
+	if err != nil {
+		return h.fail(c, "AdminTableSessions.Merge", err, "tenant_id", tenantID, "table_id", tableID, "from_table_id", body.FromTableID)
+	}
+	logging.HandlerInfo(c, "AdminTableSessions.Merge", "tables merged", fiber.StatusOK, "tables_merged", "tenant_id", tenantID, "table_id", tableID, "from_table_id", body.FromTableID, "orders", len(res.Moves))
+	return c.JSON(res)
+}
+
+func (h *AdminTableSessionsHandler) fail(c *fiber.Ctx, scope string, err error, kv ...any) error {
+	if code, errCode, ok := lookupDomainError(err); ok {
+		logging.HandlerError(c, scope, "session request rejected", code, errCode, err, kv...)
+		return c.Status(code).JSON(domainErrorBody(errCode, err))
+	}
+	logging.HandlerError(c, scope, "service error", fiber.StatusBadRequest, "session_request_failed", err, kv...)
+	return fiber.ErrBadRequest
+}
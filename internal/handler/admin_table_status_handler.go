@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"qrmenu/internal/domain"
+	"qrmenu/internal/platform/logging"
+)
+
+// TableStatusUseCase models the occupancy operations used by the admin table status HTTP adapter.
+type TableStatusUseCase interface {
+	List(tenantID, areaID string) ([]domain.TableOccupancy, error)
+	Get(tenantID, tableID string) (*domain.TableOccupancy, error)
+	Override(tenantID, tableID, adminID string, status domain.TableStatus) (*domain.TableOccupancy, error)
+	ClearOverride(tenantID, tableID string) (*domain.TableOccupancy, error)
+}
+
+// AdminTableStatusHandler exposes live table occupancy and manual status overrides.
+type AdminTableStatusHandler struct {
+	uc TableStatusUseCase
+}
+
+// NewAdminTableStatusHandler wires the table status use case into a HTTP handler instance.
+func NewAdminTableStatusHandler(uc TableStatusUseCase) *AdminTableStatusHandler {
+	return &AdminTableStatusHandler{uc: uc}
+}
+
+// GET /admin/tables/status?area_id=
+func (h *AdminTableStatusHandler) List(c *fiber.Ctx) error {
+	tenantID, _ := c.Locals("tenant_id").(string)
+	areaID := c.Query("area_id")
+
+	xs, err := h.uc.List(tenantID, areaID)
+	if err != nil {
+		logging.HandlerError(c, "AdminTableStatus.List", "service error", fiber.StatusBadRequest, "table_status_list_failed", err, "tenant_id", tenantID)
+		return fiber.ErrBadRequest
+	}
+	logging.HandlerInfo(c, "AdminTableStatus.List", "table status listed", fiber.StatusOK, "table_status_listed", "tenant_id", tenantID, "count", len(xs))
+	return c.JSON(xs)
+}
+
+// GET /admin/tables/:id/status
+func (h *AdminTableStatusHandler) Get(c *fiber.Ctx) error {
+	tenantID, _ := c.Locals("tenant_id").(string)
+	id := c.Params("id")
+
+	occ, err := h.uc.Get(tenantID, id)
+	if err != nil {
+		return h.fail(c, "AdminTableStatus.Get", "table_status_failed", err, "tenant_id", tenantID, "table_id", id)
+	}
+	logging.HandlerInfo(c, "AdminTableStatus.Get", "table status returned", fiber.StatusOK, "table_status_returned", "tenant_id", tenantID, "table_id", id, "status", occ.Status)
+	return c.JSON(occ)
+}
+
+// PUT /admin/tables/:id/status  {"status": "free"}
+func (h *AdminTableStatusHandler) Override(c *fiber.Ctx) error {
+	tenantID, _ := c.Locals("tenant_id").(string)
+	adminID, _ := c.Locals("admin_id").(string)
+	id := c.Params("id")
+
+	var body struct {
+		Status domain.TableStatus `json:"status"`
+	}
+	if err := c.BodyParser(&body); err != nil {
+		logging.HandlerError(c, "AdminTableStatus.Override", "failed to parse body", fiber.StatusBadRequest, "invalid_body", err, "tenant_id", tenantID, "table_id", id)
+		return fiber.ErrBadRequest
+	}
+
+	occ, err := h.uc.Override(tenantID, id, adminID, body.Status)
+	if err != nil {
+		return h.fail(c, "AdminTableStatus.Override", "table_status_override_failed", err, "tenant_id", tenantID, "table_id", id)
+	}
+	logging.HandlerInfo(c, "AdminTableStatus.Override", "table status overridden", fiber.StatusOK, "table_status_overridden", "tenant_id", tenantID, "table_id", id, "status", occ.Status)
+	return c.JSON(occ)
+}
+
+// DELETE /admin/tables/:id/status
+func (h *AdminTableStatusHandler) ClearOverride(c *fiber.Ctx) error {
+	tenantID, _ := c.Locals("tenant_id").(string)
+	id := c.Params("id")
+
+	occ, err := h.uc.ClearOverride(tenantID, id)
+	if err != nil {
+		return h.fail(c, "AdminTableStatus.ClearOverride", "table_status_clear_failed", err, "tenant_id", tenantID, "table_id", id)
+	}
+	logging.HandlerInfo(c, "AdminTableStatus.ClearOverride", "table status override cleared", fiber.StatusOK, "table_status_override_cleared", "tenant_id", tenantID, "table_id", id)
+	return c.JSON(occ)
+}
+
+func (h *AdminTableStatusHandler) fail(c *fiber.Ctx, scope, errCode string, err error, kv ...any) error {
+	if code, domainCode, ok := lookupDomainError(err); ok {
+		logging.HandlerError(c, scope, "table status request rejected", code, domainCode, err, kv...)
+		return c.Status(code).JSON(domainErrorBody(domainCode, err))
+	}
+	logging.HandlerError(c, scope, "service error", fiber.StatusBadRequest, errCode, err, kv...)
+	return fiber.ErrBadRequest
+}
@@ -0,0 +1,206 @@
+package handler
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"qrmenu/internal/domain"
+	"qrmenu/internal/platform/logging"
+	"qrmenu/internal/platform/qr"
+	"qrmenu/internal/usecase"
+)
+
+// AdminTablesUseCase models the table management operations used by the admin tables HTTP adapter.
+type AdminTablesUseCase interface {
+	List(tenantID string) ([]domain.Table, error)
+	Get(tenantID, id string) (*domain.Table, error)
+	Create(tenantID string, body map[string]any) (*domain.Table, error)
+	Patch(tenantID, id string, body map[string]any) (*domain.Table, error)
+	Delete(tenantID, id string) error
+	RotateToken(tenantID, id string) (*domain.Table, error)
+	QR(tenantID, id string, opts qr.Options) (*usecase.TableQR, error)
+	GenerateTableQR(tenantID, id string) (string, error)
+	QRSheet(tenantID string, ids []string, layout qr.Layout, level qr.Level) ([]byte, error)
+}
+
+// AdminTablesHandler exposes table CRUD and QR token rotation.
+type AdminTablesHandler struct {
+	uc AdminTablesUseCase
+}
+
+// NewAdminTablesHandler wires the tables use case into a HTTP handler instance.
+func NewAdminTablesHandler(uc AdminTablesUseCase) *AdminTablesHandler {
+	return &AdminTablesHandler{uc: uc}
+}
+
+// GET /admin/tables
+func (h *AdminTablesHandler) List(c *fiber.Ctx) error {
+	tenantID, _ := c.Locals("tenant_id").(string)
+
+	xs, err := h.uc.List(tenantID)
+	if err != nil {
+		logging.HandlerError(c, "AdminTables.List", "service error", fiber.StatusBadRequest, "tables_list_failed", err, "tenant_id", tenantID)
+		return fiber.ErrBadRequest
+	}
+	logging.HandlerInfo(c, "AdminTables.List", "tables listed", fiber.StatusOK, "tables_listed", "tenant_id", tenantID, "count", len(xs))
+	return c.JSON(xs)
+}
+
+// GET /admin/tables/:id
+func (h *AdminTablesHandler) Get(c *fiber.Ctx) error {
+	tenantID, _ := c.Locals("tenant_id").(string)
+	id := c.Params("id")
+
+	t, err := h.uc.Get(tenantID, id)
+	if err != nil {
+		return h.fail(c, "AdminTables.Get", "table_failed", err, "tenant_id", tenantID, "table_id", id)
+	}
+	logging.HandlerInfo(c, "AdminTables.Get", "table returned", fiber.StatusOK, "table_returned", "tenant_id", tenantID, "table_id", id)
+	return c.JSON(t)
+}
+
+// POST /admin/tables  {"name": "T1", "code": "A1", "is_active": true}
+// The token is generated server-side.
+func (h *AdminTablesHandler) Create(c *fiber.Ctx) error {
+	tenantID, _ := c.Locals("tenant_id").(string)
+
+	var payload map[string]any
+	if err := c.BodyParser(&payload); err != nil {
+		logging.HandlerError(c, "AdminTables.Create", "failed to parse body", fiber.StatusBadRequest, "invalid_body", err, "tenant_id", tenantID)
+		return fiber.ErrBadRequest
+	}
+
+	t, err := h.uc.Create(tenantID, payload)
+	if err != nil {
+		return h.fail(c, "AdminTables.Create", "table_create_failed", err, "tenant_id", tenantID)
+	}
+	logging.HandlerInfo(c, "AdminTables.Create", "table created", fiber.StatusCreated, "table_created", "tenant_id", tenantID, "table_id", t.ID)
+	return c.Status(fiber.StatusCreated).JSON(t)
+}
+
+// PATCH /admin/tables/:id
+func (h *AdminTablesHandler) Patch(c *fiber.Ctx) error {
+	tenantID, _ := c.Locals("tenant_id").(string)
+	id := c.Params("id")
+
+	var payload map[string]any
+	if err := c.BodyParser(&payload); err != nil {
+		logging.HandlerError(c, "AdminTables.Patch", "failed to parse body", fiber.StatusBadRequest, "invalid_body", err, "tenant_id", tenantID, "table_id", id)
+		return fiber.ErrBadRequest
+	}
+
+	t, err := h.uc.Patch(tenantID, id, payload)
+	if err != nil {
+		return h.fail(c, "AdminTables.Patch", "table_patch_failed", err, "tenant_id", tenantID, "table_id", id)
+	}
+	logging.HandlerInfo(c, "AdminTables.Patch", "table patched", fiber.StatusOK, "table_patched", "tenant_id", tenantID, "table_id", id)
+	return c.JSON(t)
+}
+
+// DELETE /admin/tables/:id
+func (h *AdminTablesHandler) Delete(c *fiber.Ctx) error {
+	tenantID, _ := c.Locals("tenant_id").(string)
+	id := c.Params("id")
+
+	if err := h.uc.Delete(tenantID, id); err != nil {
+		return h.fail(c, "AdminTables.Delete", "table_delete_failed", err, "tenant_id", tenantID, "table_id", id)
+	}
+	logging.HandlerInfo(c, "AdminTables.Delete", "table deleted", fiber.StatusNoContent, "table_deleted", "tenant_id", tenantID, "table_id", id)
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// POST /admin/tables/:id/rotate-token
+func (h *AdminTablesHandler) RotateToken(c *fiber.Ctx) error {
+	tenantID, _ := c.Locals("tenant_id").(string)
+	id := c.Params("id")
+
+	t, err := h.uc.RotateToken(tenantID, id)
+	if err != nil {
+		return h.fail(c, "AdminTables.RotateToken", "token_rotate_failed", err, "tenant_id", tenantID, "table_id", id)
+	}
+	logging.HandlerInfo(c, "AdminTables.RotateToken", "token rotated", fiber.StatusOK, "token_rotated", "tenant_id", tenantID, "table_id", id)
+	return c.JSON(t)
+}
+
+// GET /admin/tables/:id/qr?format=png|svg&size=512&level=L|M|Q|H&download=1
+// Responds with the image itself; the encoded link is echoed in X-Guest-URL.
+func (h *AdminTablesHandler) QR(c *fiber.Ctx) error {
+	tenantID, _ := c.Locals("tenant_id").(string)
+	id := c.Params("id")
+
+	opts := qr.Options{
+		Format: qr.Format(strings.ToLower(c.Query("format"))),
+		Size:   c.QueryInt("size"),
+		Level:  qr.Level(strings.ToUpper(c.Query("level"))),
+	}
+	res, err := h.uc.QR(tenantID, id, opts)
+	if err != nil {
+		return h.fail(c, "AdminTables.QR", "qr_generate_failed", err, "tenant_id", tenantID, "table_id", id)
+	}
+
+	disposition := "inline"
+	if c.QueryBool("download") {
+		disposition = "attachment"
+	}
+	name := res.Table.Code
+	if name == "" {
+		name = res.Table.ID
+	}
+	c.Set(fiber.HeaderContentType, res.Format.ContentType())
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf("%s; filename=%q", disposition, "table-"+name+"."+string(res.Format)))
+	c.Set("X-Guest-URL", res.URL)
+	logging.HandlerInfo(c, "AdminTables.QR", "qr generated", fiber.StatusOK, "qr_generated", "tenant_id", tenantID, "table_id", id, "format", res.Format)
+	return c.Send(res.Image)
+}
+
+// POST /admin/tables/:id/qr
+// Returns the link encoded in the table's QR code as {"url": …}; kept for
+// clients that render QR codes themselves.
+func (h *AdminTablesHandler) GenerateQR(c *fiber.Ctx) error {
+	tenantID, _ := c.Locals("tenant_id").(string)
+	id := c.Params("id")
+
+	url, err := h.uc.GenerateTableQR(tenantID, id)
+	if err != nil {
+		return h.fail(c, "AdminTables.GenerateQR", "qr_generate_failed", err, "tenant_id", tenantID, "table_id", id)
+	}
+	logging.HandlerInfo(c, "AdminTables.GenerateQR", "qr generated", fiber.StatusOK, "qr_generated", "tenant_id", tenantID, "table_id", id)
+	return c.JSON(fiber.Map{"url": url})
+}
+
+// GET /admin/tables/qr-sheet?layout=grid|tent&ids=<id>,<id>&level=M
+// Returns a printable HTML document of the active tables' QR codes.
+func (h *AdminTablesHandler) QRSheet(c *fiber.Ctx) error {
+	tenantID, _ := c.Locals("tenant_id").(string)
+
+	var ids []string
+	for _, id := range strings.Split(c.Query("ids"), ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	layout := qr.Layout(strings.ToLower(c.Query("layout")))
+	level := qr.Level(strings.ToUpper(c.Query("level")))
+
+	doc, err := h.uc.QRSheet(tenantID, ids, layout, level)
+	if err != nil {
+		return h.fail(c, "AdminTables.QRSheet", "qr_sheet_failed", err, "tenant_id", tenantID)
+	}
+	c.Set(fiber.HeaderContentType, fiber.MIMETextHTMLCharsetUTF8)
+	if c.QueryBool("download") {
+		c.Set(fiber.HeaderContentDisposition, `attachment; filename="table-qr-sheet.html"`)
+	}
+	logging.HandlerInfo(c, "AdminTables.QRSheet", "qr sheet generated", fiber.StatusOK, "qr_sheet_generated", "tenant_id", tenantID, "layout", layout, "tables", len(ids))
+	return c.Send(doc)
+}
+
+func (h *AdminTablesHandler) fail(c *fiber.Ctx, scope, errCode string, err error, kv ...any) error {
+	if code, domainCode, ok := lookupDomainError(err); ok {
+		logging.HandlerError(c, scope, "table request rejected", code, domainCode, err, kv...)
+		return c.Status(code).JSON(domainErrorBody(domainCode, err))
+	}
+	logging.HandlerError(c, scope, "service error", fiber.StatusBadRequest, errCode, err, kv...)
+	return fiber.ErrBadRequest
+}
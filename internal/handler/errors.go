@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"qrmenu/internal/domain"
+)
+
+// domainError maps a business rule violation to the HTTP status and the
+// machine readable code returned to API clients.
+type domainError struct {
+	err    error
+	status int
+	code   string
+}
+
+var domainErrors = []domainError{
+	{domain.ErrInvalidQuantity, fiber.StatusBadRequest, "invalid_quantity"},
+	{domain.ErrItemUnavailable, fiber.StatusBadRequest, "item_unavailable"},
+	{domain.ErrItemNotAvailableNow, fiber.StatusConflict, "item_not_available_now"},
+	{domain.ErrUnknownOption, fiber.StatusBadRequest, "unknown_option"},
+	{domain.ErrUnknownOptionValue, fiber.StatusBadRequest, "unknown_option_value"},
+	{domain.ErrInvalidOptionValue, fiber.StatusBadRequest, "invalid_option_value"},
+	{domain.ErrMissingRequiredOption, fiber.StatusBadRequest, "missing_required_option"},
+	{domain.ErrTooManyOptionValues, fiber.StatusBadRequest, "too_many_option_values"},
+	{domain.ErrTooFewOptionValues, fiber.StatusBadRequest, "too_few_option_values"},
+	{domain.ErrOptionValueQtyLimit, fiber.StatusBadRequest, "option_value_qty_exceeded"},
+	{domain.ErrItemNotFound, fiber.StatusNotFound, "item_not_found"},
+	{domain.ErrOptionNotFound, fiber.StatusNotFound, "option_not_found"},
+	{domain.ErrInvalidOption, fiber.StatusBadRequest, "invalid_option"},
+	{domain.ErrOptionValueNotFound, fiber.StatusNotFound, "option_value_not_found"},
+	{domain.ErrCategoryNotFound, fiber.StatusNotFound, "category_not_found"},
+	{domain.ErrAttachmentNotFound, fiber.StatusNotFound, "attachment_not_found"},
+
+	{domain.ErrOrderNotFound, fiber.StatusNotFound, "order_not_found"},
+	{domain.ErrInvalidOrderStatus, fiber.StatusBadRequest, "invalid_order_status"},
+	{domain.ErrInvalidStatusTransition, fiber.StatusConflict, "invalid_status_transition"},
+	{domain.ErrOrderItemNotFound, fiber.StatusNotFound, "order_item_not_found"},
+	{domain.ErrInvalidItemStatus, fiber.StatusBadRequest, "invalid_item_status"},
+	{domain.ErrInvalidItemTransition, fiber.StatusConflict, "invalid_item_transition"},
+	{domain.ErrOrderClosed, fiber.StatusConflict, "order_closed"},
+	{domain.ErrOrderNotAmendable, fiber.StatusConflict, "order_not_amendable"},
+	{domain.ErrInvalidSettings, fiber.StatusBadRequest, "invalid_settings"},
+	{domain.ErrTableNotFound, fiber.StatusNotFound, "table_not_found"},
+	{domain.ErrTableInUse, fiber.StatusConflict, "table_in_use"},
+	{domain.ErrTableCodeTaken, fiber.StatusConflict, "table_code_taken"},
+	{domain.ErrAreaNotFound, fiber.StatusNotFound, "area_not_found"},
+	{domain.ErrTableOffPlan, fiber.StatusBadRequest, "table_off_plan"},
+	{domain.ErrInvalidTableStatus, fiber.StatusBadRequest, "invalid_table_status"},
+	{domain.ErrOrderNotMovable, fiber.StatusConflict, "order_not_movable"},
+	{domain.ErrTableSessionLocked, fiber.StatusConflict, "table_session_locked"},
+	{domain.ErrSessionNotFound, fiber.StatusNotFound, "session_not_found"},
+	{domain.ErrInvalidSessionTransition, fiber.StatusConflict, "invalid_session_transition"},
+	{domain.ErrSessionHasBalance, fiber.StatusConflict, "session_has_balance"},
+	{domain.ErrServiceRequestNotFound, fiber.StatusNotFound, "service_request_not_found"},
+	{domain.ErrInvalidServiceRequest, fiber.StatusBadRequest, "invalid_service_request"},
+	{domain.ErrInvalidServiceTransition, fiber.StatusConflict, "invalid_service_transition"},
+	{domain.ErrServiceRequestThrottled, fiber.StatusTooManyRequests, "service_request_throttled"},
+	{domain.ErrServiceNoteTooLong, fiber.StatusBadRequest, "service_note_too_long"},
+	{domain.ErrBillNotFound, fiber.StatusNotFound, "bill_not_found"},
+	{domain.ErrInvalidSplit, fiber.StatusBadRequest, "invalid_split"},
+	{domain.ErrBillsHavePayments, fiber.StatusConflict, "bills_have_payments"},
+	{domain.ErrInvalidIdempotencyKey, fiber.StatusBadRequest, "invalid_idempotency_key"},
+	{domain.ErrIdempotencyKeyReused, fiber.StatusUnprocessableEntity, "idempotency_key_reused"},
+	{domain.ErrIdempotencyInProgress, fiber.StatusConflict, "idempotency_in_progress"},
+
+	{domain.ErrInvalidPaymentMethod, fiber.StatusBadRequest, "invalid_payment_method"},
+	{domain.ErrInvalidPaymentAmount, fiber.StatusBadRequest, "invalid_payment_amount"},
+	{domain.ErrInsufficientTender, fiber.StatusBadRequest, "insufficient_tender"},
+	{domain.ErrPaymentExceedsBalance, fiber.StatusConflict, "payment_exceeds_balance"},
+	{domain.ErrNothingToPay, fiber.StatusConflict, "nothing_to_pay"},
+	{domain.ErrOrderNotPayable, fiber.StatusConflict, "order_not_payable"},
+	{domain.ErrUnknownPaymentProvider, fiber.StatusNotFound, "unknown_payment_provider"},
+	{domain.ErrInvalidWebhookSignature, fiber.StatusUnauthorized, "invalid_signature"},
+	{domain.ErrPaymentIntentNotFound, fiber.StatusNotFound, "payment_intent_not_found"},
+	{domain.ErrPaymentAmountMismatch, fiber.StatusBadRequest, "payment_amount_mismatch"},
+	{domain.ErrInvalidEventID, fiber.StatusBadRequest, "invalid_event_id"},
+	{domain.ErrStationNotFound, fiber.StatusNotFound, "station_not_found"},
+	{domain.ErrTicketNotFound, fiber.StatusNotFound, "ticket_not_found"},
+	{domain.ErrTicketClosed, fiber.StatusConflict, "ticket_closed"},
+	{domain.ErrInvalidTicketStatus, fiber.StatusBadRequest, "invalid_ticket_status"},
+}
+
+// lookupDomainError reports the status and error code for err when it wraps a
+// known domain error.
+func lookupDomainError(err error) (int, string, bool) {
+	for _, de := range domainErrors {
+		if errors.Is(err, de.err) {
+			return de.status, de.code, true
+		}
+	}
+	return 0, "", false
+}
+
+// domainErrorBody renders the JSON error payload for a domain error.
+func domainErrorBody(code string, err error) fiber.Map {
+	return fiber.Map{"error": err.Error(), "code": code}
+}
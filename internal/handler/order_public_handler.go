@@ -1,6 +1,8 @@
 package handler
 
 import (
+	"context"
+
 	"qrmenu/internal/domain"
 	"qrmenu/internal/platform/logging"
 
@@ -14,7 +16,13 @@ type OrderCreateRequest = domain.OrderCreateRequest
 type OrderItemCreate = domain.OrderItemCreate
 
 type OrderCreator interface {
-	CreateGuestOrder(req OrderCreateRequest) (orderID string, status string, err error)
+	CreateGuestOrder(req OrderCreateRequest, idempotencyKey string) (orderID, status string, replayed bool, err error)
+	GetForGuest(id, guestSession string) (*domain.Order, error)
+	StreamForGuest(ctx context.Context, id, guestSession, lastEventID string) (<-chan domain.OrderEvent, error)
+
+	AddItemsForGuest(id, guestSession string, items []domain.OrderItemCreate) (*domain.Order, error)
+	SetItemQtyForGuest(id, guestSession, itemID string, qty int) (*domain.Order, error)
+	CancelForGuest(id, guestSession, reason string) (*domain.Order, error)
 }
 
 type OrderPublicHandler struct{ svc OrderCreator }
@@ -34,14 +42,130 @@ func (h *OrderPublicHandler) Create(c *fiber.Ctx) error {
 		return fiber.ErrBadRequest
 	}
 
-	id, status, err := h.svc.CreateGuestOrder(req)
+	// Idempotency-Key lets a guest retry safely; replays get the original response.
+	idemKey := c.Get("Idempotency-Key")
+	id, status, replayed, err := h.svc.CreateGuestOrder(req, idemKey)
 	if err != nil {
+		if code, errCode, ok := lookupDomainError(err); ok {
+			logging.HandlerError(c, "OrderPublic.Create", "order rejected", code, errCode, err, "tenant", req.Tenant, "table_token", req.TableToken)
+			return c.Status(code).JSON(domainErrorBody(errCode, err))
+		}
 		logging.HandlerError(c, "OrderPublic.Create", "failed to create order", fiber.StatusBadRequest, "order_create_failed", err, "tenant", req.Tenant, "table_token", req.TableToken)
 		return fiber.ErrBadRequest
 	}
-	logging.HandlerInfo(c, "OrderPublic.Create", "guest order created", fiber.StatusCreated, "order_created", "order_id", id, "tenant", req.Tenant)
+	if replayed {
+		c.Set("Idempotent-Replayed", "true")
+	}
+	logging.HandlerInfo(c, "OrderPublic.Create", "guest order created", fiber.StatusCreated, "order_created", "order_id", id, "tenant", req.Tenant, "replayed", replayed)
 	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
 		"order_id": id,
 		"status":   status,
 	})
 }
+
+// GET /api/v1/orders/:id?guest_session_id=
+func (h *OrderPublicHandler) Get(c *fiber.Ctx) error {
+	id := c.Params("id")
+	session := c.Query("guest_session_id")
+	if session == "" {
+		logging.HandlerError(c, "OrderPublic.Get", "guest session missing", fiber.StatusBadRequest, "guest_session_missing", fiber.ErrBadRequest, "order_id", id)
+		return fiber.ErrBadRequest
+	}
+
+	ord, err := h.svc.GetForGuest(id, session)
+	if err != nil {
+		if code, errCode, ok := lookupDomainError(err); ok {
+			logging.HandlerError(c, "OrderPublic.Get", "order lookup failed", code, errCode, err, "order_id", id)
+			return c.Status(code).JSON(domainErrorBody(errCode, err))
+		}
+		logging.HandlerError(c, "OrderPublic.Get", "query failed", fiber.StatusBadRequest, "order_query_failed", err, "order_id", id)
+		return fiber.ErrBadRequest
+	}
+	logging.HandlerInfo(c, "OrderPublic.Get", "order retrieved", fiber.StatusOK, "order_found", "order_id", id, "status", ord.Status)
+	return c.JSON(ord)
+}
+
+// GET /api/v1/orders/:id/stream?guest_session_id= (Server-Sent Events)
+func (h *OrderPublicHandler) Stream(c *fiber.Ctx) error {
+	id := c.Params("id")
+	session := c.Query("guest_session_id")
+	lastID := c.Get("Last-Event-ID", c.Query("last_event_id"))
+	if session == "" {
+		logging.HandlerError(c, "OrderPublic.Stream", "guest session missing", fiber.StatusBadRequest, "guest_session_missing", fiber.ErrBadRequest, "order_id", id)
+		return fiber.ErrBadRequest
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events, err := h.svc.StreamForGuest(ctx, id, session, lastID)
+	if err != nil {
+		cancel()
+		if code, errCode, ok := lookupDomainError(err); ok {
+			logging.HandlerError(c, "OrderPublic.Stream", "stream rejected", code, errCode, err, "order_id", id)
+			return c.Status(code).JSON(domainErrorBody(errCode, err))
+		}
+		logging.HandlerError(c, "OrderPublic.Stream", "subscribe failed", fiber.StatusServiceUnavailable, "order_stream_failed", err, "order_id", id)
+		return fiber.ErrServiceUnavailable
+	}
+
+	logging.HandlerInfo(c, "OrderPublic.Stream", "order stream opened", fiber.StatusOK, "order_stream_opened", "order_id", id, "last_event_id", lastID)
+	return streamOrderEvents(c, events, cancel)
+}
+
+// guestAmendReq is the JSON body of the guest amendment endpoints; the guest
+// session that placed the order authorizes the change.
+type guestAmendReq struct {
+	GuestSession string            `json:"guest_session_id"`
+	Items        []OrderItemCreate `json:"items"`
+	Qty          *int              `json:"qty"`
+	Reason       string            `json:"reason"`
+}
+
+// POST /api/v1/orders/:id/items
+func (h *OrderPublicHandler) AddItems(c *fiber.Ctx) error {
+	id := c.Params("id")
+	var req guestAmendReq
+	if err := c.BodyParser(&req); err != nil || req.GuestSession == "" || len(req.Items) == 0 {
+		logging.HandlerError(c, "OrderPublic.AddItems", "invalid payload", fiber.StatusBadRequest, "invalid_payload", fiber.ErrBadRequest, "order_id", id)
+		return fiber.ErrBadRequest
+	}
+	ord, err := h.svc.AddItemsForGuest(id, req.GuestSession, req.Items)
+	return h.amended(c, "OrderPublic.AddItems", id, ord, err)
+}
+
+// PATCH /api/v1/orders/:id/items/:item_id  {"guest_session_id": "...", "qty": 2}
+// A quantity of 0 removes the line.
+func (h *OrderPublicHandler) SetItemQty(c *fiber.Ctx) error {
+	id, itemID := c.Params("id"), c.Params("item_id")
+	var req guestAmendReq
+	if err := c.BodyParser(&req); err != nil || req.GuestSession == "" || req.Qty == nil {
+		logging.HandlerError(c, "OrderPublic.SetItemQty", "invalid payload", fiber.StatusBadRequest, "invalid_payload", fiber.ErrBadRequest, "order_id", id, "order_item_id", itemID)
+		return fiber.ErrBadRequest
+	}
+	ord, err := h.svc.SetItemQtyForGuest(id, req.GuestSession, itemID, *req.Qty)
+	return h.amended(c, "OrderPublic.SetItemQty", id, ord, err)
+}
+
+// POST /api/v1/orders/:id/cancel
+func (h *OrderPublicHandler) Cancel(c *fiber.Ctx) error {
+	id := c.Params("id")
+	var req guestAmendReq
+	if err := c.BodyParser(&req); err != nil || req.GuestSession == "" {
+		logging.HandlerError(c, "OrderPublic.Cancel", "invalid payload", fiber.StatusBadRequest, "invalid_payload", fiber.ErrBadRequest, "order_id", id)
+		return fiber.ErrBadRequest
+	}
+	ord, err := h.svc.CancelForGuest(id, req.GuestSession, req.Reason)
+	return h.amended(c, "OrderPublic.Cancel", id, ord, err)
+}
+
+func (h *OrderPublicHandler) amended(c *fiber.Ctx, scope, id string, ord *domain.Order, err error) error {
+	if err != nil {
+		if code, errCode, ok := lookupDomainError(err); ok {
+			logging.HandlerError(c, scope, "change rejected", code, errCode, err, "order_id", id)
+			return c.Status(code).JSON(domainErrorBody(errCode, err))
+		}
+		logging.HandlerError(c, scope, "change failed", fiber.StatusBadRequest, "order_amend_failed", err, "order_id", id)
+		return fiber.ErrBadRequest
+	}
+	logging.HandlerInfo(c, scope, "order changed", fiber.StatusOK, "order_amended", "order_id", id, "status", ord.Status, "total", ord.Total)
+	return c.JSON(ord)
+}
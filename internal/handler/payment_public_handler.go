@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"qrmenu/internal/domain"
+	"qrmenu/internal/platform/logging"
+)
+
+// GuestPaymentUseCase models the online payment flow used by the public payment HTTP adapter.
+type GuestPaymentUseCase interface {
+	StartGuestPayment(orderID, guestSession string, method domain.PaymentMethod) (*domain.PaymentIntent, error)
+	HandleWebhook(provider string, payload []byte, signature string) (*domain.PaymentIntent, error)
+}
+
+// PaymentPublicHandler exposes the guest payment and provider webhook endpoints.
+type PaymentPublicHandler struct {
+	uc GuestPaymentUseCase
+}
+
+// NewPaymentPublicHandler wires the gateway payment use case into a HTTP handler instance.
+func NewPaymentPublicHandler(uc GuestPaymentUseCase) *PaymentPublicHandler {
+	return &PaymentPublicHandler{uc: uc}
+}
+
+// Start begins an online payment for the outstanding balance of a guest order.
+func (h *PaymentPublicHandler) Start(c *fiber.Ctx) error {
+	orderID := c.Params("id")
+
+	var body struct {
+		GuestSession string `json:"guest_session_id"`
+		Method       string `json:"method"`
+	}
+	if err := c.BodyParser(&body); err != nil {
+		logging.HandlerError(c, "PaymentPublic.Start", "failed to parse body", fiber.StatusBadRequest, "invalid_body", err, "order_id", orderID)
+		return fiber.ErrBadRequest
+	}
+	if body.GuestSession == "" {
+		logging.HandlerError(c, "PaymentPublic.Start", "guest session missing", fiber.StatusBadRequest, "guest_session_missing", fiber.ErrBadRequest, "order_id", orderID)
+		return fiber.ErrBadRequest
+	}
+
+	intent, err := h.uc.StartGuestPayment(orderID, body.GuestSession, domain.PaymentMethod(body.Method))
+	if err != nil {
+		if code, errCode, ok := lookupDomainError(err); ok {
+			logging.HandlerError(c, "PaymentPublic.Start", "payment rejected", code, errCode, err, "order_id", orderID)
+			return c.Status(code).JSON(domainErrorBody(errCode, err))
+		}
+		logging.HandlerError(c, "PaymentPublic.Start", "payment start failed", fiber.StatusBadGateway, "payment_start_failed", err, "order_id", orderID)
+		return fiber.ErrBadGateway
+	}
+
+	logging.HandlerInfo(c, "PaymentPublic.Start", "payment started", fiber.StatusCreated, "payment_started", "order_id", orderID, "intent_id", intent.ID)
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"payment_id":   intent.ID,
+		"provider":     intent.Provider,
+		"reference":    intent.ProviderRef,
+		"amount":       intent.Amount,
+		"status":       intent.Status,
+		"checkout_url": intent.CheckoutURL,
+	})
+}
+
+// Webhook receives signed status callbacks from a payment provider.
+func (h *PaymentPublicHandler) Webhook(c *fiber.Ctx) error {
+	provider := c.Params("provider")
+	signature := c.Get("X-Signature")
+
+	intent, err := h.uc.HandleWebhook(provider, c.Body(), signature)
+	if err != nil {
+		if code, errCode, ok := lookupDomainError(err); ok {
+			logging.HandlerError(c, "PaymentPublic.Webhook", "webhook rejected", code, errCode, err, "provider", provider)
+			return c.Status(code).JSON(domainErrorBody(errCode, err))
+		}
+		logging.HandlerError(c, "PaymentPublic.Webhook", "webhook failed", fiber.StatusBadRequest, "webhook_failed", err, "provider", provider)
+		return fiber.ErrBadRequest
+	}
+
+	logging.HandlerInfo(c, "PaymentPublic.Webhook", "webhook processed", fiber.StatusOK, "webhook_processed", "provider", provider, "intent_id", intent.ID, "status", intent.Status)
+	return c.JSON(fiber.Map{"ok": true})
+}
@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"qrmenu/internal/domain"
+	"qrmenu/internal/platform/logging"
+)
+
+type ServiceRequestCreator interface {
+	Create(token string, typ domain.ServiceRequestType, note, guestSession string) (*domain.ServiceRequest, error)
+}
+
+// ServiceRequestPublicHandler lets guests call staff from their table.
+type ServiceRequestPublicHandler struct{ svc ServiceRequestCreator }
+
+func NewServiceRequestPublicHandler(s ServiceRequestCreator) *ServiceRequestPublicHandler {
+	return &ServiceRequestPublicHandler{svc: s}
+}
+
+type serviceRequestReq struct {
+	Type         string `json:"type"`
+	Note         string `json:"note"`
+	GuestSession string `json:"guest_session_id"`
+}
+
+// POST /api/v1/table/:token/requests
+func (h *ServiceRequestPublicHandler) Create(c *fiber.Ctx) error {
+	token := c.Params("token")
+	var req serviceRequestReq
+	if err := c.BodyParser(&req); err != nil {
+		logging.HandlerError(c, "ServiceRequestPublic.Create", "failed to parse body", fiber.StatusBadRequest, "invalid_body", err, "token", token)
+		return fiber.ErrBadRequest
+	}
+
+	sr, err := h.svc.Create(token, domain.ServiceRequestType(req.Type), req.Note, req.GuestSession)
+	if err != nil {
+		if code, errCode, ok := lookupDomainError(err); ok {
+			logging.HandlerError(c, "ServiceRequestPublic.Create", "request rejected", code, errCode, err, "token", token, "type", req.Type)
+			return c.Status(code).JSON(domainErrorBody(errCode, err))
+		}
+		logging.HandlerError(c, "ServiceRequestPublic.Create", "service error", fiber.StatusBadRequest, "service_request_failed", err, "token", token)
+		return fiber.ErrBadRequest
+	}
+	logging.HandlerInfo(c, "ServiceRequestPublic.Create", "service request created", fiber.StatusCreated, "service_request_created", "request_id", sr.ID, "table_id", sr.TableID, "type", sr.Type)
+	return c.Status(fiber.StatusCreated).JSON(sr)
+}
@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"qrmenu/internal/domain"
+)
+
+// sseHeartbeat keeps idle connections open through proxies and detects gone clients.
+const sseHeartbeat = 15 * time.Second
+
+// streamOrderEvents writes events to the client as Server-Sent Events until the
+// client disconnects or the channel closes; cancel is called on exit to release
+// the subscription.
+func streamOrderEvents(c *fiber.Ctx, events <-chan domain.OrderEvent, cancel context.CancelFunc) error {
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer cancel()
+		ticker := time.NewTicker(sseHeartbeat)
+		defer ticker.Stop()
+
+		// Tell EventSource how long to wait before reconnecting.
+		fmt.Fprint(w, "retry: 3000\n\n")
+		if err := w.Flush(); err != nil {
+			return
+		}
+		for {
+			select {
+			case ev, ok := <-events:
+				if !ok {
+					return
+				}
+				data, err := json.Marshal(ev)
+				if err != nil {
+					continue
+				}
+				fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", ev.ID, ev.Type, data)
+			case <-ticker.C:
+				fmt.Fprint(w, ": ping\n\n")
+			}
+			if err := w.Flush(); err != nil {
+				return
+			}
+		}
+	})
+	return nil
+}
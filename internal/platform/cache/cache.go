@@ -5,5 +5,6 @@ import "time"
 type Cache interface {
 	Get(key string) (string, error) // return "", nil if MISS
 	Set(key, val string, ttl time.Duration) error
+	SetNX(key, val string, ttl time.Duration) (bool, error) // false if the key already exists
 	Del(keys ...string) error
 }
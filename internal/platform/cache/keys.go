@@ -9,3 +9,11 @@ func KeyMenusByTenant(tenantID string) string {
 func KeyMenuByID(menuID string) string {
 	return fmt.Sprintf("menu:%s", menuID)
 }
+
+func KeyOrderIdempotency(tenantCode, guestSession, key string) string {
+	return fmt.Sprintf("idem:orders:%s:%s:%s", tenantCode, guestSession, key)
+}
+
+func KeyServiceRequestThrottle(tableID, requestType string) string {
+	return fmt.Sprintf("throttle:service:%s:%s", tableID, requestType)
+}
@@ -35,6 +35,10 @@ func NewRedis(addr, password string, db int) *RedisCache {
 	return &RedisCache{rdb: client, timeout: defaultTimeout}
 }
 
+// Client exposes the underlying redis client for features beyond key/value caching
+// (streams, pub/sub).
+func (c *RedisCache) Client() *redis.Client { return c.rdb }
+
 // Close releases the underlying redis connection pool.
 func (c *RedisCache) Close() error { return c.rdb.Close() }
 
@@ -57,6 +61,13 @@ func (c *RedisCache) Set(key, val string, ttl time.Duration) error {
 	return c.rdb.Set(ctx, key, val, ttl).Err()
 }
 
+// SetNX stores a value only if the key does not exist yet and reports whether it was stored.
+func (c *RedisCache) SetNX(key, val string, ttl time.Duration) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+	return c.rdb.SetNX(ctx, key, val, ttl).Result()
+}
+
 // Del removes one or more keys from the cache.
 func (c *RedisCache) Del(keys ...string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
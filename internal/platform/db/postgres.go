@@ -59,18 +59,34 @@ func AutoMigrate(db *gorm.DB) {
 	// Order ensures foreign keys reference already-migrated entities.
 	err := db.AutoMigrate(
 		&domain.Tenant{},
+		&domain.TableArea{},
 		&domain.Table{},
 
 		&domain.AdminUser{},
 
+		&domain.KitchenStation{},
 		&domain.Category{},
 		&domain.Item{},
 
 		&domain.ItemOption{},
 		&domain.ItemOptionValue{},
+		&domain.OptionAttachment{},
 
+		&domain.TableSession{},
 		&domain.Order{},
+		&domain.KitchenTicket{},
 		&domain.OrderItem{},
+		&domain.OrderItemSelection{},
+		&domain.OrderStatusHistory{},
+
+		&domain.Bill{},
+		&domain.BillLine{},
+		&domain.Payment{},
+		&domain.PaymentAllocation{},
+		&domain.PaymentIntent{},
+
+		&domain.ServiceRequest{},
+		&domain.TableStatusOverride{},
 	)
 	if err != nil {
 		log.Fatalf("AutoMigrate failed: %v", err)
@@ -0,0 +1,199 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"qrmenu/internal/domain"
+)
+
+const (
+	publishTimeout = 3 * time.Second
+	subscriberBuf  = 64
+)
+
+// RedisBroker distributes order events between API instances.
+//
+// Every event is appended to a capped per-tenant Redis stream, whose entry id
+// becomes the event id, and then broadcast on a per-tenant pub/sub channel and
+// on a per-order channel, so a guest following one order never receives the
+// rest of the tenant's traffic. Subscribers receive live events from a channel;
+// a subscriber resuming after a reconnect first replays the stream entries newer
+// than its last seen id.
+type RedisBroker struct {
+	rdb    *redis.Client
+	maxLen int64
+}
+
+// NewRedisBroker creates a broker keeping roughly the last maxLen events per tenant for replay.
+func NewRedisBroker(rdb *redis.Client, maxLen int64) *RedisBroker {
+	return &RedisBroker{rdb: rdb, maxLen: maxLen}
+}
+
+func streamKey(tenantID string) string      { return fmt.Sprintf("orders:events:tenant:%s", tenantID) }
+func channelKey(tenantID string) string     { return fmt.Sprintf("orders:live:tenant:%s", tenantID) }
+func orderChannelKey(orderID string) string { return fmt.Sprintf("orders:live:order:%s", orderID) }
+
+// Publish stores ev in the tenant stream and notifies live subscribers of the
+// tenant and of the event's order.
+// The assigned id is returned in the event.
+func (b *RedisBroker) Publish(ctx context.Context, ev domain.OrderEvent) (domain.OrderEvent, error) {
+	ctx, cancel := context.WithTimeout(ctx, publishTimeout)
+	defer cancel()
+
+	ev.ID = ""
+	data, err := json.Marshal(ev)
+	if err != nil {
+		return ev, err
+	}
+	id, err := b.rdb.XAdd(ctx, &redis.XAddArgs{
+		Stream: streamKey(ev.TenantID),
+		MaxLen: b.maxLen,
+		Approx: true,
+		Values: map[string]any{"data": data},
+	}).Result()
+	if err != nil {
+		return ev, err
+	}
+
+	ev.ID = id
+	msg, err := json.Marshal(ev)
+	if err != nil {
+		return ev, err
+	}
+	_, err = b.rdb.Pipelined(ctx, func(p redis.Pipeliner) error {
+		p.Publish(ctx, channelKey(ev.TenantID), msg)
+		if ev.OrderID != "" {
+			p.Publish(ctx, orderChannelKey(ev.OrderID), msg)
+		}
+		return nil
+	})
+	return ev, err
+}
+
+// Subscribe streams a tenant's events until ctx is canceled; the returned channel
+// is closed afterwards. When lastID is set, events after it that are still
+// retained in the stream are delivered first.
+func (b *RedisBroker) Subscribe(ctx context.Context, tenantID, lastID string) (<-chan domain.OrderEvent, error) {
+	return b.subscribe(ctx, channelKey(tenantID), tenantID, lastID, "")
+}
+
+// SubscribeOrder is Subscribe for the events of a single order of the tenant.
+func (b *RedisBroker) SubscribeOrder(ctx context.Context, tenantID, orderID, lastID string) (<-chan domain.OrderEvent, error) {
+	return b.subscribe(ctx, orderChannelKey(orderID), tenantID, lastID, orderID)
+}
+
+// subscribe listens on a pub/sub channel after replaying the tenant stream from
+// lastID; when orderID is set the replay keeps only that order's events.
+func (b *RedisBroker) subscribe(ctx context.Context, channel, tenantID, lastID, orderID string) (<-chan domain.OrderEvent, error) {
+	// Subscribe before replaying so nothing published in between is lost;
+	// duplicates are filtered by comparing ids.
+	ps := b.rdb.Subscribe(ctx, channel)
+	if _, err := ps.Receive(ctx); err != nil {
+		_ = ps.Close()
+		return nil, err
+	}
+
+	var backlog []domain.OrderEvent
+	if lastID != "" {
+		if _, ok := parseID(lastID); !ok {
+			_ = ps.Close()
+			return nil, fmt.Errorf("%w: %q", domain.ErrInvalidEventID, lastID)
+		}
+		msgs, err := b.rdb.XRangeN(ctx, streamKey(tenantID), "("+lastID, "+", b.maxLen).Result()
+		if err != nil {
+			_ = ps.Close()
+			return nil, err
+		}
+		for _, m := range msgs {
+			raw, _ := m.Values["data"].(string)
+			var ev domain.OrderEvent
+			if err := json.Unmarshal([]byte(raw), &ev); err != nil {
+				continue
+			}
+			if orderID != "" && ev.OrderID != orderID {
+				continue
+			}
+			ev.ID = m.ID
+			backlog = append(backlog, ev)
+		}
+	}
+
+	out := make(chan domain.OrderEvent, subscriberBuf)
+	go func() {
+		defer close(out)
+		defer ps.Close()
+
+		cursor := lastID
+		send := func(ev domain.OrderEvent) bool {
+			if cursor != "" && !idAfter(ev.ID, cursor) {
+				return true
+			}
+			select {
+			case out <- ev:
+				cursor = ev.ID
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		for _, ev := range backlog {
+			if !send(ev) {
+				return
+			}
+		}
+		live := ps.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case m, ok := <-live:
+				if !ok {
+					return
+				}
+				var ev domain.OrderEvent
+				if err := json.Unmarshal([]byte(m.Payload), &ev); err != nil {
+					continue
+				}
+				if !send(ev) {
+					return
+				}
+			}
+		}
+	}()
+	return out, nil
+}
+
+// parseID splits a stream entry id ("<ms>-<seq>") into its numeric parts.
+func parseID(id string) ([2]uint64, bool) {
+	ms, seq, found := strings.Cut(id, "-")
+	if !found {
+		return [2]uint64{}, false
+	}
+	a, err1 := strconv.ParseUint(ms, 10, 64)
+	b, err2 := strconv.ParseUint(seq, 10, 64)
+	if err1 != nil || err2 != nil {
+		return [2]uint64{}, false
+	}
+	return [2]uint64{a, b}, true
+}
+
+// idAfter reports whether stream id a is strictly newer than b.
+func idAfter(a, b string) bool {
+	x, ok1 := parseID(a)
+	y, ok2 := parseID(b)
+	if !ok1 || !ok2 {
+		return true
+	}
+	if x[0] != y[0] {
+		return x[0] > y[0]
+	}
+	return x[1] > y[1]
+}
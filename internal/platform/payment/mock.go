@@ -0,0 +1,100 @@
+package payment
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+)
+
+// MockProvider is a sandbox gateway that runs entirely in-process. Charges are kept
+// in memory and settled by posting a webhook signed with the shared secret, e.g.
+//
+//	body='{"reference":"mock_...","status":"succeeded","amount":25000}'
+//	sig=$(printf '%s' "$body" | openssl dgst -sha256 -hmac "$PAYMENT_WEBHOOK_SECRET" -hex | cut -d' ' -f2)
+//	curl -X POST -H "X-Signature: $sig" -d "$body" localhost:8080/api/v1/payments/webhook/mock
+type MockProvider struct {
+	secret      []byte
+	checkoutURL string
+
+	mu      sync.Mutex
+	charges map[string]Status
+}
+
+// NewMock creates a sandbox provider. checkoutURL is the base of the fake hosted payment page.
+func NewMock(secret, checkoutURL string) *MockProvider {
+	return &MockProvider{
+		secret:      []byte(secret),
+		checkoutURL: strings.TrimRight(checkoutURL, "/"),
+		charges:     map[string]Status{},
+	}
+}
+
+func (m *MockProvider) Name() string { return "mock" }
+
+func (m *MockProvider) CreateCharge(_ context.Context, req ChargeRequest) (*Charge, error) {
+	if req.Amount <= 0 {
+		return nil, errors.New("charge amount must be positive")
+	}
+	buf := make([]byte, 12)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, err
+	}
+	ref := "mock_" + hex.EncodeToString(buf)
+
+	m.mu.Lock()
+	m.charges[ref] = StatusPending
+	m.mu.Unlock()
+
+	return &Charge{
+		ProviderRef: ref,
+		CheckoutURL: m.checkoutURL + "/" + ref,
+		Status:      StatusPending,
+	}, nil
+}
+
+func (m *MockProvider) QueryStatus(_ context.Context, providerRef string) (Status, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	st, ok := m.charges[providerRef]
+	if !ok {
+		return "", errors.New("charge not found")
+	}
+	return st, nil
+}
+
+// VerifyWebhook checks the hex encoded HMAC-SHA256 of the raw payload.
+func (m *MockProvider) VerifyWebhook(payload []byte, signature string) (*WebhookEvent, error) {
+	if !hmac.Equal([]byte(m.Sign(payload)), []byte(strings.ToLower(strings.TrimSpace(signature)))) {
+		return nil, ErrInvalidSignature
+	}
+	var body struct {
+		Reference string `json:"reference"`
+		Status    Status `json:"status"`
+		Amount    int64  `json:"amount"`
+	}
+	if err := json.Unmarshal(payload, &body); err != nil {
+		return nil, err
+	}
+	if body.Reference == "" || (body.Status != StatusPending && !body.Status.Final()) {
+		return nil, errors.New("malformed webhook payload")
+	}
+
+	m.mu.Lock()
+	m.charges[body.Reference] = body.Status
+	m.mu.Unlock()
+
+	return &WebhookEvent{ProviderRef: body.Reference, Status: body.Status, Amount: body.Amount}, nil
+}
+
+// Sign returns the signature the mock expects for payload.
+func (m *MockProvider) Sign(payload []byte) string {
+	mac := hmac.New(sha256.New, m.secret)
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
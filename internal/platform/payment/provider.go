@@ -0,0 +1,56 @@
+package payment
+
+import (
+	"context"
+	"errors"
+)
+
+// Status is the lifecycle state of a charge at the payment provider.
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+	StatusExpired   Status = "expired"
+)
+
+// Final reports whether no further status changes are expected.
+func (s Status) Final() bool { return s == StatusSucceeded || s == StatusFailed || s == StatusExpired }
+
+// ErrInvalidSignature is returned when a webhook payload cannot be authenticated.
+var ErrInvalidSignature = errors.New("invalid webhook signature")
+
+// ChargeRequest asks a provider to collect Amount for one order.
+type ChargeRequest struct {
+	OrderID     string
+	Amount      int64
+	Method      string
+	Description string
+}
+
+// Charge is the provider's answer to a ChargeRequest.
+type Charge struct {
+	ProviderRef string
+	CheckoutURL string
+	Status      Status
+}
+
+// WebhookEvent is an authenticated status notification sent by a provider.
+type WebhookEvent struct {
+	ProviderRef string
+	Status      Status
+	Amount      int64
+}
+
+// Provider abstracts a payment gateway. Implementations must be safe for concurrent use.
+type Provider interface {
+	// Name identifies the provider in URLs and stored payment intents.
+	Name() string
+	// CreateCharge starts collecting a payment and returns where the guest completes it.
+	CreateCharge(ctx context.Context, req ChargeRequest) (*Charge, error)
+	// QueryStatus asks the provider for the current status of a charge.
+	QueryStatus(ctx context.Context, providerRef string) (Status, error)
+	// VerifyWebhook authenticates a webhook payload and decodes it.
+	VerifyWebhook(payload []byte, signature string) (*WebhookEvent, error)
+}
@@ -0,0 +1,119 @@
+package qr
+
+import (
+	"bytes"
+	"fmt"
+
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+// Format is the image format a QR code is rendered to.
+type Format string
+
+const (
+	FormatPNG Format = "png"
+	FormatSVG Format = "svg"
+)
+
+// ContentType is the MIME type of the rendered format.
+func (f Format) ContentType() string {
+	if f == FormatSVG {
+		return "image/svg+xml"
+	}
+	return "image/png"
+}
+
+// Level is the error-correction level: L (7%), M (15%), Q (25%) or H (30%) of
+// the code may be damaged and still scan.
+type Level string
+
+const (
+	LevelL Level = "L"
+	LevelM Level = "M"
+	LevelQ Level = "Q"
+	LevelH Level = "H"
+)
+
+var recoveryLevels = map[Level]qrcode.RecoveryLevel{
+	LevelL: qrcode.Low,
+	LevelM: qrcode.Medium,
+	LevelQ: qrcode.High,
+	LevelH: qrcode.Highest,
+}
+
+// Size bounds of a rendered code, in pixels per side.
+const (
+	MinSize     = 128
+	MaxSize     = 2048
+	DefaultSize = 512
+)
+
+// Options selects how a code is rendered. Zero values fall back to PNG, 512px and level M.
+type Options struct {
+	Format Format
+	Size   int
+	Level  Level
+}
+
+// Normalize fills in defaults and validates the options.
+func (o Options) Normalize() (Options, error) {
+	if o.Format == "" {
+		o.Format = FormatPNG
+	}
+	if o.Size == 0 {
+		o.Size = DefaultSize
+	}
+	if o.Level == "" {
+		o.Level = LevelM
+	}
+	if o.Format != FormatPNG && o.Format != FormatSVG {
+		return o, fmt.Errorf("format must be png or svg")
+	}
+	if o.Size < MinSize || o.Size > MaxSize {
+		return o, fmt.Errorf("size must be between %d and %d", MinSize, MaxSize)
+	}
+	if _, ok := recoveryLevels[o.Level]; !ok {
+		return o, fmt.Errorf("level must be one of L, M, Q, H")
+	}
+	return o, nil
+}
+
+// Render encodes content as a QR code image.
+func Render(content string, opts Options) ([]byte, error) {
+	opts, err := opts.Normalize()
+	if err != nil {
+		return nil, err
+	}
+	code, err := qrcode.New(content, recoveryLevels[opts.Level])
+	if err != nil {
+		return nil, err
+	}
+	if opts.Format == FormatSVG {
+		return svg(code.Bitmap(), opts.Size), nil
+	}
+	return code.PNG(opts.Size)
+}
+
+// svg draws the module bitmap (quiet zone included) as one path of unit squares
+// scaled to size, so the output stays small and crisp at any zoom.
+func svg(bitmap [][]bool, size int) []byte {
+	n := len(bitmap)
+	var b bytes.Buffer
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`, size, size, n, n)
+	fmt.Fprintf(&b, `<rect width="%d" height="%d" fill="#fff"/><path fill="#000" d="`, n, n)
+	for y, row := range bitmap {
+		for x := 0; x < len(row); x++ {
+			if !row[x] {
+				continue
+			}
+			run := 1
+			for x+run < len(row) && row[x+run] {
+				run++
+			}
+			fmt.Fprintf(&b, "M%d %dh%dv1h-%dz", x, y, run, run)
+			x += run - 1
+		}
+	}
+	b.WriteString(`"/></svg>`)
+	return b.Bytes()
+}
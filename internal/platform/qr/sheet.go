@@ -0,0 +1,129 @@
+package qr
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+)
+
+// Layout is how table codes are arranged on a printable sheet.
+type Layout string
+
+const (
+	// LayoutGrid prints 12 labelled codes per A4 page (3 × 4).
+	LayoutGrid Layout = "grid"
+	// LayoutTent prints two fold-in-half table tents per A4 page.
+	LayoutTent Layout = "tent"
+)
+
+// PerPage is the number of cards that fit on one page of the layout.
+func (l Layout) PerPage() int {
+	if l == LayoutTent {
+		return 2
+	}
+	return 12
+}
+
+// Valid reports whether l is a known layout.
+func (l Layout) Valid() bool { return l == LayoutGrid || l == LayoutTent }
+
+// SheetCard is one table on a sheet.
+type SheetCard struct {
+	Name string
+	Code string
+	URL  string
+	SVG  []byte
+}
+
+// Sheet is a printable document of table QR codes for one tenant.
+type Sheet struct {
+	Title   string
+	LogoURL string
+	Layout  Layout
+	Cards   []SheetCard
+}
+
+type sheetCard struct {
+	SheetCard
+	Image template.HTML
+}
+
+type sheetPage struct{ Cards []sheetCard }
+
+// RenderSheet renders s as a self-contained HTML document split into A4 pages,
+// ready to print from a browser (or to "Save as PDF").
+func RenderSheet(s Sheet) ([]byte, error) {
+	if !s.Layout.Valid() {
+		return nil, fmt.Errorf("layout must be grid or tent")
+	}
+	per := s.Layout.PerPage()
+	var pages []sheetPage
+	for i, c := range s.Cards {
+		if i%per == 0 {
+			pages = append(pages, sheetPage{})
+		}
+		// The SVG is produced by Render from module data only, never from user input.
+		p := &pages[len(pages)-1]
+		p.Cards = append(p.Cards, sheetCard{SheetCard: c, Image: template.HTML(c.SVG)})
+	}
+	var b bytes.Buffer
+	err := sheetTemplate.Execute(&b, map[string]any{
+		"Title":   s.Title,
+		"LogoURL": s.LogoURL,
+		"Layout":  string(s.Layout),
+		"Pages":   pages,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+var sheetTemplate = template.Must(template.New("sheet").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+@page { size: A4; margin: 10mm; }
+* { box-sizing: border-box; }
+body { margin: 0; font-family: Helvetica, Arial, sans-serif; color: #000; }
+.page { width: 190mm; height: 277mm; page-break-after: always; break-after: page; display: grid; }
+.page:last-child { page-break-after: auto; break-after: auto; }
+.card { display: flex; flex-direction: column; align-items: center; justify-content: center; text-align: center; border: 0.2mm dashed #999; padding: 4mm; overflow: hidden; }
+.card svg { display: block; }
+.card img.logo { max-height: 12mm; max-width: 80%; margin-bottom: 2mm; }
+.card .name { font-size: 14pt; font-weight: bold; margin-top: 2mm; }
+.card .code { font-size: 10pt; color: #444; }
+.grid { grid-template-columns: repeat(3, 1fr); grid-template-rows: repeat(4, 1fr); }
+.grid .card svg { width: 45mm; height: 45mm; }
+.tent { grid-template-rows: repeat(2, 1fr); }
+.tent .card { display: grid; grid-template-rows: 1fr 1fr; padding: 0; }
+.tent .side { display: flex; flex-direction: column; align-items: center; justify-content: center; padding: 6mm; }
+.tent .side + .side { border-top: 0.2mm dashed #999; }
+.tent .back { transform: rotate(180deg); }
+.tent .card svg { width: 45mm; height: 45mm; }
+.tent .name { font-size: 20pt; }
+.url { font-size: 6pt; color: #666; word-break: break-all; margin-top: 1mm; }
+.hint { font-size: 9pt; color: #444; margin-top: 1mm; }
+</style>
+</head>
+<body>
+{{- $logo := .LogoURL }}{{ $layout := .Layout }}
+{{- range .Pages }}
+<section class="page {{$layout}}">
+{{- range .Cards }}
+{{- if eq $layout "tent" }}
+  <div class="card">
+    <div class="side back">{{ if $logo }}<img class="logo" src="{{$logo}}" alt="">{{ end }}{{.Image}}<div class="name">{{.Name}}</div>{{ if .Code }}<div class="code">{{.Code}}</div>{{ end }}<div class="hint">Scan to order</div></div>
+    <div class="side">{{ if $logo }}<img class="logo" src="{{$logo}}" alt="">{{ end }}{{.Image}}<div class="name">{{.Name}}</div>{{ if .Code }}<div class="code">{{.Code}}</div>{{ end }}<div class="hint">Scan to order</div></div>
+  </div>
+{{- else }}
+  <div class="card">{{ if $logo }}<img class="logo" src="{{$logo}}" alt="">{{ end }}{{.Image}}<div class="name">{{.Name}}</div>{{ if .Code }}<div class="code">{{.Code}}</div>{{ end }}<div class="url">{{.URL}}</div></div>
+{{- end }}
+{{- end }}
+</section>
+{{- end }}
+</body>
+</html>
+`))
@@ -0,0 +1,16 @@
+package security
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+)
+
+// RandomToken returns n bytes from crypto/rand encoded as unpadded base64url,
+// suitable for URLs and QR codes.
+func RandomToken(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
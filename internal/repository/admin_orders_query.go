@@ -16,11 +16,11 @@ func NewAdminOrdersQuery(orders OrderRepository) AdminOrdersQuery {
 }
 
 func (q *adminOrdersQuery) List(status, cursor, tenantID string) (OrdersPage, error) {
-	return q.orders.ListAdmin(tenantID, status, cursor, 20)
+	return q.orders.ListAdmin(tenantID, status, "", cursor, 20)
 }
 
 func (q *adminOrdersQuery) UpdateStatus(id, status, tenantID string) (map[string]any, error) {
-	o, err := q.orders.UpdateStatus(tenantID, id, domain.OrderStatus(status))
+	o, err := q.orders.UpdateStatus(tenantID, id, domain.OrderStatusChange{To: domain.OrderStatus(status)})
 	if err != nil { return map[string]any{}, err }
 	return map[string]any{
 		"id": o.ID, "tenant_id": o.TenantID, "table_id": o.TableID,
@@ -0,0 +1,245 @@
+package repository
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"qrmenu/internal/domain"
+	"qrmenu/internal/platform/logging"
+)
+
+type BillRepository interface {
+	Split(tenantID, tableID string, req domain.SplitRequest) ([]domain.Bill, error)
+	ListByTable(tenantID, tableID string) ([]domain.Bill, error)
+	Find(tenantID, id string) (*domain.Bill, error)
+	Clear(tenantID, tableID string) error
+	Pay(tenantID, id string, req domain.PaymentRequest) (*domain.Payment, error)
+}
+
+type billRepo struct{ db *gorm.DB }
+
+func NewBillRepository(db *gorm.DB) BillRepository { return &billRepo{db: db} }
+
+// Split divides the outstanding orders of the table's current session into bills,
+// replacing an earlier split as long as none of its bills has been paid.
+func (r *billRepo) Split(tenantID, tableID string, req domain.SplitRequest) ([]domain.Bill, error) {
+	var bills []domain.Bill
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		s, err := lockedSession(tx, tenantID, tableID)
+		if err != nil {
+			return err
+		}
+		if err := clearBills(tx, s.ID); err != nil {
+			return err
+		}
+
+		var orders []domain.Order
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("session_id = ? AND status <> ? AND paid_amount < total", s.ID, domain.OrderCanceled).
+			Order("created_at ASC, id ASC").
+			Find(&orders).Error; err != nil {
+			return err
+		}
+		if len(orders) == 0 {
+			return domain.ErrNothingToPay
+		}
+		for i := range orders {
+			if err := tx.Where("order_id = ?", orders[i].ID).Order("id ASC").Find(&orders[i].Items).Error; err != nil {
+				return err
+			}
+		}
+
+		if bills, err = domain.SplitOrders(orders, req); err != nil {
+			return err
+		}
+		for i := range bills {
+			bills[i].TenantID, bills[i].SessionID, bills[i].TableID = tenantID, s.ID, tableID
+		}
+		return tx.Create(&bills).Error
+	})
+	if err != nil {
+		logging.RepoError("BillRepository.Split", "split failed", "split_failed", err, "tenant_id", tenantID, "table_id", tableID, "mode", req.Mode)
+		return nil, err
+	}
+	logging.RepoInfo("BillRepository.Split", "bills created", "bills_split", "tenant_id", tenantID, "table_id", tableID, "mode", req.Mode, "bills", len(bills))
+	return bills, nil
+}
+
+// ListByTable returns the bills of the table's current session.
+func (r *billRepo) ListByTable(tenantID, tableID string) ([]domain.Bill, error) {
+	var bills []domain.Bill
+	err := r.db.
+		Joins("JOIN table_sessions ts ON ts.id = bills.session_id AND ts.status <> ?", domain.SessionClosed).
+		Where("bills.tenant_id = ? AND bills.table_id = ?", tenantID, tableID).
+		Preload("Lines", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		Order("bills.created_at ASC, bills.label ASC").
+		Find(&bills).Error
+	if err != nil {
+		logging.RepoError("BillRepository.ListByTable", "query failed", "query_failed", err, "tenant_id", tenantID, "table_id", tableID)
+		return nil, err
+	}
+	logging.RepoInfo("BillRepository.ListByTable", "bills listed", "bills_listed", "tenant_id", tenantID, "table_id", tableID, "count", len(bills))
+	return bills, nil
+}
+
+func (r *billRepo) Find(tenantID, id string) (*domain.Bill, error) {
+	var b domain.Bill
+	if err := r.db.Where("id = ? AND tenant_id = ?", id, tenantID).
+		Preload("Lines", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		First(&b).Error; err != nil {
+		logging.RepoError("BillRepository.Find", "load failed", "load_failed", err, "tenant_id", tenantID, "bill_id", id)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrBillNotFound
+		}
+		return nil, err
+	}
+	return &b, nil
+}
+
+// Clear removes the split of the table's current session; paid bills cannot be removed.
+func (r *billRepo) Clear(tenantID, tableID string) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		s, err := lockedSession(tx, tenantID, tableID)
+		if err != nil {
+			return err
+		}
+		return clearBills(tx, s.ID)
+	})
+	if err != nil {
+		logging.RepoError("BillRepository.Clear", "clear failed", "bills_clear_failed", err, "tenant_id", tenantID, "table_id", tableID)
+		return err
+	}
+	logging.RepoInfo("BillRepository.Clear", "bills cleared", "bills_cleared", "tenant_id", tenantID, "table_id", tableID)
+	return nil
+}
+
+// Pay records a payment against a bill. The amount is allocated over the bill's
+// lines in order, each capped to what its order still owes, so payments made
+// outside the bill are never collected twice.
+func (r *billRepo) Pay(tenantID, id string, req domain.PaymentRequest) (*domain.Payment, error) {
+	var pay domain.Payment
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var b domain.Bill
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND tenant_id = ?", id, tenantID).First(&b).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return domain.ErrBillNotFound
+			}
+			return err
+		}
+		if err := tx.Where("bill_id = ?", b.ID).Order("id ASC").Find(&b.Lines).Error; err != nil {
+			return err
+		}
+		orderIDs := make([]string, 0, len(b.Lines))
+		for _, l := range b.Lines {
+			orderIDs = append(orderIDs, l.OrderID)
+		}
+		orders, err := lockOrders(tx, tenantID, uniqueStrings(orderIDs))
+		if err != nil {
+			return err
+		}
+
+		// What each line can still collect, given what its order still owes.
+		owed := map[string]int64{}
+		for _, o := range orders {
+			if o.Status != domain.OrderCanceled {
+				owed[o.ID] = o.Outstanding()
+			}
+		}
+		payable := make([]int64, len(b.Lines))
+		var outstanding int64
+		for i, l := range b.Lines {
+			payable[i] = min(l.Outstanding(), owed[l.OrderID])
+			owed[l.OrderID] -= payable[i]
+			outstanding += payable[i]
+		}
+		if outstanding == 0 {
+			return domain.ErrNothingToPay
+		}
+		amount, tendered, err := tenderFor(req, outstanding)
+		if err != nil {
+			return err
+		}
+
+		pay = domain.Payment{
+			TenantID:   tenantID,
+			TableID:    b.TableID,
+			BillID:     &b.ID,
+			Method:     req.Method,
+			Amount:     amount,
+			Tendered:   tendered,
+			Change:     tendered - amount,
+			Reference:  optionalString(req.Reference),
+			ReceivedBy: optionalString(req.ReceivedBy),
+		}
+		perOrder := map[string]int64{}
+		remaining := amount
+		for i := range b.Lines {
+			part := min(payable[i], remaining)
+			if part == 0 {
+				continue
+			}
+			remaining -= part
+			l := &b.Lines[i]
+			l.PaidAmount += part
+			if err := tx.Model(&domain.BillLine{}).Where("id = ?", l.ID).Update("paid_amount", l.PaidAmount).Error; err != nil {
+				return err
+			}
+			if perOrder[l.OrderID] == 0 {
+				pay.Allocations = append(pay.Allocations, domain.PaymentAllocation{OrderID: l.OrderID})
+			}
+			perOrder[l.OrderID] += part
+		}
+		for i := range pay.Allocations {
+			pay.Allocations[i].Amount = perOrder[pay.Allocations[i].OrderID]
+		}
+
+		b.PaidAmount += amount
+		if err := tx.Model(&domain.Bill{}).Where("id = ?", b.ID).Updates(map[string]any{
+			"paid_amount": b.PaidAmount,
+			"paid_status": domain.PaidStatusFor(b.PaidAmount, b.Total),
+		}).Error; err != nil {
+			return err
+		}
+		return applyPayment(tx, orders, &pay)
+	})
+	if err != nil {
+		logging.RepoError("BillRepository.Pay", "payment failed", "payment_failed", err, "tenant_id", tenantID, "bill_id", id, "method", req.Method)
+		return nil, err
+	}
+	logging.RepoInfo("BillRepository.Pay", "bill payment recorded", "bill_payment_recorded", "tenant_id", tenantID, "bill_id", id, "payment_id", pay.ID, "amount", pay.Amount)
+	return &pay, nil
+}
+
+// lockedSession locks the table and returns its current session.
+func lockedSession(tx *gorm.DB, tenantID, tableID string) (*domain.TableSession, error) {
+	if err := lockTable(tx, tenantID, tableID); err != nil {
+		return nil, err
+	}
+	s, err := activeSession(tx, tableID)
+	if err != nil {
+		return nil, err
+	}
+	if s == nil {
+		return nil, domain.ErrSessionNotFound
+	}
+	return s, nil
+}
+
+// clearBills deletes the bills of a session unless one of them was paid.
+func clearBills(tx *gorm.DB, sessionID string) error {
+	var paid int64
+	if err := tx.Model(&domain.Bill{}).Where("session_id = ? AND paid_amount > 0", sessionID).Count(&paid).Error; err != nil {
+		return err
+	}
+	if paid > 0 {
+		return domain.ErrBillsHavePayments
+	}
+	if err := tx.Where("bill_id IN (?)", tx.Model(&domain.Bill{}).Select("id").Where("session_id = ?", sessionID)).
+		Delete(&domain.BillLine{}).Error; err != nil {
+		return err
+	}
+	return tx.Where("session_id = ?", sessionID).Delete(&domain.Bill{}).Error
+}
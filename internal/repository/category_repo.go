@@ -40,8 +40,10 @@ func (r *categoryRepo) Create(c *domain.Category) error {
 	return nil
 }
 
+// Replace overwrites every editable column, including zero values and a nil station.
 func (r *categoryRepo) Replace(c *domain.Category) error {
-	if err := r.db.Where("id = ? AND tenant_id = ?", c.ID, c.TenantID).Updates(c).Error; err != nil {
+	if err := r.db.Model(c).Where("id = ? AND tenant_id = ?", c.ID, c.TenantID).
+		Select("name", "sort", "is_active", "station_id", "availability").Updates(c).Error; err != nil {
 		logging.RepoError("CategoryRepository.Replace", "update failed", "update_failed", err, "tenant_id", c.TenantID, "category_id", c.ID)
 		return err
 	}
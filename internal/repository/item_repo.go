@@ -45,8 +45,12 @@ func (r *itemRepo) Create(i *domain.Item) error {
 	return nil
 }
 
+// Replace overwrites every editable column, including zero values and nil
+// description, photo and station. Flags are not part of the admin payload and are kept.
 func (r *itemRepo) Replace(i *domain.Item) error {
-	if err := r.db.Where("id = ? AND tenant_id = ?", i.ID, i.TenantID).Updates(i).Error; err != nil {
+	if err := r.db.Model(i).Where("id = ? AND tenant_id = ?", i.ID, i.TenantID).
+		Select("category_id", "name", "description", "price", "photo_url", "is_active", "station_id", "availability").
+		Updates(i).Error; err != nil {
 		logging.RepoError("ItemRepository.Replace", "update failed", "update_failed", err, "tenant_id", i.TenantID, "item_id", i.ID)
 		return err
 	}
@@ -0,0 +1,209 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"qrmenu/internal/domain"
+	"qrmenu/internal/platform/logging"
+)
+
+// TicketFilter narrows the kitchen display. StationID "unassigned" selects
+// tickets for lines without a station; empty fields match everything.
+type TicketFilter struct {
+	StationID string
+	Status    domain.TicketStatus
+}
+
+const UnassignedStation = "unassigned"
+
+type KitchenRepository interface {
+	ListStations(tenantID string) ([]domain.KitchenStation, error)
+	CreateStation(s *domain.KitchenStation) error
+	PatchStation(tenantID, id string, fields map[string]any) (*domain.KitchenStation, error)
+	DeleteStation(tenantID, id string) error
+	FindStation(tenantID, id string) (*domain.KitchenStation, error)
+
+	ListTickets(tenantID string, f TicketFilter) ([]domain.KitchenTicket, error)
+	SetTicketStatus(tenantID, id string, status domain.TicketStatus, changedBy string) (*domain.KitchenTicket, *domain.Order, error)
+}
+
+type kitchenRepo struct{ db *gorm.DB }
+
+func NewKitchenRepository(db *gorm.DB) KitchenRepository { return &kitchenRepo{db: db} }
+
+func (r *kitchenRepo) ListStations(tenantID string) ([]domain.KitchenStation, error) {
+	var xs []domain.KitchenStation
+	if err := r.db.Where("tenant_id = ?", tenantID).Order("sort ASC, name ASC").Find(&xs).Error; err != nil {
+		logging.RepoError("KitchenRepository.ListStations", "query failed", "query_failed", err, "tenant_id", tenantID)
+		return nil, err
+	}
+	logging.RepoInfo("KitchenRepository.ListStations", "stations listed", "stations_listed", "tenant_id", tenantID, "count", len(xs))
+	return xs, nil
+}
+
+func (r *kitchenRepo) CreateStation(s *domain.KitchenStation) error {
+	if err := r.db.Create(s).Error; err != nil {
+		logging.RepoError("KitchenRepository.CreateStation", "insert failed", "insert_failed", err, "tenant_id", s.TenantID)
+		return err
+	}
+	logging.RepoInfo("KitchenRepository.CreateStation", "station created", "station_created", "tenant_id", s.TenantID, "station_id", s.ID)
+	return nil
+}
+
+func (r *kitchenRepo) PatchStation(tenantID, id string, fields map[string]any) (*domain.KitchenStation, error) {
+	res := r.db.Model(&domain.KitchenStation{}).Where("id = ? AND tenant_id = ?", id, tenantID).Updates(fields)
+	if res.Error != nil {
+		logging.RepoError("KitchenRepository.PatchStation", "update failed", "update_failed", res.Error, "tenant_id", tenantID, "station_id", id)
+		return nil, res.Error
+	}
+	logging.RepoInfo("KitchenRepository.PatchStation", "station patched", "station_patched", "tenant_id", tenantID, "station_id", id)
+	return r.FindStation(tenantID, id)
+}
+
+// DeleteStation removes a station and unassigns the categories, items and
+// tickets routed to it. The foreign keys do this in migrated databases, but
+// AutoMigrate creates none, so it is done explicitly.
+func (r *kitchenRepo) DeleteStation(tenantID, id string) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("id = ? AND tenant_id = ?", id, tenantID).Delete(&domain.KitchenStation{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return domain.ErrStationNotFound
+		}
+		for _, model := range []any{&domain.Category{}, &domain.Item{}, &domain.KitchenTicket{}} {
+			if err := tx.Model(model).Where("tenant_id = ? AND station_id = ?", tenantID, id).
+				Update("station_id", nil).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		logging.RepoError("KitchenRepository.DeleteStation", "delete failed", "delete_failed", err, "tenant_id", tenantID, "station_id", id)
+		return err
+	}
+	logging.RepoInfo("KitchenRepository.DeleteStation", "station deleted", "station_deleted", "tenant_id", tenantID, "station_id", id)
+	return nil
+}
+
+func (r *kitchenRepo) FindStation(tenantID, id string) (*domain.KitchenStation, error) {
+	var s domain.KitchenStation
+	if err := r.db.Where("id = ? AND tenant_id = ?", id, tenantID).First(&s).Error; err != nil {
+		logging.RepoError("KitchenRepository.FindStation", "query failed", "query_failed", err, "tenant_id", tenantID, "station_id", id)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrStationNotFound
+		}
+		return nil, err
+	}
+	return &s, nil
+}
+
+// ListTickets returns the tickets of active (not done or canceled) orders, oldest first,
+// with their lines and selected options.
+func (r *kitchenRepo) ListTickets(tenantID string, f TicketFilter) ([]domain.KitchenTicket, error) {
+	q := r.db.Joins("JOIN orders o ON o.id = kitchen_tickets.order_id").
+		Where("kitchen_tickets.tenant_id = ? AND o.status NOT IN ?", tenantID, []domain.OrderStatus{domain.OrderDone, domain.OrderCanceled})
+	switch f.StationID {
+	case "":
+	case UnassignedStation:
+		q = q.Where("kitchen_tickets.station_id IS NULL")
+	default:
+		q = q.Where("kitchen_tickets.station_id = ?", f.StationID)
+	}
+	if f.Status != "" {
+		q = q.Where("kitchen_tickets.status = ?", f.Status)
+	}
+
+	var xs []domain.KitchenTicket
+	if err := q.Preload("Items.Selections").
+		Order("kitchen_tickets.created_at ASC, kitchen_tickets.id ASC").
+		Find(&xs).Error; err != nil {
+		logging.RepoError("KitchenRepository.ListTickets", "query failed", "query_failed", err, "tenant_id", tenantID, "station_id", f.StationID)
+		return nil, err
+	}
+	logging.RepoInfo("KitchenRepository.ListTickets", "tickets listed", "tickets_listed", "tenant_id", tenantID, "station_id", f.StationID, "count", len(xs))
+	return xs, nil
+}
+
+// SetTicketStatus bumps or recalls a ticket, moves its lines to ready (or back
+// to cooking) and rolls the lines up onto the parent order status, recording the
+// change in the order history. The order is returned only when its status changed.
+func (r *kitchenRepo) SetTicketStatus(tenantID, id string, status domain.TicketStatus, changedBy string) (*domain.KitchenTicket, *domain.Order, error) {
+	var (
+		ticket  domain.KitchenTicket
+		changed bool
+	)
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("id = ? AND tenant_id = ?", id, tenantID).First(&ticket).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return domain.ErrTicketNotFound
+			}
+			return err
+		}
+		// Lock the order before its tickets so concurrent bumps on one order serialize.
+		var ord domain.Order
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", ticket.OrderID).First(&ord).Error; err != nil {
+			return err
+		}
+		if ord.Status == domain.OrderDone || ord.Status == domain.OrderCanceled {
+			return fmt.Errorf("%w: order is %s", domain.ErrTicketClosed, ord.Status)
+		}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", ticket.ID).First(&ticket).Error; err != nil {
+			return err
+		}
+		if ticket.Status == status {
+			return nil
+		}
+
+		// Bumping readies the ticket's unfinished lines; recalling sends ready lines back to cooking.
+		from, to := []domain.OrderItemStatus{domain.ItemQueued, domain.ItemCooking}, domain.ItemReady
+		var bumpedAt *time.Time
+		if status == domain.TicketBumped {
+			now := time.Now()
+			bumpedAt = &now
+		} else {
+			from, to = []domain.OrderItemStatus{domain.ItemReady}, domain.ItemCooking
+		}
+		if err := tx.Model(&domain.KitchenTicket{}).Where("id = ?", ticket.ID).Updates(map[string]any{
+			"status":    status,
+			"bumped_at": bumpedAt,
+		}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&domain.OrderItem{}).
+			Where("ticket_id = ? AND status IN ?", ticket.ID, from).
+			Update("status", to).Error; err != nil {
+			return err
+		}
+
+		var err error
+		changed, err = rollUpOrderStatus(tx, &ord, changedBy, "kitchen ticket "+string(status))
+		return err
+	})
+	if err != nil {
+		logging.RepoError("KitchenRepository.SetTicketStatus", "update failed", "update_failed", err, "tenant_id", tenantID, "ticket_id", id, "status", status)
+		return nil, nil, err
+	}
+
+	if err := r.db.Preload("Items.Selections").Where("id = ?", id).First(&ticket).Error; err != nil {
+		return nil, nil, err
+	}
+	logging.RepoInfo("KitchenRepository.SetTicketStatus", "ticket updated", "ticket_updated", "tenant_id", tenantID, "ticket_id", id, "status", status, "order_changed", changed)
+	if !changed {
+		return &ticket, nil, nil
+	}
+	ord, err := NewOrderRepository(r.db).FindByID(tenantID, ticket.OrderID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &ticket, ord, nil
+}
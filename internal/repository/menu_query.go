@@ -17,7 +17,8 @@ func NewMenuQuery(db *gorm.DB) MenuQuery { return &menuQuery{db} }
 
 // GetMenuByTenantCode returns a menu response based on the given tenant code.
 // It first finds the tenant based on the given code, then retrieves the categories and items
-// for the tenant. If the tenant is not found, it returns an error.
+// for the tenant, with each item's options and option values nested underneath.
+// Availability flags are left unset; they depend on the time the menu is served. If the tenant is not found, it returns an error.
 // If there is an error during the database query, it also returns an error.
 func (q *menuQuery) GetMenuByTenantCode(code string) (*domain.MenuResponse, error) {
 	var t domain.Tenant
@@ -38,10 +39,64 @@ func (q *menuQuery) GetMenuByTenantCode(code string) (*domain.MenuResponse, erro
 		logging.RepoError("MenuQuery.GetMenuByTenantCode", "items lookup failed", "items_query_failed", err, "tenant_id", t.ID)
 		return nil, err
 	}
+	menuItems, err := q.attachOptions(t.ID, items)
+	if err != nil {
+		logging.RepoError("MenuQuery.GetMenuByTenantCode", "options lookup failed", "options_query_failed", err, "tenant_id", t.ID)
+		return nil, err
+	}
+	menuCats := make([]domain.MenuCategory, 0, len(cats))
+	for _, c := range cats {
+		menuCats = append(menuCats, domain.MenuCategory{Category: c})
+	}
 	logging.RepoInfo("MenuQuery.GetMenuByTenantCode", "menu loaded", "menu_loaded", "tenant_code", code, "categories", len(cats), "items", len(items))
 	return &domain.MenuResponse{
 		Tenant:     t.Code,
-		Categories: cats,
-		Items:      items,
+		Timezone:   t.Timezone,
+		Categories: menuCats,
+		Items:      menuItems,
 	}, nil
 }
+
+// attachOptions loads the options offered on each of the given items (through
+// item or category attachments) with their values, and nests them under each item.
+func (q *menuQuery) attachOptions(tenantID string, items []domain.Item) ([]domain.MenuItem, error) {
+	out := make([]domain.MenuItem, 0, len(items))
+	if len(items) == 0 {
+		return out, nil
+	}
+
+	optsByItem, err := attachedOptions(q.db, tenantID, items)
+	if err != nil {
+		return nil, err
+	}
+	optIDs := []string{}
+	for _, opts := range optsByItem {
+		for _, o := range opts {
+			optIDs = append(optIDs, o.ID)
+		}
+	}
+	valuesByOption := map[string][]domain.ItemOptionValue{}
+	if len(optIDs) > 0 {
+		var vals []domain.ItemOptionValue
+		if err := q.db.Where("option_id IN ?", uniqueStrings(optIDs)).
+			Order("sort ASC, label ASC").Find(&vals).Error; err != nil {
+			return nil, err
+		}
+		for _, v := range vals {
+			valuesByOption[v.OptionID] = append(valuesByOption[v.OptionID], v)
+		}
+	}
+
+	for _, it := range items {
+		mo := make([]domain.MenuItemOption, 0, len(optsByItem[it.ID]))
+		for _, o := range optsByItem[it.ID] {
+			vals := valuesByOption[o.ID]
+			if vals == nil {
+				vals = []domain.ItemOptionValue{}
+			}
+			mo = append(mo, domain.MenuItemOption{ItemOption: o, Values: vals})
+		}
+		out = append(out, domain.MenuItem{Item: it, Options: mo})
+	}
+	return out, nil
+}
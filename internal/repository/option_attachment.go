@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"sort"
+
+	"gorm.io/gorm"
+
+	"qrmenu/internal/domain"
+)
+
+// attachedOptions resolves the options offered on each of the given items of a
+// tenant, keyed by item id: options attached to the item itself and to its
+// category, the item's attachment winning when both exist, with the
+// attachment overrides applied and ordered by sort and name.
+func attachedOptions(db *gorm.DB, tenantID string, items []domain.Item) (map[string][]domain.ItemOption, error) {
+	out := make(map[string][]domain.ItemOption, len(items))
+	if len(items) == 0 {
+		return out, nil
+	}
+	itemIDs := make([]string, 0, len(items))
+	catIDs := make([]string, 0, len(items))
+	for _, it := range items {
+		itemIDs = append(itemIDs, it.ID)
+		catIDs = append(catIDs, it.CategoryID)
+	}
+
+	var atts []domain.OptionAttachment
+	if err := db.Where("tenant_id = ? AND (item_id IN ? OR category_id IN ?)", tenantID, itemIDs, uniqueStrings(catIDs)).
+		Find(&atts).Error; err != nil {
+		return nil, err
+	}
+	if len(atts) == 0 {
+		return out, nil
+	}
+	byItem := map[string][]domain.OptionAttachment{}
+	byCategory := map[string][]domain.OptionAttachment{}
+	optIDs := make([]string, 0, len(atts))
+	for _, a := range atts {
+		if a.ItemID != nil {
+			byItem[*a.ItemID] = append(byItem[*a.ItemID], a)
+		} else if a.CategoryID != nil {
+			byCategory[*a.CategoryID] = append(byCategory[*a.CategoryID], a)
+		}
+		optIDs = append(optIDs, a.OptionID)
+	}
+
+	var opts []domain.ItemOption
+	if err := db.Where("id IN ? AND tenant_id = ?", uniqueStrings(optIDs), tenantID).Find(&opts).Error; err != nil {
+		return nil, err
+	}
+	optByID := make(map[string]domain.ItemOption, len(opts))
+	for _, o := range opts {
+		optByID[o.ID] = o
+	}
+
+	for _, it := range items {
+		chosen := map[string]domain.OptionAttachment{}
+		for _, a := range byCategory[it.CategoryID] {
+			chosen[a.OptionID] = a
+		}
+		for _, a := range byItem[it.ID] {
+			chosen[a.OptionID] = a
+		}
+		list := make([]domain.ItemOption, 0, len(chosen))
+		for optID, a := range chosen {
+			if o, ok := optByID[optID]; ok {
+				list = append(list, a.Apply(o))
+			}
+		}
+		sort.Slice(list, func(i, j int) bool {
+			if list[i].Sort != list[j].Sort {
+				return list[i].Sort < list[j].Sort
+			}
+			if list[i].Name != list[j].Name {
+				return list[i].Name < list[j].Name
+			}
+			return list[i].ID < list[j].ID
+		})
+		if len(list) > 0 {
+			out[it.ID] = list
+		}
+	}
+	return out, nil
+}
@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"errors"
+
 	"qrmenu/internal/domain"
 	"qrmenu/internal/platform/logging"
 
@@ -8,46 +10,85 @@ import (
 )
 
 type OptionRepository interface {
+	ListOptions(tenantID string) ([]domain.ItemOption, error)
+	CreateOption(opt *domain.ItemOption) error
 	ListItemOptions(itemID, tenantID string) ([]domain.ItemOption, error)
 	CreateItemOption(itemID, tenantID string, opt *domain.ItemOption) error
+	FindItemOption(optionID, tenantID string) (*domain.ItemOption, error)
+	PatchItemOption(optionID, tenantID string, fields map[string]any) (*domain.ItemOption, error)
+	DeleteItemOption(optionID, tenantID string) error
 
 	ListOptionValues(optionID, tenantID string) ([]domain.ItemOptionValue, error)
 	CreateOptionValue(optionID, tenantID string, v *domain.ItemOptionValue) error
+	FindOptionValue(optionID, valueID, tenantID string) (*domain.ItemOptionValue, error)
+	PatchOptionValue(optionID, valueID, tenantID string, fields map[string]any) (*domain.ItemOptionValue, error)
+	DeleteOptionValue(optionID, valueID, tenantID string) error
+
+	ListAttachments(optionID, tenantID string) ([]domain.OptionAttachment, error)
+	Attach(a *domain.OptionAttachment) error
+	Detach(optionID, attachmentID, tenantID string) error
 }
 
 type optionRepo struct{ db *gorm.DB }
 
 func NewOptionRepository(db *gorm.DB) OptionRepository { return &optionRepo{db} }
 
-func (r *optionRepo) ListItemOptions(itemID, tenantID string) ([]domain.ItemOption, error) {
-	// Guard tenant ownership via join to items table
+func (r *optionRepo) ListOptions(tenantID string) ([]domain.ItemOption, error) {
 	var xs []domain.ItemOption
-	err := r.db.Table("item_options io").
-		Select("io.*").
-		Joins("JOIN items i ON i.id = io.item_id AND i.tenant_id = ?", tenantID).
-		Where("io.item_id = ?", itemID).
-		Order("io.name ASC").Scan(&xs).Error
+	if err := r.db.Where("tenant_id = ?", tenantID).Order("sort ASC, name ASC").Find(&xs).Error; err != nil {
+		logging.RepoError("OptionRepository.ListOptions", "query failed", "query_failed", err, "tenant_id", tenantID)
+		return nil, err
+	}
+	logging.RepoInfo("OptionRepository.ListOptions", "options listed", "options_listed", "tenant_id", tenantID, "count", len(xs))
+	return xs, nil
+}
+
+func (r *optionRepo) CreateOption(opt *domain.ItemOption) error {
+	if err := r.db.Create(opt).Error; err != nil {
+		logging.RepoError("OptionRepository.CreateOption", "insert failed", "insert_failed", err, "tenant_id", opt.TenantID)
+		return err
+	}
+	logging.RepoInfo("OptionRepository.CreateOption", "option created", "option_created", "tenant_id", opt.TenantID, "option_id", opt.ID)
+	return nil
+}
+
+// ListItemOptions returns the options offered on an item, through its own or its
+// category's attachments, with the attachment overrides applied.
+func (r *optionRepo) ListItemOptions(itemID, tenantID string) ([]domain.ItemOption, error) {
+	var item domain.Item
+	if err := r.db.Where("id = ? AND tenant_id = ?", itemID, tenantID).First(&item).Error; err != nil {
+		logging.RepoError("OptionRepository.ListItemOptions", "item lookup failed", "item_lookup_failed", err, "tenant_id", tenantID, "item_id", itemID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrItemNotFound
+		}
+		return nil, err
+	}
+	byItem, err := attachedOptions(r.db, tenantID, []domain.Item{item})
 	if err != nil {
 		logging.RepoError("OptionRepository.ListItemOptions", "query failed", "query_failed", err, "tenant_id", tenantID, "item_id", itemID)
 		return nil, err
 	}
+	xs := byItem[itemID]
+	if xs == nil {
+		xs = []domain.ItemOption{}
+	}
 	logging.RepoInfo("OptionRepository.ListItemOptions", "options listed", "options_listed", "tenant_id", tenantID, "item_id", itemID, "count", len(xs))
-	return xs, err
+	return xs, nil
 }
 
+// CreateItemOption creates an option and attaches it to the item.
 func (r *optionRepo) CreateItemOption(itemID, tenantID string, opt *domain.ItemOption) error {
-	// Verify the item belongs to the tenant
-	var cnt int64
-	if err := r.db.Model(&domain.Item{}).
-		Where("id = ? AND tenant_id = ?", itemID, tenantID).Count(&cnt).Error; err != nil {
-		logging.RepoError("OptionRepository.CreateItemOption", "item validation failed", "item_validation_failed", err, "tenant_id", tenantID, "item_id", itemID)
-		return err
-	}
-	if cnt == 0 {
-		return gorm.ErrRecordNotFound
-	}
-	opt.ItemID = itemID
-	if err := r.db.Create(opt).Error; err != nil {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tenantItem(tx, itemID, tenantID); err != nil {
+			return err
+		}
+		opt.TenantID = tenantID
+		if err := tx.Create(opt).Error; err != nil {
+			return err
+		}
+		return tx.Create(&domain.OptionAttachment{TenantID: tenantID, OptionID: opt.ID, ItemID: &itemID}).Error
+	})
+	if err != nil {
 		logging.RepoError("OptionRepository.CreateItemOption", "insert failed", "insert_failed", err, "tenant_id", tenantID, "item_id", itemID)
 		return err
 	}
@@ -59,10 +100,9 @@ func (r *optionRepo) ListOptionValues(optionID, tenantID string) ([]domain.ItemO
 	var xs []domain.ItemOptionValue
 	err := r.db.Table("item_option_values v").
 		Select("v.*").
-		Joins("JOIN item_options o ON o.id = v.option_id").
-		Joins("JOIN items i ON i.id = o.item_id AND i.tenant_id = ?", tenantID).
+		Joins("JOIN item_options o ON o.id = v.option_id AND o.tenant_id = ?", tenantID).
 		Where("v.option_id = ?", optionID).
-		Order("v.label ASC").Scan(&xs).Error
+		Order("v.sort ASC, v.label ASC").Scan(&xs).Error
 	if err != nil {
 		logging.RepoError("OptionRepository.ListOptionValues", "query failed", "query_failed", err, "tenant_id", tenantID, "option_id", optionID)
 		return nil, err
@@ -72,18 +112,11 @@ func (r *optionRepo) ListOptionValues(optionID, tenantID string) ([]domain.ItemO
 }
 
 func (r *optionRepo) CreateOptionValue(optionID, tenantID string, v *domain.ItemOptionValue) error {
-	// Ensure option → item → tenant ownership aligns
-	var cnt int64
-	if err := r.db.Table("item_options o").
-		Joins("JOIN items i ON i.id = o.item_id").
-		Where("o.id = ? AND i.tenant_id = ?", optionID, tenantID).
-		Count(&cnt).Error; err != nil {
+	// Ensure the option belongs to the tenant
+	if err := tenantOption(r.db, optionID, tenantID); err != nil {
 		logging.RepoError("OptionRepository.CreateOptionValue", "option validation failed", "option_validation_failed", err, "tenant_id", tenantID, "option_id", optionID)
 		return err
 	}
-	if cnt == 0 {
-		return gorm.ErrRecordNotFound
-	}
 	v.OptionID = optionID
 	if err := r.db.Create(v).Error; err != nil {
 		logging.RepoError("OptionRepository.CreateOptionValue", "insert failed", "insert_failed", err, "tenant_id", tenantID, "option_id", optionID)
@@ -92,3 +125,201 @@ func (r *optionRepo) CreateOptionValue(optionID, tenantID string, v *domain.Item
 	logging.RepoInfo("OptionRepository.CreateOptionValue", "option value created", "option_value_created", "tenant_id", tenantID, "option_id", optionID, "value_id", v.ID)
 	return nil
 }
+
+func (r *optionRepo) FindItemOption(optionID, tenantID string) (*domain.ItemOption, error) {
+	var o domain.ItemOption
+	err := r.db.Where("id = ? AND tenant_id = ?", optionID, tenantID).Take(&o).Error
+	if err != nil {
+		logging.RepoError("OptionRepository.FindItemOption", "query failed", "query_failed", err, "tenant_id", tenantID, "option_id", optionID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrOptionNotFound
+		}
+		return nil, err
+	}
+	return &o, nil
+}
+
+func (r *optionRepo) PatchItemOption(optionID, tenantID string, fields map[string]any) (*domain.ItemOption, error) {
+	if err := tenantOption(r.db, optionID, tenantID); err != nil {
+		logging.RepoError("OptionRepository.PatchItemOption", "option validation failed", "option_validation_failed", err, "tenant_id", tenantID, "option_id", optionID)
+		return nil, err
+	}
+	if len(fields) > 0 {
+		if err := r.db.Model(&domain.ItemOption{}).Where("id = ?", optionID).Updates(fields).Error; err != nil {
+			logging.RepoError("OptionRepository.PatchItemOption", "update failed", "update_failed", err, "tenant_id", tenantID, "option_id", optionID)
+			return nil, err
+		}
+	}
+	logging.RepoInfo("OptionRepository.PatchItemOption", "option patched", "option_patched", "tenant_id", tenantID, "option_id", optionID)
+	return r.FindItemOption(optionID, tenantID)
+}
+
+// DeleteItemOption removes an option together with its values and attachments.
+// Orders keep their own snapshot of the selections, so past orders are not affected.
+func (r *optionRepo) DeleteItemOption(optionID, tenantID string) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tenantOption(tx, optionID, tenantID); err != nil {
+			return err
+		}
+		if err := tx.Where("option_id = ?", optionID).Delete(&domain.ItemOptionValue{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("option_id = ?", optionID).Delete(&domain.OptionAttachment{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", optionID).Delete(&domain.ItemOption{}).Error
+	})
+	if err != nil {
+		logging.RepoError("OptionRepository.DeleteItemOption", "delete failed", "delete_failed", err, "tenant_id", tenantID, "option_id", optionID)
+		return err
+	}
+	logging.RepoInfo("OptionRepository.DeleteItemOption", "option deleted", "option_deleted", "tenant_id", tenantID, "option_id", optionID)
+	return nil
+}
+
+func (r *optionRepo) FindOptionValue(optionID, valueID, tenantID string) (*domain.ItemOptionValue, error) {
+	var v domain.ItemOptionValue
+	err := r.db.Table("item_option_values v").
+		Select("v.*").
+		Joins("JOIN item_options o ON o.id = v.option_id AND o.tenant_id = ?", tenantID).
+		Where("v.id = ? AND v.option_id = ?", valueID, optionID).
+		Take(&v).Error
+	if err != nil {
+		logging.RepoError("OptionRepository.FindOptionValue", "query failed", "query_failed", err, "tenant_id", tenantID, "option_id", optionID, "value_id", valueID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrOptionValueNotFound
+		}
+		return nil, err
+	}
+	return &v, nil
+}
+
+func (r *optionRepo) PatchOptionValue(optionID, valueID, tenantID string, fields map[string]any) (*domain.ItemOptionValue, error) {
+	if _, err := r.FindOptionValue(optionID, valueID, tenantID); err != nil {
+		return nil, err
+	}
+	if len(fields) > 0 {
+		if err := r.db.Model(&domain.ItemOptionValue{}).Where("id = ?", valueID).Updates(fields).Error; err != nil {
+			logging.RepoError("OptionRepository.PatchOptionValue", "update failed", "update_failed", err, "tenant_id", tenantID, "option_id", optionID, "value_id", valueID)
+			return nil, err
+		}
+	}
+	logging.RepoInfo("OptionRepository.PatchOptionValue", "option value patched", "option_value_patched", "tenant_id", tenantID, "option_id", optionID, "value_id", valueID)
+	return r.FindOptionValue(optionID, valueID, tenantID)
+}
+
+func (r *optionRepo) DeleteOptionValue(optionID, valueID, tenantID string) error {
+	if _, err := r.FindOptionValue(optionID, valueID, tenantID); err != nil {
+		return err
+	}
+	if err := r.db.Where("id = ?", valueID).Delete(&domain.ItemOptionValue{}).Error; err != nil {
+		logging.RepoError("OptionRepository.DeleteOptionValue", "delete failed", "delete_failed", err, "tenant_id", tenantID, "option_id", optionID, "value_id", valueID)
+		return err
+	}
+	logging.RepoInfo("OptionRepository.DeleteOptionValue", "option value deleted", "option_value_deleted", "tenant_id", tenantID, "option_id", optionID, "value_id", valueID)
+	return nil
+}
+
+func (r *optionRepo) ListAttachments(optionID, tenantID string) ([]domain.OptionAttachment, error) {
+	if err := tenantOption(r.db, optionID, tenantID); err != nil {
+		logging.RepoError("OptionRepository.ListAttachments", "option validation failed", "option_validation_failed", err, "tenant_id", tenantID, "option_id", optionID)
+		return nil, err
+	}
+	var xs []domain.OptionAttachment
+	if err := r.db.Where("option_id = ?", optionID).Order("created_at ASC").Find(&xs).Error; err != nil {
+		logging.RepoError("OptionRepository.ListAttachments", "query failed", "query_failed", err, "tenant_id", tenantID, "option_id", optionID)
+		return nil, err
+	}
+	logging.RepoInfo("OptionRepository.ListAttachments", "attachments listed", "attachments_listed", "tenant_id", tenantID, "option_id", optionID, "count", len(xs))
+	return xs, nil
+}
+
+// Attach offers an option on an item or category of the tenant. Attaching it
+// again to the same item or category replaces the overrides of the existing
+// attachment, whose id is kept.
+func (r *optionRepo) Attach(a *domain.OptionAttachment) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tenantOption(tx, a.OptionID, a.TenantID); err != nil {
+			return err
+		}
+		q := tx.Where("option_id = ?", a.OptionID)
+		if a.ItemID != nil {
+			if err := tenantItem(tx, *a.ItemID, a.TenantID); err != nil {
+				return err
+			}
+			q = q.Where("item_id = ?", *a.ItemID)
+		} else {
+			var n int64
+			if err := tx.Model(&domain.Category{}).Where("id = ? AND tenant_id = ?", *a.CategoryID, a.TenantID).Count(&n).Error; err != nil {
+				return err
+			}
+			if n == 0 {
+				return domain.ErrCategoryNotFound
+			}
+			q = q.Where("category_id = ?", *a.CategoryID)
+		}
+
+		var existing domain.OptionAttachment
+		err := q.Take(&existing).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return tx.Create(a).Error
+		}
+		if err != nil {
+			return err
+		}
+		a.ID, a.CreatedAt = existing.ID, existing.CreatedAt
+		return tx.Model(&domain.OptionAttachment{}).Where("id = ?", a.ID).Updates(map[string]any{
+			"required":   a.Required,
+			"min_select": a.MinSelect,
+			"max_select": a.MaxSelect,
+			"sort":       a.Sort,
+		}).Error
+	})
+	if err != nil {
+		logging.RepoError("OptionRepository.Attach", "attach failed", "attach_failed", err, "tenant_id", a.TenantID, "option_id", a.OptionID)
+		return err
+	}
+	logging.RepoInfo("OptionRepository.Attach", "option attached", "option_attached", "tenant_id", a.TenantID, "option_id", a.OptionID, "attachment_id", a.ID)
+	return nil
+}
+
+func (r *optionRepo) Detach(optionID, attachmentID, tenantID string) error {
+	res := r.db.Where("id = ? AND option_id = ? AND tenant_id = ?", attachmentID, optionID, tenantID).Delete(&domain.OptionAttachment{})
+	if res.Error != nil {
+		logging.RepoError("OptionRepository.Detach", "delete failed", "delete_failed", res.Error, "tenant_id", tenantID, "option_id", optionID, "attachment_id", attachmentID)
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return domain.ErrAttachmentNotFound
+	}
+	logging.RepoInfo("OptionRepository.Detach", "option detached", "option_detached", "tenant_id", tenantID, "option_id", optionID, "attachment_id", attachmentID)
+	return nil
+}
+
+// tenantOption checks that the option belongs to the tenant.
+func tenantOption(tx *gorm.DB, optionID, tenantID string) error {
+	var cnt int64
+	if err := tx.Model(&domain.ItemOption{}).
+		Where("id = ? AND tenant_id = ?", optionID, tenantID).
+		Count(&cnt).Error; err != nil {
+		return err
+	}
+	if cnt == 0 {
+		return domain.ErrOptionNotFound
+	}
+	return nil
+}
+
+// tenantItem checks that the item belongs to the tenant.
+func tenantItem(tx *gorm.DB, itemID, tenantID string) error {
+	var cnt int64
+	if err := tx.Model(&domain.Item{}).
+		Where("id = ? AND tenant_id = ?", itemID, tenantID).
+		Count(&cnt).Error; err != nil {
+		return err
+	}
+	if cnt == 0 {
+		return domain.ErrItemNotFound
+	}
+	return nil
+}
@@ -0,0 +1,302 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+
+	"qrmenu/internal/domain"
+	"qrmenu/internal/platform/logging"
+)
+
+// menuCatalog holds the menu rows needed to price an order. It is loaded with a
+// fixed number of queries regardless of how many lines the basket contains.
+type menuCatalog struct {
+	items    map[string]domain.Item
+	options  map[string][]domain.ItemOption      // keyed by item id, overrides applied
+	values   map[string][]domain.ItemOptionValue // keyed by option id
+	stations map[string]*string                  // kitchen station keyed by category id
+	hours    map[string]domain.Availability      // category schedules keyed by category id
+	now      time.Time                           // current time in the tenant's timezone
+}
+
+// loadMenuCatalog fetches the active items referenced by the order together with
+// the options attached to them, option values and the kitchen stations and
+// availability schedules of their categories.
+func loadMenuCatalog(tx *gorm.DB, tenantID string, lines []domain.OrderItemCreate) (*menuCatalog, error) {
+	ids := make([]string, 0, len(lines))
+	for _, ln := range lines {
+		ids = append(ids, ln.ItemID)
+	}
+
+	cat := &menuCatalog{
+		items:    map[string]domain.Item{},
+		options:  map[string][]domain.ItemOption{},
+		values:   map[string][]domain.ItemOptionValue{},
+		stations: map[string]*string{},
+		hours:    map[string]domain.Availability{},
+	}
+
+	var tz string
+	if err := tx.Model(&domain.Tenant{}).Select("timezone").Where("id = ?", tenantID).Scan(&tz).Error; err != nil {
+		return nil, err
+	}
+	loc, err := domain.LoadTimezone(tz)
+	if err != nil {
+		logging.RepoError("OrderPricing.loadMenuCatalog", "unknown tenant timezone, using the server time zone", "timezone_invalid", err, "tenant_id", tenantID, "timezone", tz)
+	}
+	cat.now = time.Now().In(loc)
+
+	var items []domain.Item
+	if err := tx.Where("id IN ? AND tenant_id = ? AND is_active = TRUE", ids, tenantID).
+		Find(&items).Error; err != nil {
+		return nil, err
+	}
+	for _, it := range items {
+		cat.items[it.ID] = it
+	}
+	if len(items) == 0 {
+		return cat, nil
+	}
+
+	catIDs := make([]string, 0, len(items))
+	for _, it := range items {
+		catIDs = append(catIDs, it.CategoryID)
+	}
+	var cats []domain.Category
+	if err := tx.Select("id", "station_id", "availability").Where("id IN ?", catIDs).Find(&cats).Error; err != nil {
+		return nil, err
+	}
+	for _, c := range cats {
+		cat.stations[c.ID] = c.StationID
+		cat.hours[c.ID] = c.Availability
+	}
+
+	opts, err := attachedOptions(tx, tenantID, items)
+	if err != nil {
+		return nil, err
+	}
+	optIDs := []string{}
+	for itemID, xs := range opts {
+		cat.options[itemID] = xs
+		for _, o := range xs {
+			optIDs = append(optIDs, o.ID)
+		}
+	}
+	if len(optIDs) == 0 {
+		return cat, nil
+	}
+
+	var vals []domain.ItemOptionValue
+	if err := tx.Where("option_id IN ?", uniqueStrings(optIDs)).Order("sort ASC, label ASC").Find(&vals).Error; err != nil {
+		return nil, err
+	}
+	for _, v := range vals {
+		cat.values[v.OptionID] = append(cat.values[v.OptionID], v)
+	}
+	return cat, nil
+}
+
+// priceLine validates the guest's option selection for one line against the menu
+// and returns the priced order item (not yet attached to an order).
+//
+// Options are keyed by option id; each entry is a value id, a list of value ids
+// or an object of value quantities keyed by value id. Options left out get their
+// default values; a null entry selects nothing.
+func (m *menuCatalog) priceLine(in domain.OrderItemCreate) (domain.OrderItem, error) {
+	if in.Qty <= 0 {
+		return domain.OrderItem{}, fmt.Errorf("%w: item %s", domain.ErrInvalidQuantity, in.ItemID)
+	}
+	item, ok := m.items[in.ItemID]
+	if !ok {
+		return domain.OrderItem{}, fmt.Errorf("%w: item %s", domain.ErrItemUnavailable, in.ItemID)
+	}
+	if !m.hours[item.CategoryID].AvailableAt(m.now) || !item.Availability.AvailableAt(m.now) {
+		return domain.OrderItem{}, fmt.Errorf("%w: item %s", domain.ErrItemNotAvailableNow, item.ID)
+	}
+
+	opts := m.options[item.ID]
+	known := make(map[string]domain.ItemOption, len(opts))
+	for _, o := range opts {
+		known[o.ID] = o
+	}
+	for optID := range in.Options {
+		if _, ok := known[optID]; !ok {
+			return domain.OrderItem{}, fmt.Errorf("%w: item %s option %s", domain.ErrUnknownOption, item.ID, optID)
+		}
+	}
+
+	var (
+		selections []domain.OrderItemSelection
+		delta      int64
+		normalized = datatypes.JSONMap{}
+	)
+	for _, opt := range opts {
+		raw, present := in.Options[opt.ID]
+		qtys, err := m.selectedValues(opt.ID, raw, present)
+		if err != nil {
+			return domain.OrderItem{}, fmt.Errorf("%w: item %s option %s", err, item.ID, opt.ID)
+		}
+		if err := checkSelection(opt, qtys); err != nil {
+			return domain.OrderItem{}, fmt.Errorf("%w: item %s option %s", err, item.ID, opt.ID)
+		}
+		if len(qtys) == 0 {
+			continue
+		}
+
+		var (
+			ids    []string
+			counts = map[string]any{}
+			multi  bool
+		)
+		for _, val := range m.values[opt.ID] {
+			qty, picked := qtys[val.ID]
+			if !picked {
+				continue
+			}
+			if qty > 1 && qty > val.MaxQty {
+				return domain.OrderItem{}, fmt.Errorf("%w: option %s value %s", domain.ErrOptionValueQtyLimit, opt.ID, val.ID)
+			}
+			selections = append(selections, domain.OrderItemSelection{
+				OptionID:   opt.ID,
+				OptionName: opt.Name,
+				ValueID:    val.ID,
+				ValueLabel: val.Label,
+				Qty:        qty,
+				DeltaPrice: val.DeltaPrice,
+			})
+			delta += val.DeltaPrice * int64(qty)
+			ids = append(ids, val.ID)
+			counts[val.ID] = qty
+			multi = multi || qty > 1
+		}
+		switch {
+		case opt.Type != domain.OptionTypeAddon:
+			normalized[opt.ID] = ids[0]
+		case multi:
+			normalized[opt.ID] = counts
+		default:
+			normalized[opt.ID] = ids
+		}
+	}
+
+	oi := domain.OrderItem{
+		ItemID:       item.ID,
+		Name:         item.Name,
+		Qty:          in.Qty,
+		UnitPrice:    item.Price,
+		OptionsPrice: delta,
+		LineTotal:    (item.Price + delta) * int64(in.Qty),
+		Status:       domain.ItemQueued,
+		Selections:   selections,
+	}
+	if len(normalized) > 0 {
+		oi.Options = normalized
+	}
+	return oi, nil
+}
+
+// stationFor returns the kitchen station preparing an item: the item's own
+// station, else its category's, else nil.
+func (m *menuCatalog) stationFor(itemID string) *string {
+	it := m.items[itemID]
+	if it.StationID != nil {
+		return it.StationID
+	}
+	return m.stations[it.CategoryID]
+}
+
+// selectedValues resolves a raw JSON selection into quantities keyed by value
+// id, checking that every value belongs to the option. An option left out of the
+// request selects its default values once each.
+func (m *menuCatalog) selectedValues(optionID string, raw any, present bool) (map[string]int, error) {
+	qtys := map[string]int{}
+	if !present {
+		for _, v := range m.values[optionID] {
+			if v.IsDefault {
+				qtys[v.ID] = 1
+			}
+		}
+		return qtys, nil
+	}
+	switch v := raw.(type) {
+	case nil:
+	case string:
+		if v != "" {
+			qtys[v] = 1
+		}
+	case []any:
+		for _, x := range v {
+			s, ok := x.(string)
+			if !ok || s == "" {
+				return nil, domain.ErrInvalidOptionValue
+			}
+			qtys[s] = 1
+		}
+	case []string:
+		for _, s := range v {
+			qtys[s] = 1
+		}
+	case map[string]any:
+		for id, x := range v {
+			n, ok := x.(float64)
+			if !ok || n < 0 || n != float64(int(n)) {
+				return nil, domain.ErrInvalidOptionValue
+			}
+			if n > 0 {
+				qtys[id] = int(n)
+			}
+		}
+	default:
+		return nil, domain.ErrInvalidOptionValue
+	}
+
+	for id := range qtys {
+		found := false
+		for _, val := range m.values[optionID] {
+			if val.ID == id {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%w: value %s", domain.ErrUnknownOptionValue, id)
+		}
+	}
+	return qtys, nil
+}
+
+// checkSelection enforces the option's min/max selection counts, where each
+// value counts as many times as it was picked.
+func checkSelection(opt domain.ItemOption, qtys map[string]int) error {
+	count := 0
+	for _, n := range qtys {
+		count += n
+	}
+	if count == 0 && opt.MinChoices() > 0 {
+		return domain.ErrMissingRequiredOption
+	}
+	if count < opt.MinChoices() {
+		return fmt.Errorf("%w: at least %d", domain.ErrTooFewOptionValues, opt.MinChoices())
+	}
+	if max := opt.MaxChoices(); max > 0 && count > max {
+		return fmt.Errorf("%w: at most %d", domain.ErrTooManyOptionValues, max)
+	}
+	return nil
+}
+
+// applyOrderTotals recomputes the order level totals from its lines; voided
+// lines are not charged.
+func applyOrderTotals(o *domain.Order, lines []domain.OrderItem) {
+	o.Subtotal, o.OptionsTotal, o.Total = 0, 0, 0
+	for _, ln := range lines {
+		if ln.Status == domain.ItemVoided {
+			continue
+		}
+		o.Subtotal += ln.UnitPrice * int64(ln.Qty)
+		o.OptionsTotal += ln.OptionsPrice * int64(ln.Qty)
+		o.Total += ln.LineTotal
+	}
+}
@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"qrmenu/internal/domain"
+)
+
+func pricingCatalog() *menuCatalog {
+	return &menuCatalog{
+		items: map[string]domain.Item{
+			"tea": {ID: "tea", Name: "Tea", Price: 20000},
+		},
+		options: map[string][]domain.ItemOption{
+			"tea": {
+				{ID: "size", Name: "Size", Type: domain.OptionTypeSize, Required: true},
+				{ID: "top", Name: "Toppings", Type: domain.OptionTypeAddon, MaxSelect: 3},
+			},
+		},
+		values: map[string][]domain.ItemOptionValue{
+			"size": {
+				{ID: "m", OptionID: "size", Label: "M", MaxQty: 1, IsDefault: true},
+				{ID: "l", OptionID: "size", Label: "L", DeltaPrice: 5000, MaxQty: 1},
+			},
+			"top": {
+				{ID: "pearl", OptionID: "top", Label: "Pearl", DeltaPrice: 3000, MaxQty: 2, IsDefault: true},
+				{ID: "jelly", OptionID: "top", Label: "Jelly", DeltaPrice: 4000, MaxQty: 1},
+			},
+		},
+		stations: map[string]*string{},
+		hours:    map[string]domain.Availability{},
+		now:      time.Date(2025, 11, 3, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+func equalQtys(a, b map[string]int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if b[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+func TestSelectedValues(t *testing.T) {
+	m := pricingCatalog()
+	tests := []struct {
+		name    string
+		option  string
+		raw     any
+		present bool
+		want    map[string]int
+		wantErr error
+	}{
+		{name: "omitted selects defaults", option: "top", want: map[string]int{"pearl": 1}},
+		{name: "null selects nothing", option: "top", raw: nil, present: true, want: map[string]int{}},
+		{name: "single value id", option: "size", raw: "l", present: true, want: map[string]int{"l": 1}},
+		{name: "empty string selects nothing", option: "size", raw: "", present: true, want: map[string]int{}},
+		{name: "list of value ids", option: "top", raw: []any{"pearl", "jelly"}, present: true, want: map[string]int{"pearl": 1, "jelly": 1}},
+		{name: "quantity map", option: "top", raw: map[string]any{"pearl": float64(2), "jelly": float64(0)}, present: true, want: map[string]int{"pearl": 2}},
+		{name: "fractional quantity", option: "top", raw: map[string]any{"pearl": 1.5}, present: true, wantErr: domain.ErrInvalidOptionValue},
+		{name: "negative quantity", option: "top", raw: map[string]any{"pearl": float64(-1)}, present: true, wantErr: domain.ErrInvalidOptionValue},
+		{name: "non-string list entry", option: "top", raw: []any{float64(1)}, present: true, wantErr: domain.ErrInvalidOptionValue},
+		{name: "unsupported type", option: "top", raw: true, present: true, wantErr: domain.ErrInvalidOptionValue},
+		{name: "value of another option", option: "top", raw: "l", present: true, wantErr: domain.ErrUnknownOptionValue},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := m.selectedValues(tc.option, tc.raw, tc.present)
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !equalQtys(got, tc.want) {
+				t.Fatalf("selection = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCheckSelection(t *testing.T) {
+	size := domain.ItemOption{Name: "Size", Type: domain.OptionTypeSize, Required: true}
+	addons := domain.ItemOption{Name: "Toppings", Type: domain.OptionTypeAddon, MinSelect: 1, MaxSelect: 3}
+	unlimited := domain.ItemOption{Name: "Extras", Type: domain.OptionTypeAddon}
+	tests := []struct {
+		name    string
+		opt     domain.ItemOption
+		qtys    map[string]int
+		wantErr error
+	}{
+		{name: "required option left empty", opt: size, qtys: map[string]int{}, wantErr: domain.ErrMissingRequiredOption},
+		{name: "single value", opt: size, qtys: map[string]int{"m": 1}},
+		{name: "two values of a size", opt: size, qtys: map[string]int{"m": 1, "l": 1}, wantErr: domain.ErrTooManyOptionValues},
+		{name: "quantities count towards max", opt: addons, qtys: map[string]int{"pearl": 2, "jelly": 1}},
+		{name: "quantities over max", opt: addons, qtys: map[string]int{"pearl": 2, "jelly": 2}, wantErr: domain.ErrTooManyOptionValues},
+		{name: "below min", opt: domain.ItemOption{Type: domain.OptionTypeAddon, MinSelect: 2}, qtys: map[string]int{"pearl": 1}, wantErr: domain.ErrTooFewOptionValues},
+		{name: "no max", opt: unlimited, qtys: map[string]int{"a": 5, "b": 7}},
+		{name: "optional left empty", opt: unlimited, qtys: map[string]int{}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkSelection(tc.opt, tc.qtys)
+			if tc.wantErr == nil && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tc.wantErr != nil && !errors.Is(err, tc.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestPriceLineOptions(t *testing.T) {
+	m := pricingCatalog()
+	tests := []struct {
+		name    string
+		options map[string]any
+		total   int64
+		wantErr error
+	}{
+		{name: "defaults when options omitted", total: 23000},
+		{name: "value picked twice within max_qty", options: map[string]any{"size": "l", "top": map[string]any{"pearl": float64(2)}}, total: 31000},
+		{name: "null addon selects nothing", options: map[string]any{"top": nil}, total: 20000},
+		{name: "value over max_qty", options: map[string]any{"top": map[string]any{"jelly": float64(2)}}, wantErr: domain.ErrOptionValueQtyLimit},
+		{name: "null required option", options: map[string]any{"size": nil}, wantErr: domain.ErrMissingRequiredOption},
+		{name: "unknown option", options: map[string]any{"ice": "none"}, wantErr: domain.ErrUnknownOption},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			line, err := m.priceLine(domain.OrderItemCreate{ItemID: "tea", Qty: 1, Options: tc.options})
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if line.LineTotal != tc.total {
+				t.Fatalf("line total = %d, want %d", line.LineTotal, tc.total)
+			}
+		})
+	}
+}
@@ -2,12 +2,13 @@ package repository
 
 import (
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
-	"gorm.io/datatypes"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"qrmenu/internal/domain"
 	"qrmenu/internal/platform/logging"
@@ -19,9 +20,16 @@ type OrdersPage struct {
 }
 
 type OrderRepository interface {
-	CreateGuestOrder(req domain.OrderCreateRequest) (orderID string, status domain.OrderStatus, err error)
-	ListAdmin(tenantID, status, cursor string, limit int) (OrdersPage, error)
-	UpdateStatus(tenantID, id string, status domain.OrderStatus) (*domain.Order, error)
+	CreateGuestOrder(req domain.OrderCreateRequest) (*domain.Order, error)
+	ListAdmin(tenantID, status, areaID, cursor string, limit int) (OrdersPage, error)
+	UpdateStatus(tenantID, id string, change domain.OrderStatusChange) (*domain.Order, error)
+	FindByID(tenantID, id string) (*domain.Order, error)
+	FindForGuest(id, guestSession string) (*domain.Order, error)
+	UpdateItemStatus(tenantID, orderID, itemID string, change domain.OrderItemStatusChange) (ord *domain.Order, statusChanged bool, err error)
+
+	AddItemsForGuest(id, guestSession string, items []domain.OrderItemCreate) (*domain.Order, error)
+	SetItemQtyForGuest(id, guestSession, itemID string, qty int) (*domain.Order, error)
+	CancelForGuest(id, guestSession, reason string) (*domain.Order, error)
 }
 
 type orderRepo struct{ db *gorm.DB }
@@ -30,9 +38,13 @@ func NewOrderRepository(db *gorm.DB) OrderRepository { return &orderRepo{db: db}
 
 // CreateGuestOrder creates an order from public endpoint payload.
 // - Resolves tenant by code, table by token (must belong to tenant).
-// - Creates order with WAITING & UNPAID status, then inserts items.
-func (r *orderRepo) CreateGuestOrder(req domain.OrderCreateRequest) (string, domain.OrderStatus, error) {
-	var orderID string
+// - Attaches the order to the table's open session; a session locked for billing rejects it.
+// - Prices each line server-side (base price + option deltas) and rejects
+//   unknown or missing required option selections.
+// - Creates order with WAITING & UNPAID status and computed totals, then inserts items
+//   grouped into one kitchen ticket per preparing station.
+func (r *orderRepo) CreateGuestOrder(req domain.OrderCreateRequest) (*domain.Order, error) {
+	var order domain.Order
 	logging.RepoInfo("OrderRepository.CreateGuestOrder", "create guest order", "order_create_requested", "tenant", req.Tenant, "table_token", req.TableToken, "items", len(req.Items))
 	err := r.db.Transaction(func(tx *gorm.DB) error {
 		// Resolve tenant by code
@@ -41,66 +53,73 @@ func (r *orderRepo) CreateGuestOrder(req domain.OrderCreateRequest) (string, dom
 			logging.RepoError("OrderRepository.CreateGuestOrder", "tenant lookup failed", "tenant_lookup_failed", err, "tenant_code", req.Tenant)
 			return err
 		}
-		// Resolve table by token within tenant
+		// Resolve table by token within tenant (locked so the table's session is opened once)
 		var table domain.Table
-		if err := tx.Where("token = ? AND tenant_id = ? AND is_active = TRUE", req.TableToken, tenant.ID).
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("token = ? AND tenant_id = ? AND is_active = TRUE", req.TableToken, tenant.ID).
 			First(&table).Error; err != nil {
 			logging.RepoError("OrderRepository.CreateGuestOrder", "table lookup failed", "table_lookup_failed", err, "tenant_id", tenant.ID, "table_token", req.TableToken)
 			return err
 		}
+		// Join the table's running session, opening one on the first order
+		session, err := sessionForNewOrder(tx, tenant.ID, table.ID)
+		if err != nil {
+			logging.RepoError("OrderRepository.CreateGuestOrder", "table session unavailable", "session_unavailable", err, "tenant_id", tenant.ID, "table_id", table.ID)
+			return err
+		}
+
+		// Price every line against the menu before anything is written
+		catalog, err := loadMenuCatalog(tx, tenant.ID, req.Items)
+		if err != nil {
+			logging.RepoError("OrderRepository.CreateGuestOrder", "menu lookup failed", "menu_lookup_failed", err, "tenant_id", tenant.ID)
+			return err
+		}
+		lines := make([]domain.OrderItem, 0, len(req.Items))
+		for _, it := range req.Items {
+			oi, err := catalog.priceLine(it)
+			if err != nil {
+				logging.RepoError("OrderRepository.CreateGuestOrder", "order line rejected", "order_line_invalid", err, "tenant_id", tenant.ID, "item_id", it.ItemID)
+				return err
+			}
+			lines = append(lines, oi)
+		}
 
 		// Create order
-		order := domain.Order{
+		order = domain.Order{
 			TenantID:     tenant.ID,
 			TableID:      table.ID,
+			SessionID:    &session.ID,
 			GuestSession: req.GuestSession,
 			Note:         req.Note,
 			Status:       domain.OrderWaiting,
 			PaidStatus:   domain.Unpaid,
 		}
+		applyOrderTotals(&order, lines)
 		if err := tx.Create(&order).Error; err != nil {
 			logging.RepoError("OrderRepository.CreateGuestOrder", "order insert failed", "order_insert_failed", err, "tenant_id", tenant.ID)
 			return err
 		}
-		orderID = order.ID
 
-		// Create items
-		for _, it := range req.Items {
-			var menuItem domain.Item
-			if err := tx.Where("id = ? AND tenant_id = ? AND is_active = TRUE", it.ItemID, tenant.ID).
-				First(&menuItem).Error; err != nil {
-				logging.RepoError("OrderRepository.CreateGuestOrder", "menu item lookup failed", "menu_item_lookup_failed", err, "tenant_id", tenant.ID, "item_id", it.ItemID)
-				return err
-			}
-			oi := domain.OrderItem{
-				OrderID:   order.ID,
-				ItemID:    menuItem.ID,
-				Name:      menuItem.Name,
-				Qty:       it.Qty,
-				UnitPrice: menuItem.Price,
-			}
-			if it.Options != nil {
-				oi.Options = datatypes.JSONMap(it.Options) // jsonb
-			}
-			if err := tx.Create(&oi).Error; err != nil {
-				logging.RepoError("OrderRepository.CreateGuestOrder", "order item insert failed", "order_item_insert_failed", err, "order_id", order.ID, "item_id", menuItem.ID)
-				return err
-			}
+		// Split the lines into one kitchen ticket per station and insert them
+		if err := insertOrderLines(tx, &order, lines, catalog); err != nil {
+			logging.RepoError("OrderRepository.CreateGuestOrder", "order lines insert failed", "order_item_insert_failed", err, "order_id", order.ID)
+			return err
 		}
+		order.Items = lines
 
 		return nil
 	})
 	if err != nil {
 		logging.RepoError("OrderRepository.CreateGuestOrder", "transaction failed", "transaction_failed", err, "tenant", req.Tenant)
-		return orderID, domain.OrderWaiting, err
+		return nil, err
 	}
-	logging.RepoInfo("OrderRepository.CreateGuestOrder", "order created", "order_created", "order_id", orderID, "tenant", req.Tenant)
-	return orderID, domain.OrderWaiting, nil
+	logging.RepoInfo("OrderRepository.CreateGuestOrder", "order created", "order_created", "order_id", order.ID, "tenant", req.Tenant)
+	return &order, nil
 }
 
-// ListAdmin returns paginated orders for a tenant with optional status filter.
+// ListAdmin returns paginated orders for a tenant with optional status and table area filters.
 // Cursor format: base64url("RFC3339Nano|order_id") and paging by (created_at,id) DESC.
-func (r *orderRepo) ListAdmin(tenantID, status, cursor string, limit int) (OrdersPage, error) {
+func (r *orderRepo) ListAdmin(tenantID, status, areaID, cursor string, limit int) (OrdersPage, error) {
 	if limit <= 0 || limit > 100 {
 		limit = 20
 	}
@@ -108,6 +127,9 @@ func (r *orderRepo) ListAdmin(tenantID, status, cursor string, limit int) (Order
 	if status != "" {
 		q = q.Where("status = ?", status)
 	}
+	if areaID != "" {
+		q = q.Where("table_id IN (?)", r.db.Model(&domain.Table{}).Select("id").Where("tenant_id = ? AND area_id = ?", tenantID, areaID))
+	}
 	// Apply cursor: fetch records older than the cursor (DESC ordering)
 	if cursor != "" {
 		if ts, id, ok := decodeCursor(cursor); ok {
@@ -118,7 +140,7 @@ func (r *orderRepo) ListAdmin(tenantID, status, cursor string, limit int) (Order
 	var rows []domain.Order
 	if err := q.Order("created_at DESC, id DESC").
 		Limit(limit + 1).
-		Preload("Items").
+		Preload("Items.Selections").
 		Find(&rows).Error; err != nil {
 		logging.RepoError("OrderRepository.ListAdmin", "query failed", "query_failed", err, "tenant_id", tenantID, "status", status)
 		return OrdersPage{}, err
@@ -136,25 +158,413 @@ func (r *orderRepo) ListAdmin(tenantID, status, cursor string, limit int) (Order
 	return OrdersPage{Data: rows, NextCursor: next}, nil
 }
 
-// UpdateStatus updates an order status (scoped by tenant) and returns the updated order.
-func (r *orderRepo) UpdateStatus(tenantID, id string, status domain.OrderStatus) (*domain.Order, error) {
-	if err := r.db.Model(&domain.Order{}).
-		Where("id = ? AND tenant_id = ?", id, tenantID).
-		Update("status", status).Error; err != nil {
-		logging.RepoError("OrderRepository.UpdateStatus", "update failed", "update_failed", err, "tenant_id", tenantID, "order_id", id, "status", status)
+// UpdateStatus applies a status transition (scoped by tenant) and returns the updated order.
+// The order row is locked while the transition is checked against the lifecycle graph,
+// and every applied transition is appended to order_status_history.
+// Requesting the current status is a no-op.
+func (r *orderRepo) UpdateStatus(tenantID, id string, change domain.OrderStatusChange) (*domain.Order, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var cur domain.Order
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND tenant_id = ?", id, tenantID).
+			First(&cur).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return domain.ErrOrderNotFound
+			}
+			return err
+		}
+		if err := applyStatusChange(tx, &cur, change); err != nil {
+			return err
+		}
+		return cascadeLineStatus(tx, &cur, change)
+	})
+	if err != nil {
+		logging.RepoError("OrderRepository.UpdateStatus", "update failed", "update_failed", err, "tenant_id", tenantID, "order_id", id, "status", change.To)
 		return nil, err
 	}
+	o, err := r.FindByID(tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	logging.RepoInfo("OrderRepository.UpdateStatus", "order updated", "order_updated", "tenant_id", tenantID, "order_id", id, "status", o.Status)
+	return o, nil
+}
+
+// FindByID loads an order with its items and status history (scoped by tenant).
+func (r *orderRepo) FindByID(tenantID, id string) (*domain.Order, error) {
 	var o domain.Order
 	if err := r.db.Where("id = ? AND tenant_id = ?", id, tenantID).
-		Preload("Items").
+		Preload("Items.Selections").
+		Preload("History", func(db *gorm.DB) *gorm.DB { return db.Order("created_at ASC") }).
 		First(&o).Error; err != nil {
-		logging.RepoError("OrderRepository.UpdateStatus", "load failed", "load_failed", err, "tenant_id", tenantID, "order_id", id)
+		logging.RepoError("OrderRepository.FindByID", "load failed", "load_failed", err, "tenant_id", tenantID, "order_id", id)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrOrderNotFound
+		}
 		return nil, err
 	}
-	logging.RepoInfo("OrderRepository.UpdateStatus", "order updated", "order_updated", "tenant_id", tenantID, "order_id", id, "status", o.Status)
+	logging.RepoInfo("OrderRepository.FindByID", "order found", "order_found", "tenant_id", tenantID, "order_id", id)
+	return &o, nil
+}
+
+// FindForGuest loads an order with its items for the guest session that placed it.
+// A mismatching session is reported as not found so order IDs cannot be probed.
+func (r *orderRepo) FindForGuest(id, guestSession string) (*domain.Order, error) {
+	var o domain.Order
+	if err := r.db.Where("id = ? AND guest_session_id = ?", id, guestSession).
+		Preload("Items.Selections").
+		First(&o).Error; err != nil {
+		logging.RepoError("OrderRepository.FindForGuest", "load failed", "load_failed", err, "order_id", id)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrOrderNotFound
+		}
+		return nil, err
+	}
+	logging.RepoInfo("OrderRepository.FindForGuest", "order found", "order_found", "tenant_id", o.TenantID, "order_id", id)
 	return &o, nil
 }
 
+// UpdateItemStatus moves one line of an order to a new preparation status and
+// rolls the change up onto the order status. Voiding a line takes it out of the
+// order totals and refreshes the paid status. Requesting the current status is a no-op.
+func (r *orderRepo) UpdateItemStatus(tenantID, orderID, itemID string, change domain.OrderItemStatusChange) (*domain.Order, bool, error) {
+	var changed bool
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var ord domain.Order
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND tenant_id = ?", orderID, tenantID).
+			First(&ord).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return domain.ErrOrderNotFound
+			}
+			return err
+		}
+		if ord.Status == domain.OrderDone || ord.Status == domain.OrderCanceled {
+			return domain.ErrOrderClosed
+		}
+
+		var line domain.OrderItem
+		if err := tx.Where("id = ? AND order_id = ?", itemID, ord.ID).First(&line).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return domain.ErrOrderItemNotFound
+			}
+			return err
+		}
+		if line.Status == change.To {
+			return nil
+		}
+		if !line.Status.CanTransitionTo(change.To) {
+			return fmt.Errorf("%w: %s -> %s", domain.ErrInvalidItemTransition, line.Status, change.To)
+		}
+		fields := map[string]any{"status": change.To}
+		if change.To == domain.ItemVoided {
+			fields["void_reason"] = optionalString(change.Reason)
+		}
+		if err := tx.Model(&domain.OrderItem{}).Where("id = ?", line.ID).Updates(fields).Error; err != nil {
+			return err
+		}
+
+		if change.To == domain.ItemVoided {
+			if err := refreshOrderTotals(tx, &ord); err != nil {
+				return err
+			}
+		}
+		var err error
+		changed, err = rollUpOrderStatus(tx, &ord, change.ChangedBy, "item "+line.Name+" "+string(change.To))
+		return err
+	})
+	if err != nil {
+		logging.RepoError("OrderRepository.UpdateItemStatus", "update failed", "update_failed", err, "tenant_id", tenantID, "order_id", orderID, "order_item_id", itemID, "status", change.To)
+		return nil, false, err
+	}
+	o, err := r.FindByID(tenantID, orderID)
+	if err != nil {
+		return nil, false, err
+	}
+	logging.RepoInfo("OrderRepository.UpdateItemStatus", "order item updated", "order_item_updated", "tenant_id", tenantID, "order_id", orderID, "order_item_id", itemID, "status", change.To, "order_status", o.Status)
+	return o, changed, nil
+}
+
+// AddItemsForGuest prices new lines against the menu and adds them to a guest's
+// waiting order, routing them to the order's kitchen tickets.
+func (r *orderRepo) AddItemsForGuest(id, guestSession string, items []domain.OrderItemCreate) (*domain.Order, error) {
+	return r.amendForGuest("OrderRepository.AddItemsForGuest", id, guestSession, func(tx *gorm.DB, o *domain.Order) error {
+		catalog, err := loadMenuCatalog(tx, o.TenantID, items)
+		if err != nil {
+			return err
+		}
+		lines := make([]domain.OrderItem, 0, len(items))
+		for _, it := range items {
+			oi, err := catalog.priceLine(it)
+			if err != nil {
+				return err
+			}
+			lines = append(lines, oi)
+		}
+		if err := insertOrderLines(tx, o, lines, catalog); err != nil {
+			return err
+		}
+		return refreshOrderTotals(tx, o)
+	})
+}
+
+// SetItemQtyForGuest changes the quantity of one line of a guest's waiting order.
+// A quantity of zero removes the line; the last line cannot be removed (the order
+// is canceled instead).
+func (r *orderRepo) SetItemQtyForGuest(id, guestSession, itemID string, qty int) (*domain.Order, error) {
+	if qty < 0 {
+		return nil, domain.ErrInvalidQuantity
+	}
+	return r.amendForGuest("OrderRepository.SetItemQtyForGuest", id, guestSession, func(tx *gorm.DB, o *domain.Order) error {
+		var line domain.OrderItem
+		if err := tx.Where("id = ? AND order_id = ?", itemID, o.ID).First(&line).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return domain.ErrOrderItemNotFound
+			}
+			return err
+		}
+
+		if qty > 0 {
+			if err := tx.Model(&domain.OrderItem{}).Where("id = ?", line.ID).Updates(map[string]any{
+				"qty":        qty,
+				"line_total": (line.UnitPrice + line.OptionsPrice) * int64(qty),
+			}).Error; err != nil {
+				return err
+			}
+			return refreshOrderTotals(tx, o)
+		}
+
+		var count int64
+		if err := tx.Model(&domain.OrderItem{}).Where("order_id = ? AND status <> ?", o.ID, domain.ItemVoided).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count <= 1 && line.Status != domain.ItemVoided {
+			return fmt.Errorf("%w: cancel the order to remove its last item", domain.ErrOrderNotAmendable)
+		}
+		if err := tx.Where("order_item_id = ?", line.ID).Delete(&domain.OrderItemSelection{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&domain.OrderItem{}, "id = ?", line.ID).Error; err != nil {
+			return err
+		}
+		if line.TicketID != nil {
+			// Drop the kitchen ticket if this was its only line
+			if err := tx.Where("id = ? AND NOT EXISTS (SELECT 1 FROM order_items WHERE ticket_id = ?)", *line.TicketID, *line.TicketID).
+				Delete(&domain.KitchenTicket{}).Error; err != nil {
+				return err
+			}
+		}
+		return refreshOrderTotals(tx, o)
+	})
+}
+
+// CancelForGuest cancels a guest's waiting order; the transition is recorded in
+// the order history with the guest's reason.
+func (r *orderRepo) CancelForGuest(id, guestSession, reason string) (*domain.Order, error) {
+	return r.amendForGuest("OrderRepository.CancelForGuest", id, guestSession, func(tx *gorm.DB, o *domain.Order) error {
+		msg := "canceled by guest"
+		if reason != "" {
+			msg += ": " + reason
+		}
+		return applyStatusChange(tx, o, domain.OrderStatusChange{To: domain.OrderCanceled, Reason: msg})
+	})
+}
+
+// amendForGuest applies fn to a guest's order, locked together with its table,
+// while the tenant's amendment window is open and the table is not being billed.
+// A mismatching guest session is reported as not found.
+func (r *orderRepo) amendForGuest(scope, id, guestSession string, fn func(tx *gorm.DB, o *domain.Order) error) (*domain.Order, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var o domain.Order
+		if err := tx.Where("id = ? AND guest_session_id = ?", id, guestSession).First(&o).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return domain.ErrOrderNotFound
+			}
+			return err
+		}
+		// Lock the table before the order, like order creation and session changes do
+		if err := lockTable(tx, o.TenantID, o.TableID); err != nil {
+			return err
+		}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", o.ID).First(&o).Error; err != nil {
+			return err
+		}
+
+		var tenant domain.Tenant
+		if err := tx.Where("id = ?", o.TenantID).First(&tenant).Error; err != nil {
+			return err
+		}
+		window := time.Duration(tenant.AmendWindowSeconds) * time.Second
+		if err := o.CheckGuestAmendable(window, time.Now()); err != nil {
+			return err
+		}
+		if o.SessionID != nil {
+			var s domain.TableSession
+			if err := tx.Where("id = ?", *o.SessionID).First(&s).Error; err != nil {
+				return err
+			}
+			if s.Status == domain.SessionBilling {
+				return domain.ErrTableSessionLocked
+			}
+		}
+		return fn(tx, &o)
+	})
+	if err != nil {
+		logging.RepoError(scope, "amendment failed", "order_amend_failed", err, "order_id", id)
+		return nil, err
+	}
+	o, err := r.FindForGuest(id, guestSession)
+	if err != nil {
+		return nil, err
+	}
+	logging.RepoInfo(scope, "order amended", "order_amended", "tenant_id", o.TenantID, "order_id", id, "status", o.Status, "total", o.Total)
+	return o, nil
+}
+
+// insertOrderLines attaches priced lines to an order and inserts them (with their
+// selections), grouping them into one kitchen ticket per preparing station. The
+// order's existing tickets are reused.
+func insertOrderLines(tx *gorm.DB, o *domain.Order, lines []domain.OrderItem, catalog *menuCatalog) error {
+	var existing []domain.KitchenTicket
+	if err := tx.Where("order_id = ?", o.ID).Find(&existing).Error; err != nil {
+		return err
+	}
+	tickets := map[string]string{}
+	for _, t := range existing {
+		key := ""
+		if t.StationID != nil {
+			key = *t.StationID
+		}
+		tickets[key] = t.ID
+	}
+
+	for i := range lines {
+		station := catalog.stationFor(lines[i].ItemID)
+		key := ""
+		if station != nil {
+			key = *station
+		}
+		ticketID, ok := tickets[key]
+		if !ok {
+			t := domain.KitchenTicket{
+				TenantID:  o.TenantID,
+				OrderID:   o.ID,
+				TableID:   o.TableID,
+				StationID: station,
+				Status:    domain.TicketOpen,
+			}
+			if err := tx.Create(&t).Error; err != nil {
+				return err
+			}
+			ticketID = t.ID
+			tickets[key] = ticketID
+		}
+		lines[i].TicketID = &ticketID
+		lines[i].OrderID = o.ID
+		if err := tx.Create(&lines[i]).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// refreshOrderTotals recomputes a locked order's totals from its lines and
+// updates its paid status against the new total.
+func refreshOrderTotals(tx *gorm.DB, o *domain.Order) error {
+	var lines []domain.OrderItem
+	if err := tx.Where("order_id = ?", o.ID).Find(&lines).Error; err != nil {
+		return err
+	}
+	applyOrderTotals(o, lines)
+	o.PaidStatus = domain.PaidStatusFor(o.PaidAmount, o.Total)
+	if o.PaidAmount > o.Total {
+		logging.RepoError("OrderRepository.refreshOrderTotals", "order overpaid after void, refund required", "refund_required", domain.ErrPaymentExceedsBalance, "order_id", o.ID, "paid_amount", o.PaidAmount, "total", o.Total)
+	}
+	return tx.Model(&domain.Order{}).Where("id = ?", o.ID).Updates(map[string]any{
+		"subtotal":      o.Subtotal,
+		"options_total": o.OptionsTotal,
+		"total":         o.Total,
+		"paid_status":   o.PaidStatus,
+	}).Error
+}
+
+// rollUpOrderStatus moves a locked order to the status derived from its lines,
+// walking the lifecycle one recorded transition at a time. It reports whether
+// the order status changed.
+func rollUpOrderStatus(tx *gorm.DB, o *domain.Order, changedBy, reason string) (bool, error) {
+	var lines []domain.OrderItem
+	if err := tx.Where("order_id = ?", o.ID).Find(&lines).Error; err != nil {
+		return false, err
+	}
+	path := domain.StatusPath(o.Status, domain.DeriveOrderStatus(o.Status, lines))
+	for _, next := range path {
+		if err := applyStatusChange(tx, o, domain.OrderStatusChange{To: next, ChangedBy: changedBy, Reason: reason}); err != nil {
+			return false, err
+		}
+	}
+	return len(path) > 0, nil
+}
+
+// applyStatusChange moves a locked order to change.To if the lifecycle allows it
+// and records the transition.
+func applyStatusChange(tx *gorm.DB, o *domain.Order, change domain.OrderStatusChange) error {
+	if o.Status == change.To {
+		return nil
+	}
+	if !o.Status.CanTransitionTo(change.To) {
+		return fmt.Errorf("%w: %s -> %s", domain.ErrInvalidStatusTransition, o.Status, change.To)
+	}
+	if err := tx.Model(&domain.Order{}).
+		Where("id = ?", o.ID).
+		Update("status", change.To).Error; err != nil {
+		return err
+	}
+	h := domain.OrderStatusHistory{
+		OrderID:    o.ID,
+		TenantID:   o.TenantID,
+		FromStatus: o.Status,
+		ToStatus:   change.To,
+		ChangedBy:  optionalString(change.ChangedBy),
+		Reason:     optionalString(change.Reason),
+	}
+	if err := tx.Create(&h).Error; err != nil {
+		return err
+	}
+	o.Status = change.To
+	return nil
+}
+
+// cascadeLineStatus carries a manual order status change down to the order's
+// unfinished lines and closes its open kitchen tickets, so the KDS does not keep
+// showing lines of an order staff already delivered, finished or canceled.
+// Totals are left as they were: a canceled order keeps its record.
+func cascadeLineStatus(tx *gorm.DB, o *domain.Order, change domain.OrderStatusChange) error {
+	to, from, ok := domain.CascadeLineStatus(change.To)
+	if !ok {
+		return nil
+	}
+	fields := map[string]any{"status": to}
+	if to == domain.ItemVoided {
+		fields["void_reason"] = optionalString(change.Reason)
+	}
+	if err := tx.Model(&domain.OrderItem{}).
+		Where("order_id = ? AND status IN ?", o.ID, from).
+		Updates(fields).Error; err != nil {
+		return err
+	}
+	return tx.Model(&domain.KitchenTicket{}).
+		Where("order_id = ? AND status = ?", o.ID, domain.TicketOpen).
+		Updates(map[string]any{"status": domain.TicketBumped, "bumped_at": time.Now()}).Error
+}
+
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
 // --- cursor helpers ---
 
 func encodeCursor(t time.Time, id string) string {
@@ -0,0 +1,239 @@
+package repository
+
+import (
+	"fmt"
+	"sort"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"qrmenu/internal/domain"
+	"qrmenu/internal/platform/logging"
+)
+
+// MoveOrders transfers orders of running sittings to another active table of the
+// tenant. The orders join the target table's session (opened if needed), each
+// move is written to the order history, unpaid bill splits of the affected
+// sessions are dropped, and source sessions left without orders are closed.
+func (r *tableSessionRepo) MoveOrders(tenantID string, orderIDs []string, toTableID, adminID, reason string) (*domain.TableTransfer, error) {
+	var out domain.TableTransfer
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Learn the source tables first so every table is locked before its orders.
+		var from []string
+		if err := tx.Model(&domain.Order{}).
+			Where("id IN ? AND tenant_id = ?", orderIDs, tenantID).
+			Distinct().Pluck("table_id", &from).Error; err != nil {
+			return err
+		}
+		if _, err := lockTables(tx, tenantID, append(from, toTableID)); err != nil {
+			return err
+		}
+		orders, err := lockOrders(tx, tenantID, orderIDs)
+		if err != nil {
+			return err
+		}
+		for _, o := range orders {
+			if !containsString(from, o.TableID) {
+				return fmt.Errorf("%w: order %s was moved concurrently", domain.ErrOrderNotMovable, o.ID)
+			}
+		}
+		return transferOrders(tx, tenantID, orders, toTableID, adminID, reason, &out)
+	})
+	if err != nil {
+		logging.RepoError("TableSessionRepository.MoveOrders", "move failed", "orders_move_failed", err, "tenant_id", tenantID, "to_table_id", toTableID, "orders", len(orderIDs))
+		return nil, err
+	}
+	logging.RepoInfo("TableSessionRepository.MoveOrders", "orders moved", "orders_moved", "tenant_id", tenantID, "to_table_id", toTableID, "orders", len(out.Moves))
+	return r.withTab(&out)
+}
+
+// Merge moves the open orders of fromTableID's running session (not done or
+// canceled, and not fully paid) to toTableID, so both tables are settled on one
+// tab. Finished and paid orders stay in the source session's history; the source
+// session is closed once nothing is owed on it.
+func (r *tableSessionRepo) Merge(tenantID, fromTableID, toTableID, adminID, reason string) (*domain.TableTransfer, error) {
+	var out domain.TableTransfer
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if fromTableID == toTableID {
+			return fmt.Errorf("%w: cannot merge a table into itself", domain.ErrOrderNotMovable)
+		}
+		if _, err := lockTables(tx, tenantID, []string{fromTableID, toTableID}); err != nil {
+			return err
+		}
+		src, err := activeSession(tx, fromTableID)
+		if err != nil {
+			return err
+		}
+		if src == nil {
+			return domain.ErrSessionNotFound
+		}
+		var orders []domain.Order
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("session_id = ?", src.ID).
+			Where("status NOT IN ?", []domain.OrderStatus{domain.OrderDone, domain.OrderCanceled}).
+			Where("paid_status IN ?", []domain.PaidStatus{domain.Unpaid, domain.PartiallyPaid}).
+			Order("created_at ASC, id ASC").
+			Find(&orders).Error; err != nil {
+			return err
+		}
+		if err := transferOrders(tx, tenantID, orders, toTableID, adminID, reason, &out); err != nil {
+			return err
+		}
+		// transferOrders only closes sittings left without orders; the merged table
+		// is done unless an order kept at it is still owed.
+		if err := tx.Where("id = ?", src.ID).First(src).Error; err != nil {
+			return err
+		}
+		if src.Status == domain.SessionClosed {
+			return nil
+		}
+		owed, err := sessionOutstanding(tx, src.ID)
+		if err != nil || owed > 0 {
+			return err
+		}
+		return closeSession(tx, src, adminID, false)
+	})
+	if err != nil {
+		logging.RepoError("TableSessionRepository.Merge", "merge failed", "tables_merge_failed", err, "tenant_id", tenantID, "from_table_id", fromTableID, "to_table_id", toTableID)
+		return nil, err
+	}
+	logging.RepoInfo("TableSessionRepository.Merge", "tables merged", "tables_merged", "tenant_id", tenantID, "from_table_id", fromTableID, "to_table_id", toTableID, "orders", len(out.Moves))
+	return r.withTab(&out)
+}
+
+func (r *tableSessionRepo) withTab(out *domain.TableTransfer) (*domain.TableTransfer, error) {
+	tab, err := r.tab(out.Tab.Session)
+	if err != nil {
+		return nil, err
+	}
+	out.Tab = *tab
+	return out, nil
+}
+
+// lockTables row-locks several tables of a tenant in id order, so concurrent
+// transfers between the same tables cannot deadlock.
+func lockTables(tx *gorm.DB, tenantID string, ids []string) (map[string]domain.Table, error) {
+	ids = uniqueStrings(ids)
+	sort.Strings(ids)
+	var tables []domain.Table
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id IN ? AND tenant_id = ?", ids, tenantID).
+		Order("id ASC").
+		Find(&tables).Error; err != nil {
+		return nil, err
+	}
+	byID := make(map[string]domain.Table, len(tables))
+	for _, t := range tables {
+		byID[t.ID] = t
+	}
+	for _, id := range ids {
+		if _, ok := byID[id]; !ok {
+			return nil, fmt.Errorf("%w: %s", domain.ErrTableNotFound, id)
+		}
+	}
+	return byID, nil
+}
+
+// transferOrders moves locked orders to the locked table toTableID; out.Tab.Session
+// is set to the target session.
+func transferOrders(tx *gorm.DB, tenantID string, orders []domain.Order, toTableID, adminID, reason string, out *domain.TableTransfer) error {
+	var target domain.Table
+	if err := tx.Where("id = ? AND tenant_id = ?", toTableID, tenantID).First(&target).Error; err != nil {
+		return err
+	}
+	if !target.IsActive {
+		return fmt.Errorf("%w: table %s is inactive", domain.ErrOrderNotMovable, toTableID)
+	}
+
+	// Source sessions must still be running and not in the middle of billing.
+	sources := map[string]*domain.TableSession{}
+	for _, o := range orders {
+		if o.TableID == toTableID {
+			return fmt.Errorf("%w: order %s is already at that table", domain.ErrOrderNotMovable, o.ID)
+		}
+		if o.SessionID == nil {
+			return fmt.Errorf("%w: order %s has no running session", domain.ErrOrderNotMovable, o.ID)
+		}
+		if _, ok := sources[*o.SessionID]; ok {
+			continue
+		}
+		var s domain.TableSession
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", *o.SessionID).First(&s).Error; err != nil {
+			return err
+		}
+		switch s.Status {
+		case domain.SessionClosed:
+			return fmt.Errorf("%w: order %s belongs to a closed session", domain.ErrOrderNotMovable, o.ID)
+		case domain.SessionBilling:
+			return domain.ErrTableSessionLocked
+		}
+		sources[s.ID] = &s
+	}
+
+	dest, err := sessionForNewOrder(tx, tenantID, toTableID)
+	if err != nil {
+		return err
+	}
+	out.Tab.Session = *dest
+	// Bill splits no longer match the tabs once orders change sessions.
+	if err := clearBills(tx, dest.ID); err != nil {
+		return err
+	}
+	for id := range sources {
+		if err := clearBills(tx, id); err != nil {
+			return err
+		}
+	}
+
+	for i := range orders {
+		o := &orders[i]
+		from := o.TableID
+		if err := tx.Model(&domain.Order{}).Where("id = ?", o.ID).Updates(map[string]any{
+			"table_id":   toTableID,
+			"session_id": dest.ID,
+		}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&domain.KitchenTicket{}).Where("order_id = ?", o.ID).Update("table_id", toTableID).Error; err != nil {
+			return err
+		}
+		h := domain.OrderStatusHistory{
+			OrderID:     o.ID,
+			TenantID:    o.TenantID,
+			FromStatus:  o.Status,
+			ToStatus:    o.Status,
+			FromTableID: &from,
+			ToTableID:   &toTableID,
+			ChangedBy:   optionalString(adminID),
+			Reason:      optionalString(reason),
+		}
+		if err := tx.Create(&h).Error; err != nil {
+			return err
+		}
+		o.TableID, o.SessionID = toTableID, &dest.ID
+		out.Moves = append(out.Moves, domain.OrderMove{Order: *o, FromTableID: from})
+	}
+
+	// Sittings whose every order left are over.
+	for _, s := range sources {
+		var left int64
+		if err := tx.Model(&domain.Order{}).Where("session_id = ?", s.ID).Count(&left).Error; err != nil {
+			return err
+		}
+		if left == 0 {
+			if err := closeSession(tx, s, adminID, false); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func containsString(xs []string, x string) bool {
+	for _, v := range xs {
+		if v == x {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,271 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"qrmenu/internal/domain"
+	"qrmenu/internal/platform/logging"
+)
+
+type PaymentRepository interface {
+	Record(tenantID, tableID string, orderIDs []string, req domain.PaymentRequest) (*domain.Payment, error)
+	ListByOrder(tenantID, orderID string) ([]domain.Payment, error)
+	OutstandingOrderIDsByTable(tenantID, tableID string) ([]string, error)
+
+	CreateIntent(i *domain.PaymentIntent) error
+	SettleIntent(provider, providerRef string, status domain.PaymentIntentStatus, amount int64) (*domain.PaymentIntent, error)
+}
+
+type paymentRepo struct{ db *gorm.DB }
+
+func NewPaymentRepository(db *gorm.DB) PaymentRepository { return &paymentRepo{db: db} }
+
+// Record stores a payment against one or more orders of the same table (tableID,
+// when given, must be that table). The orders are locked, the amount is allocated oldest order first, and each
+// order's paid amount and paid status are updated in the same transaction.
+func (r *paymentRepo) Record(tenantID, tableID string, orderIDs []string, req domain.PaymentRequest) (*domain.Payment, error) {
+	var pay domain.Payment
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		orders, err := lockOrders(tx, tenantID, orderIDs)
+		if err != nil {
+			return err
+		}
+		return settleOrders(tx, orders, tableID, req, &pay)
+	})
+	if err != nil {
+		logging.RepoError("PaymentRepository.Record", "payment failed", "payment_failed", err, "tenant_id", tenantID, "orders", len(orderIDs), "method", req.Method)
+		return nil, err
+	}
+	logging.RepoInfo("PaymentRepository.Record", "payment recorded", "payment_recorded", "tenant_id", tenantID, "payment_id", pay.ID, "amount", pay.Amount, "orders", len(pay.Allocations))
+	return &pay, nil
+}
+
+func (r *paymentRepo) ListByOrder(tenantID, orderID string) ([]domain.Payment, error) {
+	var xs []domain.Payment
+	err := r.db.Joins("JOIN payment_allocations pa ON pa.payment_id = payments.id AND pa.order_id = ?", orderID).
+		Where("payments.tenant_id = ?", tenantID).
+		Preload("Allocations").
+		Order("payments.created_at ASC").
+		Find(&xs).Error
+	if err != nil {
+		logging.RepoError("PaymentRepository.ListByOrder", "query failed", "query_failed", err, "tenant_id", tenantID, "order_id", orderID)
+		return nil, err
+	}
+	logging.RepoInfo("PaymentRepository.ListByOrder", "payments listed", "payments_listed", "tenant_id", tenantID, "order_id", orderID, "count", len(xs))
+	return xs, nil
+}
+
+// OutstandingOrderIDsByTable returns the table's non-canceled orders that still have a balance.
+func (r *paymentRepo) OutstandingOrderIDsByTable(tenantID, tableID string) ([]string, error) {
+	var ids []string
+	err := r.db.Model(&domain.Order{}).
+		Where("tenant_id = ? AND table_id = ? AND status <> ? AND paid_amount < total", tenantID, tableID, domain.OrderCanceled).
+		Order("created_at ASC, id ASC").
+		Pluck("id", &ids).Error
+	if err != nil {
+		logging.RepoError("PaymentRepository.OutstandingOrderIDsByTable", "query failed", "query_failed", err, "tenant_id", tenantID, "table_id", tableID)
+		return nil, err
+	}
+	logging.RepoInfo("PaymentRepository.OutstandingOrderIDsByTable", "outstanding orders listed", "outstanding_orders_listed", "tenant_id", tenantID, "table_id", tableID, "count", len(ids))
+	return ids, nil
+}
+
+func (r *paymentRepo) CreateIntent(i *domain.PaymentIntent) error {
+	if err := r.db.Create(i).Error; err != nil {
+		logging.RepoError("PaymentRepository.CreateIntent", "insert failed", "insert_failed", err, "tenant_id", i.TenantID, "order_id", i.OrderID, "provider", i.Provider)
+		return err
+	}
+	logging.RepoInfo("PaymentRepository.CreateIntent", "payment intent created", "payment_intent_created", "tenant_id", i.TenantID, "order_id", i.OrderID, "intent_id", i.ID, "provider", i.Provider)
+	return nil
+}
+
+// SettleIntent applies a provider status notification to a pending intent.
+// Notifications for intents that already reached a final status are ignored, so
+// providers may retry webhooks safely. A successful intent records a Payment for
+// its order, capped to the balance still outstanding at that moment; it is
+// rejected when the amount the provider reports differs from the intent's.
+func (r *paymentRepo) SettleIntent(provider, providerRef string, status domain.PaymentIntentStatus, amount int64) (*domain.PaymentIntent, error) {
+	var intent domain.PaymentIntent
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("provider = ? AND provider_ref = ?", provider, providerRef).
+			First(&intent).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return domain.ErrPaymentIntentNotFound
+			}
+			return err
+		}
+		if intent.Status != domain.IntentPending || status == domain.IntentPending {
+			return nil
+		}
+
+		if status == domain.IntentSucceeded {
+			if amount != intent.Amount {
+				return fmt.Errorf("%w: reported %d, expected %d", domain.ErrPaymentAmountMismatch, amount, intent.Amount)
+			}
+			orders, err := lockOrders(tx, intent.TenantID, []string{intent.OrderID})
+			if err != nil {
+				return err
+			}
+			amount := intent.Amount
+			if out := orders[0].Outstanding(); out < amount {
+				amount = out
+			}
+			if amount == 0 || orders[0].Status == domain.OrderCanceled {
+				logging.RepoError("PaymentRepository.SettleIntent", "nothing left to collect, refund required", "refund_required", domain.ErrNothingToPay, "intent_id", intent.ID, "order_id", intent.OrderID)
+			} else {
+				var pay domain.Payment
+				req := domain.PaymentRequest{Method: intent.Method, Amount: amount, Reference: provider + ":" + providerRef}
+				if err := settleOrders(tx, orders, "", req, &pay); err != nil {
+					return err
+				}
+				intent.PaymentID = &pay.ID
+			}
+		}
+
+		intent.Status = status
+		return tx.Model(&domain.PaymentIntent{}).Where("id = ?", intent.ID).Updates(map[string]any{
+			"status":     intent.Status,
+			"payment_id": intent.PaymentID,
+		}).Error
+	})
+	if err != nil {
+		logging.RepoError("PaymentRepository.SettleIntent", "settle failed", "settle_failed", err, "provider", provider, "provider_ref", providerRef, "status", status)
+		return nil, err
+	}
+	logging.RepoInfo("PaymentRepository.SettleIntent", "payment intent settled", "payment_intent_settled", "intent_id", intent.ID, "order_id", intent.OrderID, "status", intent.Status)
+	return &intent, nil
+}
+
+// lockOrders loads and row-locks the given orders of a tenant, oldest first.
+func lockOrders(tx *gorm.DB, tenantID string, orderIDs []string) ([]domain.Order, error) {
+	var orders []domain.Order
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id IN ? AND tenant_id = ?", orderIDs, tenantID).
+		Order("created_at ASC, id ASC").
+		Find(&orders).Error; err != nil {
+		return nil, err
+	}
+	if len(orders) == 0 || len(orders) != len(uniqueStrings(orderIDs)) {
+		return nil, domain.ErrOrderNotFound
+	}
+	return orders, nil
+}
+
+// settleOrders validates req against the locked orders (which must all belong to
+// tableID, or to one table when tableID is empty), inserts the payment with
+// its allocations into pay and updates the orders' paid amounts.
+func settleOrders(tx *gorm.DB, orders []domain.Order, tableID string, req domain.PaymentRequest, pay *domain.Payment) error {
+	if tableID == "" {
+		tableID = orders[0].TableID
+	}
+	var outstanding int64
+	for _, o := range orders {
+		if o.TableID != tableID {
+			return fmt.Errorf("%w: orders belong to different tables", domain.ErrOrderNotPayable)
+		}
+		if o.Status == domain.OrderCanceled {
+			return fmt.Errorf("%w: order %s is canceled", domain.ErrOrderNotPayable, o.ID)
+		}
+		outstanding += o.Outstanding()
+	}
+	if outstanding == 0 {
+		return domain.ErrNothingToPay
+	}
+
+	amount, tendered, err := tenderFor(req, outstanding)
+	if err != nil {
+		return err
+	}
+
+	*pay = domain.Payment{
+		TenantID:   orders[0].TenantID,
+		TableID:    orders[0].TableID,
+		Method:     req.Method,
+		Amount:     amount,
+		Tendered:   tendered,
+		Change:     tendered - amount,
+		Reference:  optionalString(req.Reference),
+		ReceivedBy: optionalString(req.ReceivedBy),
+	}
+	remaining := amount
+	for i := range orders {
+		if remaining == 0 {
+			break
+		}
+		part := orders[i].Outstanding()
+		if part == 0 {
+			continue
+		}
+		if part > remaining {
+			part = remaining
+		}
+		remaining -= part
+		pay.Allocations = append(pay.Allocations, domain.PaymentAllocation{OrderID: orders[i].ID, Amount: part})
+	}
+	return applyPayment(tx, orders, pay)
+}
+
+// tenderFor resolves the amount and tendered cash of req against the balance
+// that can be collected: Amount defaults to outstanding, Tendered to Amount.
+func tenderFor(req domain.PaymentRequest, outstanding int64) (amount, tendered int64, err error) {
+	amount = req.Amount
+	if amount == 0 {
+		amount = outstanding
+	}
+	if amount < 0 {
+		return 0, 0, domain.ErrInvalidPaymentAmount
+	}
+	if amount > outstanding {
+		return 0, 0, fmt.Errorf("%w: outstanding %d", domain.ErrPaymentExceedsBalance, outstanding)
+	}
+	tendered = req.Tendered
+	if tendered == 0 {
+		tendered = amount
+	}
+	if tendered < amount {
+		return 0, 0, domain.ErrInsufficientTender
+	}
+	return amount, tendered, nil
+}
+
+// applyPayment adds pay's allocations to the locked orders' paid amounts, inserts
+// the payment and closes table sessions that are now settled.
+func applyPayment(tx *gorm.DB, orders []domain.Order, pay *domain.Payment) error {
+	for _, a := range pay.Allocations {
+		for i := range orders {
+			o := &orders[i]
+			if o.ID != a.OrderID {
+				continue
+			}
+			o.PaidAmount += a.Amount
+			if err := tx.Model(&domain.Order{}).Where("id = ?", o.ID).Updates(map[string]any{
+				"paid_amount": o.PaidAmount,
+				"paid_status": domain.PaidStatusFor(o.PaidAmount, o.Total),
+			}).Error; err != nil {
+				return err
+			}
+		}
+	}
+	if err := tx.Create(pay).Error; err != nil {
+		return err
+	}
+	return closeSettledSessions(tx, orders)
+}
+
+func uniqueStrings(xs []string) []string {
+	seen := make(map[string]struct{}, len(xs))
+	out := make([]string, 0, len(xs))
+	for _, x := range xs {
+		if _, ok := seen[x]; ok {
+			continue
+		}
+		seen[x] = struct{}{}
+		out = append(out, x)
+	}
+	return out
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"qrmenu/internal/domain"
+	"qrmenu/internal/platform/logging"
+)
+
+type ServiceRequestRepository interface {
+	Create(sr *domain.ServiceRequest) error
+	List(tenantID string, statuses []domain.ServiceRequestStatus) ([]domain.ServiceRequest, error)
+	SetStatus(tenantID, id string, to domain.ServiceRequestStatus, adminID string) (*domain.ServiceRequest, error)
+}
+
+type serviceRequestRepo struct{ db *gorm.DB }
+
+func NewServiceRequestRepository(db *gorm.DB) ServiceRequestRepository {
+	return &serviceRequestRepo{db: db}
+}
+
+func (r *serviceRequestRepo) Create(sr *domain.ServiceRequest) error {
+	if err := r.db.Create(sr).Error; err != nil {
+		logging.RepoError("ServiceRequestRepository.Create", "insert failed", "insert_failed", err, "tenant_id", sr.TenantID, "table_id", sr.TableID, "type", sr.Type)
+		return err
+	}
+	logging.RepoInfo("ServiceRequestRepository.Create", "service request created", "service_request_created", "tenant_id", sr.TenantID, "table_id", sr.TableID, "request_id", sr.ID, "type", sr.Type)
+	return nil
+}
+
+// List returns the tenant's requests in the given statuses (all when empty), oldest first.
+func (r *serviceRequestRepo) List(tenantID string, statuses []domain.ServiceRequestStatus) ([]domain.ServiceRequest, error) {
+	q := r.db.Where("tenant_id = ?", tenantID)
+	if len(statuses) > 0 {
+		q = q.Where("status IN ?", statuses)
+	}
+	var xs []domain.ServiceRequest
+	if err := q.Order("created_at ASC, id ASC").Find(&xs).Error; err != nil {
+		logging.RepoError("ServiceRequestRepository.List", "query failed", "query_failed", err, "tenant_id", tenantID)
+		return nil, err
+	}
+	logging.RepoInfo("ServiceRequestRepository.List", "service requests listed", "service_requests_listed", "tenant_id", tenantID, "count", len(xs))
+	return xs, nil
+}
+
+// SetStatus acknowledges or resolves a request, stamping who did it and when.
+func (r *serviceRequestRepo) SetStatus(tenantID, id string, to domain.ServiceRequestStatus, adminID string) (*domain.ServiceRequest, error) {
+	var sr domain.ServiceRequest
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND tenant_id = ?", id, tenantID).First(&sr).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return domain.ErrServiceRequestNotFound
+			}
+			return err
+		}
+		if !sr.Status.CanTransitionTo(to) {
+			return fmt.Errorf("%w: %s -> %s", domain.ErrInvalidServiceTransition, sr.Status, to)
+		}
+		now := time.Now()
+		fields := map[string]any{"status": to}
+		switch to {
+		case domain.ServiceAcknowledged:
+			sr.AcknowledgedAt, sr.AcknowledgedBy = &now, optionalString(adminID)
+			fields["acknowledged_at"], fields["acknowledged_by"] = sr.AcknowledgedAt, sr.AcknowledgedBy
+		case domain.ServiceResolved:
+			sr.ResolvedAt, sr.ResolvedBy = &now, optionalString(adminID)
+			fields["resolved_at"], fields["resolved_by"] = sr.ResolvedAt, sr.ResolvedBy
+		}
+		sr.Status = to
+		return tx.Model(&domain.ServiceRequest{}).Where("id = ?", sr.ID).Updates(fields).Error
+	})
+	if err != nil {
+		logging.RepoError("ServiceRequestRepository.SetStatus", "update failed", "update_failed", err, "tenant_id", tenantID, "request_id", id, "status", to)
+		return nil, err
+	}
+	logging.RepoInfo("ServiceRequestRepository.SetStatus", "service request updated", "service_request_updated", "tenant_id", tenantID, "request_id", id, "status", to)
+	return &sr, nil
+}
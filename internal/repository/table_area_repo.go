@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+
+	"qrmenu/internal/domain"
+	"qrmenu/internal/platform/logging"
+)
+
+type TableAreaRepository interface {
+	List(tenantID string) ([]domain.TableArea, error)
+	Find(tenantID, id string) (*domain.TableArea, error)
+	Create(a *domain.TableArea) error
+	Patch(tenantID, id string, fields map[string]any) (*domain.TableArea, error)
+	Delete(tenantID, id string) error
+}
+
+type tableAreaRepo struct{ db *gorm.DB }
+
+func NewTableAreaRepository(db *gorm.DB) TableAreaRepository { return &tableAreaRepo{db: db} }
+
+func (r *tableAreaRepo) List(tenantID string) ([]domain.TableArea, error) {
+	var xs []domain.TableArea
+	if err := r.db.Where("tenant_id = ?", tenantID).Order("sort ASC, name ASC").Find(&xs).Error; err != nil {
+		logging.RepoError("TableAreaRepository.List", "query failed", "query_failed", err, "tenant_id", tenantID)
+		return nil, err
+	}
+	logging.RepoInfo("TableAreaRepository.List", "areas listed", "areas_listed", "tenant_id", tenantID, "count", len(xs))
+	return xs, nil
+}
+
+func (r *tableAreaRepo) Find(tenantID, id string) (*domain.TableArea, error) {
+	var a domain.TableArea
+	if err := r.db.Where("id = ? AND tenant_id = ?", id, tenantID).First(&a).Error; err != nil {
+		logging.RepoError("TableAreaRepository.Find", "query failed", "query_failed", err, "tenant_id", tenantID, "area_id", id)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrAreaNotFound
+		}
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (r *tableAreaRepo) Create(a *domain.TableArea) error {
+	if err := r.db.Create(a).Error; err != nil {
+		logging.RepoError("TableAreaRepository.Create", "insert failed", "insert_failed", err, "tenant_id", a.TenantID)
+		return err
+	}
+	logging.RepoInfo("TableAreaRepository.Create", "area created", "area_created", "tenant_id", a.TenantID, "area_id", a.ID)
+	return nil
+}
+
+func (r *tableAreaRepo) Patch(tenantID, id string, fields map[string]any) (*domain.TableArea, error) {
+	res := r.db.Model(&domain.TableArea{}).Where("id = ? AND tenant_id = ?", id, tenantID).Updates(fields)
+	if res.Error != nil {
+		logging.RepoError("TableAreaRepository.Patch", "update failed", "update_failed", res.Error, "tenant_id", tenantID, "area_id", id)
+		return nil, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return nil, domain.ErrAreaNotFound
+	}
+	logging.RepoInfo("TableAreaRepository.Patch", "area patched", "area_patched", "tenant_id", tenantID, "area_id", id)
+	return r.Find(tenantID, id)
+}
+
+// Delete removes an area; its tables stay and become unassigned.
+func (r *tableAreaRepo) Delete(tenantID, id string) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tenantArea(tx, tenantID, id); err != nil {
+			return err
+		}
+		if err := tx.Model(&domain.Table{}).Where("area_id = ?", id).Update("area_id", nil).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&domain.TableArea{}).Error
+	})
+	if err != nil {
+		logging.RepoError("TableAreaRepository.Delete", "delete failed", "delete_failed", err, "tenant_id", tenantID, "area_id", id)
+		return err
+	}
+	logging.RepoInfo("TableAreaRepository.Delete", "area deleted", "area_deleted", "tenant_id", tenantID, "area_id", id)
+	return nil
+}
+
+// tenantArea checks that the area exists and belongs to the tenant.
+func tenantArea(tx *gorm.DB, tenantID, id string) error {
+	var n int64
+	if err := tx.Model(&domain.TableArea{}).Where("id = ? AND tenant_id = ?", id, tenantID).Count(&n).Error; err != nil {
+		return err
+	}
+	if n == 0 {
+		return domain.ErrAreaNotFound
+	}
+	return nil
+}
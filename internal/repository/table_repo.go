@@ -2,6 +2,7 @@ package repository
 
 import (
 	"errors"
+	"fmt"
 
 	"qrmenu/internal/domain"
 	"qrmenu/internal/platform/logging"
@@ -11,6 +12,13 @@ import (
 
 type TableRepository interface {
 	ResolveByToken(token string) (*domain.Tenant, *domain.Table, error)
+
+	List(tenantID string) ([]domain.Table, error)
+	Find(tenantID, id string) (*domain.Table, error)
+	Create(t *domain.Table) error
+	Patch(tenantID, id string, fields map[string]any) (*domain.Table, error)
+	Delete(tenantID, id string) error
+	Place(tenantID string, placements []domain.TablePlacement) error
 }
 
 type tableRepo struct{ db *gorm.DB }
@@ -21,6 +29,9 @@ func (r *tableRepo) ResolveByToken(token string) (*domain.Tenant, *domain.Table,
 	var tb domain.Table
 	if err := r.db.Where("token = ? AND is_active = TRUE", token).First(&tb).Error; err != nil {
 		logging.RepoError("TableRepository.ResolveByToken", "table lookup failed", "table_lookup_failed", err, "token", token)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, domain.ErrTableNotFound
+		}
 		return nil, nil, err
 	}
 	var tn domain.Tenant
@@ -35,3 +46,182 @@ func (r *tableRepo) ResolveByToken(token string) (*domain.Tenant, *domain.Table,
 	logging.RepoInfo("TableRepository.ResolveByToken", "table resolved", "table_resolved", "token", token, "tenant_id", tn.ID)
 	return &tn, &tb, nil
 }
+
+func (r *tableRepo) List(tenantID string) ([]domain.Table, error) {
+	var xs []domain.Table
+	if err := r.db.Where("tenant_id = ?", tenantID).Order("code ASC, name ASC").Find(&xs).Error; err != nil {
+		logging.RepoError("TableRepository.List", "query failed", "query_failed", err, "tenant_id", tenantID)
+		return nil, err
+	}
+	logging.RepoInfo("TableRepository.List", "tables listed", "tables_listed", "tenant_id", tenantID, "count", len(xs))
+	return xs, nil
+}
+
+func (r *tableRepo) Find(tenantID, id string) (*domain.Table, error) {
+	var t domain.Table
+	if err := r.db.Where("id = ? AND tenant_id = ?", id, tenantID).First(&t).Error; err != nil {
+		logging.RepoError("TableRepository.Find", "query failed", "query_failed", err, "tenant_id", tenantID, "table_id", id)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrTableNotFound
+		}
+		return nil, err
+	}
+	return &t, nil
+}
+
+// Create inserts a table whose code is not used by another table of the tenant.
+func (r *tableRepo) Create(t *domain.Table) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tableCodeTaken(tx, t.TenantID, t.Code, ""); err != nil {
+			return err
+		}
+		active := t.IsActive
+		if err := tx.Create(t).Error; err != nil {
+			return err
+		}
+		// gorm swaps a false is_active for the column default on insert.
+		if !active {
+			t.IsActive = false
+			return tx.Model(&domain.Table{}).Where("id = ?", t.ID).Update("is_active", false).Error
+		}
+		return nil
+	})
+	if err != nil {
+		logging.RepoError("TableRepository.Create", "insert failed", "insert_failed", err, "tenant_id", t.TenantID)
+		return err
+	}
+	logging.RepoInfo("TableRepository.Create", "table created", "table_created", "tenant_id", t.TenantID, "table_id", t.ID)
+	return nil
+}
+
+// Patch updates the given columns (name, code, is_active, token, area_id, capacity) of a tenant's table.
+func (r *tableRepo) Patch(tenantID, id string, fields map[string]any) (*domain.Table, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if code, ok := fields["code"].(string); ok {
+			if err := tableCodeTaken(tx, tenantID, code, id); err != nil {
+				return err
+			}
+		}
+		res := tx.Model(&domain.Table{}).Where("id = ? AND tenant_id = ?", id, tenantID).Updates(fields)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return domain.ErrTableNotFound
+		}
+		if area, ok := fields["area_id"]; ok && area != nil {
+			return tableOnPlan(tx, tenantID, id)
+		}
+		return nil
+	})
+	if err != nil {
+		logging.RepoError("TableRepository.Patch", "update failed", "update_failed", err, "tenant_id", tenantID, "table_id", id)
+		return nil, err
+	}
+	logging.RepoInfo("TableRepository.Patch", "table patched", "table_patched", "tenant_id", tenantID, "table_id", id)
+	return r.Find(tenantID, id)
+}
+
+// Delete removes a table that never received an order; tables with order history
+// must be deactivated instead.
+func (r *tableRepo) Delete(tenantID, id string) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockTable(tx, tenantID, id); err != nil {
+			return err
+		}
+		var orders int64
+		if err := tx.Model(&domain.Order{}).Where("table_id = ?", id).Count(&orders).Error; err != nil {
+			return err
+		}
+		if orders > 0 {
+			return domain.ErrTableInUse
+		}
+		return tx.Where("id = ?", id).Delete(&domain.Table{}).Error
+	})
+	if err != nil {
+		logging.RepoError("TableRepository.Delete", "delete failed", "delete_failed", err, "tenant_id", tenantID, "table_id", id)
+		return err
+	}
+	logging.RepoInfo("TableRepository.Delete", "table deleted", "table_deleted", "tenant_id", tenantID, "table_id", id)
+	return nil
+}
+
+// Place applies floor plan placements to several tables in one transaction.
+// Every table, and every area a table is moved to, must belong to the tenant.
+func (r *tableRepo) Place(tenantID string, placements []domain.TablePlacement) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		for _, p := range placements {
+			fields, err := p.Fields()
+			if err != nil {
+				return err
+			}
+			if len(fields) == 0 {
+				continue
+			}
+			if p.AreaID != nil && !p.ClearArea {
+				if err := tenantArea(tx, tenantID, *p.AreaID); err != nil {
+					return err
+				}
+			}
+			res := tx.Model(&domain.Table{}).Where("id = ? AND tenant_id = ?", p.TableID, tenantID).Updates(fields)
+			if res.Error != nil {
+				return res.Error
+			}
+			if res.RowsAffected == 0 {
+				return fmt.Errorf("%w: %s", domain.ErrTableNotFound, p.TableID)
+			}
+			movedOnPlan := (p.AreaID != nil && !p.ClearArea) || (p.PosX != nil && !p.ClearPosition)
+			if movedOnPlan {
+				if err := tableOnPlan(tx, tenantID, p.TableID); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		logging.RepoError("TableRepository.Place", "placement failed", "placement_failed", err, "tenant_id", tenantID, "tables", len(placements))
+		return err
+	}
+	logging.RepoInfo("TableRepository.Place", "tables placed", "tables_placed", "tenant_id", tenantID, "tables", len(placements))
+	return nil
+}
+
+// tableOnPlan rejects a table whose position falls outside its area's floor plan.
+// Tables without an area or a position are not checked.
+func tableOnPlan(tx *gorm.DB, tenantID, tableID string) error {
+	var t domain.Table
+	if err := tx.Where("id = ? AND tenant_id = ?", tableID, tenantID).First(&t).Error; err != nil {
+		return err
+	}
+	if t.AreaID == nil || t.PosX == nil || t.PosY == nil {
+		return nil
+	}
+	var a domain.TableArea
+	if err := tx.Where("id = ? AND tenant_id = ?", *t.AreaID, tenantID).First(&a).Error; err != nil {
+		return err
+	}
+	if !a.Contains(*t.PosX, *t.PosY) {
+		return fmt.Errorf("%w: table %s at (%d, %d), plan is %dx%d", domain.ErrTableOffPlan, tableID, *t.PosX, *t.PosY, a.PlanWidth, a.PlanHeight)
+	}
+	return nil
+}
+
+// tableCodeTaken rejects a non-empty code already used by another table of the tenant.
+func tableCodeTaken(tx *gorm.DB, tenantID, code, exceptID string) error {
+	if code == "" {
+		return nil
+	}
+	q := tx.Model(&domain.Table{}).Where("tenant_id = ? AND code = ?", tenantID, code)
+	if exceptID != "" {
+		q = q.Where("id <> ?", exceptID)
+	}
+	var n int64
+	if err := q.Count(&n).Error; err != nil {
+		return err
+	}
+	if n > 0 {
+		return fmt.Errorf("%w: %s", domain.ErrTableCodeTaken, code)
+	}
+	return nil
+}
@@ -0,0 +1,267 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"qrmenu/internal/domain"
+	"qrmenu/internal/platform/logging"
+)
+
+type TableSessionRepository interface {
+	Current(tenantID, tableID string) (*domain.TableTab, error)
+	Tab(tenantID, sessionID string) (*domain.TableTab, error)
+	RequestBill(tenantID, tableID string) (*domain.TableSession, error)
+	Reopen(tenantID, tableID string) (*domain.TableSession, error)
+	Close(tenantID, tableID, adminID string, force bool) (*domain.TableSession, error)
+	Start(tenantID, tableID, adminID string, force bool) (*domain.TableSession, error)
+
+	MoveOrders(tenantID string, orderIDs []string, toTableID, adminID, reason string) (*domain.TableTransfer, error)
+	Merge(tenantID, fromTableID, toTableID, adminID, reason string) (*domain.TableTransfer, error)
+}
+
+type tableSessionRepo struct{ db *gorm.DB }
+
+func NewTableSessionRepository(db *gorm.DB) TableSessionRepository {
+	return &tableSessionRepo{db: db}
+}
+
+// Current returns the running tab of the table's open or billing session.
+func (r *tableSessionRepo) Current(tenantID, tableID string) (*domain.TableTab, error) {
+	var s domain.TableSession
+	if err := r.db.Where("tenant_id = ? AND table_id = ? AND status <> ?", tenantID, tableID, domain.SessionClosed).
+		First(&s).Error; err != nil {
+		logging.RepoError("TableSessionRepository.Current", "load failed", "load_failed", err, "tenant_id", tenantID, "table_id", tableID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrSessionNotFound
+		}
+		return nil, err
+	}
+	return r.tab(s)
+}
+
+// Tab returns the bill of any session of the tenant, including closed ones.
+func (r *tableSessionRepo) Tab(tenantID, sessionID string) (*domain.TableTab, error) {
+	var s domain.TableSession
+	if err := r.db.Where("id = ? AND tenant_id = ?", sessionID, tenantID).First(&s).Error; err != nil {
+		logging.RepoError("TableSessionRepository.Tab", "load failed", "load_failed", err, "tenant_id", tenantID, "session_id", sessionID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrSessionNotFound
+		}
+		return nil, err
+	}
+	return r.tab(s)
+}
+
+func (r *tableSessionRepo) tab(s domain.TableSession) (*domain.TableTab, error) {
+	var orders []domain.Order
+	if err := r.db.Where("session_id = ?", s.ID).
+		Preload("Items.Selections").
+		Order("created_at ASC, id ASC").
+		Find(&orders).Error; err != nil {
+		logging.RepoError("TableSessionRepository.Tab", "orders query failed", "query_failed", err, "session_id", s.ID)
+		return nil, err
+	}
+	tab := domain.NewTableTab(s, orders)
+	logging.RepoInfo("TableSessionRepository.Tab", "tab loaded", "tab_loaded", "tenant_id", s.TenantID, "session_id", s.ID, "orders", len(orders), "outstanding", tab.Outstanding)
+	return &tab, nil
+}
+
+// RequestBill locks the table's open session for billing; guests can no longer order.
+func (r *tableSessionRepo) RequestBill(tenantID, tableID string) (*domain.TableSession, error) {
+	return r.transition("TableSessionRepository.RequestBill", tenantID, tableID, func(tx *gorm.DB, s *domain.TableSession) error {
+		if s.Status != domain.SessionOpen {
+			return fmt.Errorf("%w: session is %s", domain.ErrInvalidSessionTransition, s.Status)
+		}
+		now := time.Now()
+		s.Status, s.BillingAt = domain.SessionBilling, &now
+		return tx.Model(&domain.TableSession{}).Where("id = ?", s.ID).Updates(map[string]any{
+			"status":     s.Status,
+			"billing_at": s.BillingAt,
+		}).Error
+	})
+}
+
+// Reopen unlocks a session in billing so guests can order again.
+func (r *tableSessionRepo) Reopen(tenantID, tableID string) (*domain.TableSession, error) {
+	return r.transition("TableSessionRepository.Reopen", tenantID, tableID, func(tx *gorm.DB, s *domain.TableSession) error {
+		if s.Status != domain.SessionBilling {
+			return fmt.Errorf("%w: session is %s", domain.ErrInvalidSessionTransition, s.Status)
+		}
+		s.Status, s.BillingAt = domain.SessionOpen, nil
+		return tx.Model(&domain.TableSession{}).Where("id = ?", s.ID).Updates(map[string]any{
+			"status":     s.Status,
+			"billing_at": nil,
+		}).Error
+	})
+}
+
+// Close ends the table's current session. A session with an outstanding balance
+// is only closed when force is set.
+func (r *tableSessionRepo) Close(tenantID, tableID, adminID string, force bool) (*domain.TableSession, error) {
+	return r.transition("TableSessionRepository.Close", tenantID, tableID, func(tx *gorm.DB, s *domain.TableSession) error {
+		return closeSession(tx, s, adminID, force)
+	})
+}
+
+// Start closes the table's current session, if any, and opens an empty one for
+// the next party.
+func (r *tableSessionRepo) Start(tenantID, tableID, adminID string, force bool) (*domain.TableSession, error) {
+	var next domain.TableSession
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockTable(tx, tenantID, tableID); err != nil {
+			return err
+		}
+		cur, err := activeSession(tx, tableID)
+		if err != nil {
+			return err
+		}
+		if cur != nil {
+			if err := closeSession(tx, cur, adminID, force); err != nil {
+				return err
+			}
+		}
+		next = domain.TableSession{TenantID: tenantID, TableID: tableID, Status: domain.SessionOpen}
+		return tx.Create(&next).Error
+	})
+	if err != nil {
+		logging.RepoError("TableSessionRepository.Start", "start failed", "session_start_failed", err, "tenant_id", tenantID, "table_id", tableID)
+		return nil, err
+	}
+	logging.RepoInfo("TableSessionRepository.Start", "session started", "session_started", "tenant_id", tenantID, "table_id", tableID, "session_id", next.ID)
+	return &next, nil
+}
+
+// transition applies fn to the table's locked current session.
+func (r *tableSessionRepo) transition(scope, tenantID, tableID string, fn func(tx *gorm.DB, s *domain.TableSession) error) (*domain.TableSession, error) {
+	var s *domain.TableSession
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockTable(tx, tenantID, tableID); err != nil {
+			return err
+		}
+		var err error
+		if s, err = activeSession(tx, tableID); err != nil {
+			return err
+		}
+		if s == nil {
+			return domain.ErrSessionNotFound
+		}
+		return fn(tx, s)
+	})
+	if err != nil {
+		logging.RepoError(scope, "session update failed", "session_update_failed", err, "tenant_id", tenantID, "table_id", tableID)
+		return nil, err
+	}
+	logging.RepoInfo(scope, "session updated", "session_updated", "tenant_id", tenantID, "table_id", tableID, "session_id", s.ID, "status", s.Status)
+	return s, nil
+}
+
+// lockTable row-locks a tenant's table; session changes of one table serialize on it.
+func lockTable(tx *gorm.DB, tenantID, tableID string) error {
+	var t domain.Table
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ? AND tenant_id = ?", tableID, tenantID).First(&t).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.ErrTableNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// activeSession returns the table's session that is not closed, or nil.
+func activeSession(tx *gorm.DB, tableID string) (*domain.TableSession, error) {
+	var s domain.TableSession
+	err := tx.Where("table_id = ? AND status <> ?", tableID, domain.SessionClosed).First(&s).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// sessionForNewOrder returns the session a new guest order joins, opening one on
+// the table's first order. The table row must be locked by the caller.
+func sessionForNewOrder(tx *gorm.DB, tenantID, tableID string) (*domain.TableSession, error) {
+	s, err := activeSession(tx, tableID)
+	if err != nil {
+		return nil, err
+	}
+	if s == nil {
+		s = &domain.TableSession{TenantID: tenantID, TableID: tableID, Status: domain.SessionOpen}
+		if err := tx.Create(s).Error; err != nil {
+			return nil, err
+		}
+		return s, nil
+	}
+	if s.Status == domain.SessionBilling {
+		return nil, domain.ErrTableSessionLocked
+	}
+	return s, nil
+}
+
+// sessionOutstanding sums the balance still owed on a session's non-canceled orders.
+func sessionOutstanding(tx *gorm.DB, sessionID string) (int64, error) {
+	var out int64
+	err := tx.Model(&domain.Order{}).
+		Select("COALESCE(SUM(GREATEST(total - paid_amount, 0)), 0)").
+		Where("session_id = ? AND status <> ?", sessionID, domain.OrderCanceled).
+		Scan(&out).Error
+	return out, err
+}
+
+func closeSession(tx *gorm.DB, s *domain.TableSession, adminID string, force bool) error {
+	out, err := sessionOutstanding(tx, s.ID)
+	if err != nil {
+		return err
+	}
+	if out > 0 && !force {
+		return fmt.Errorf("%w: outstanding %d", domain.ErrSessionHasBalance, out)
+	}
+	now := time.Now()
+	s.Status, s.ClosedAt, s.ClosedBy = domain.SessionClosed, &now, optionalString(adminID)
+	return tx.Model(&domain.TableSession{}).Where("id = ?", s.ID).Updates(map[string]any{
+		"status":    s.Status,
+		"closed_at": s.ClosedAt,
+		"closed_by": s.ClosedBy,
+	}).Error
+}
+
+// closeSettledSessions closes the sessions (open or billing) of the given orders
+// once nothing is owed on them any more, so the table's next guests start a new
+// tab instead of joining the party that just paid.
+func closeSettledSessions(tx *gorm.DB, orders []domain.Order) error {
+	seen := map[string]bool{}
+	for _, o := range orders {
+		if o.SessionID == nil || seen[*o.SessionID] {
+			continue
+		}
+		seen[*o.SessionID] = true
+
+		var s domain.TableSession
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", *o.SessionID).First(&s).Error; err != nil {
+			return err
+		}
+		if s.Status == domain.SessionClosed {
+			continue
+		}
+		out, err := sessionOutstanding(tx, s.ID)
+		if err != nil {
+			return err
+		}
+		if out == 0 {
+			if err := closeSession(tx, &s, "", false); err != nil {
+				return err
+			}
+			logging.RepoInfo("PaymentRepository.closeSettledSessions", "session settled and closed", "session_closed", "session_id", s.ID, "table_id", s.TableID)
+		}
+	}
+	return nil
+}
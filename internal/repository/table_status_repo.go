@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"qrmenu/internal/domain"
+	"qrmenu/internal/platform/logging"
+)
+
+type TableStatusRepository interface {
+	// Activities gathers the occupancy facts of the tenant's active tables, or of
+	// one table when tableID is set.
+	Activities(tenantID, tableID string) ([]domain.TableActivity, error)
+	SetOverride(o *domain.TableStatusOverride) error
+	ClearOverride(tenantID, tableID string) error
+}
+
+type tableStatusRepo struct{ db *gorm.DB }
+
+func NewTableStatusRepository(db *gorm.DB) TableStatusRepository { return &tableStatusRepo{db: db} }
+
+type sessionTotals struct {
+	SessionID   string
+	Orders      int
+	Unserved    int
+	Total       int64
+	Outstanding int64
+}
+
+type billRequest struct {
+	TableID string
+	Latest  time.Time
+}
+
+func (r *tableStatusRepo) Activities(tenantID, tableID string) ([]domain.TableActivity, error) {
+	scope := func(q *gorm.DB, col string) *gorm.DB {
+		q = q.Where("tenant_id = ?", tenantID)
+		if tableID != "" {
+			q = q.Where(col+" = ?", tableID)
+		}
+		return q
+	}
+
+	var tables []domain.Table
+	q := scope(r.db, "id")
+	if tableID == "" {
+		q = q.Where("is_active = TRUE")
+	}
+	if err := q.Order("code ASC, name ASC").Find(&tables).Error; err != nil {
+		logging.RepoError("TableStatusRepository.Activities", "tables query failed", "query_failed", err, "tenant_id", tenantID)
+		return nil, err
+	}
+	if tableID != "" && len(tables) == 0 {
+		return nil, domain.ErrTableNotFound
+	}
+
+	// Each table's active session, or else its most recent one.
+	var sessions []domain.TableSession
+	if err := scope(r.db.Table("table_sessions").
+		Select("DISTINCT ON (table_id) *"), "table_id").
+		Order("table_id, (status <> 'closed') DESC, opened_at DESC").
+		Find(&sessions).Error; err != nil {
+		logging.RepoError("TableStatusRepository.Activities", "sessions query failed", "query_failed", err, "tenant_id", tenantID)
+		return nil, err
+	}
+	byTable := make(map[string]*domain.TableSession, len(sessions))
+	var active []string
+	for i := range sessions {
+		byTable[sessions[i].TableID] = &sessions[i]
+		if sessions[i].Status != domain.SessionClosed {
+			active = append(active, sessions[i].ID)
+		}
+	}
+
+	totals := map[string]sessionTotals{}
+	if len(active) > 0 {
+		var rows []sessionTotals
+		if err := r.db.Model(&domain.Order{}).
+			Select(`session_id,
+				COUNT(*) AS orders,
+				COUNT(*) FILTER (WHERE status IN ?) AS unserved,
+				COALESCE(SUM(total), 0) AS total,
+				COALESCE(SUM(GREATEST(total - paid_amount, 0)), 0) AS outstanding`,
+				[]domain.OrderStatus{domain.OrderWaiting, domain.OrderProcessing, domain.OrderDelivering}).
+			Where("session_id IN ? AND status <> ?", active, domain.OrderCanceled).
+			Group("session_id").
+			Scan(&rows).Error; err != nil {
+			logging.RepoError("TableStatusRepository.Activities", "orders query failed", "query_failed", err, "tenant_id", tenantID)
+			return nil, err
+		}
+		for _, t := range rows {
+			totals[t.SessionID] = t
+		}
+	}
+
+	var requests []billRequest
+	if err := scope(r.db.Model(&domain.ServiceRequest{}), "table_id").
+		Select("table_id, MAX(created_at) AS latest").
+		Where("type = ? AND status IN ?", domain.ServiceRequestBill, []domain.ServiceRequestStatus{domain.ServiceOpen, domain.ServiceAcknowledged}).
+		Group("table_id").
+		Scan(&requests).Error; err != nil {
+		logging.RepoError("TableStatusRepository.Activities", "service requests query failed", "query_failed", err, "tenant_id", tenantID)
+		return nil, err
+	}
+	billAsked := make(map[string]time.Time, len(requests))
+	for _, b := range requests {
+		billAsked[b.TableID] = b.Latest
+	}
+
+	var overrides []domain.TableStatusOverride
+	if err := scope(r.db, "table_id").Find(&overrides).Error; err != nil {
+		logging.RepoError("TableStatusRepository.Activities", "overrides query failed", "query_failed", err, "tenant_id", tenantID)
+		return nil, err
+	}
+	overridden := make(map[string]*domain.TableStatusOverride, len(overrides))
+	for i := range overrides {
+		overridden[overrides[i].TableID] = &overrides[i]
+	}
+
+	out := make([]domain.TableActivity, 0, len(tables))
+	for _, t := range tables {
+		a := domain.TableActivity{Table: t, Session: byTable[t.ID], Override: overridden[t.ID]}
+		if s := a.Session; s != nil && s.Status != domain.SessionClosed {
+			tot := totals[s.ID]
+			a.Orders, a.Unserved, a.Total, a.Outstanding = tot.Orders, tot.Unserved, tot.Total, tot.Outstanding
+			// Only a request made during this sitting counts.
+			if at, ok := billAsked[t.ID]; ok && !at.Before(s.OpenedAt) {
+				a.BillRequested = true
+			}
+		}
+		out = append(out, a)
+	}
+	logging.RepoInfo("TableStatusRepository.Activities", "table activity loaded", "table_activity_loaded", "tenant_id", tenantID, "tables", len(out))
+	return out, nil
+}
+
+// SetOverride stores the staff status of a table, replacing any previous one.
+func (r *tableStatusRepo) SetOverride(o *domain.TableStatusOverride) error {
+	o.CreatedAt = time.Now()
+	if err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "table_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"status", "base_status", "session_id", "set_by", "created_at"}),
+	}).Create(o).Error; err != nil {
+		logging.RepoError("TableStatusRepository.SetOverride", "upsert failed", "upsert_failed", err, "tenant_id", o.TenantID, "table_id", o.TableID)
+		return err
+	}
+	logging.RepoInfo("TableStatusRepository.SetOverride", "table status overridden", "table_status_overridden", "tenant_id", o.TenantID, "table_id", o.TableID, "status", o.Status)
+	return nil
+}
+
+func (r *tableStatusRepo) ClearOverride(tenantID, tableID string) error {
+	if err := r.db.Where("tenant_id = ? AND table_id = ?", tenantID, tableID).Delete(&domain.TableStatusOverride{}).Error; err != nil {
+		logging.RepoError("TableStatusRepository.ClearOverride", "delete failed", "delete_failed", err, "tenant_id", tenantID, "table_id", tableID)
+		return err
+	}
+	logging.RepoInfo("TableStatusRepository.ClearOverride", "table status override cleared", "table_status_override_cleared", "tenant_id", tenantID, "table_id", tableID)
+	return nil
+}
@@ -9,7 +9,9 @@ import (
 
 type TenantRepository interface {
 	FindByCode(code string) (*domain.Tenant, error)
+	FindByID(id string) (*domain.Tenant, error)
 	Create(t *domain.Tenant) error
+	UpdateSettings(id string, fields map[string]any) (*domain.Tenant, error)
 }
 
 type tenantRepo struct{ db *gorm.DB }
@@ -26,6 +28,16 @@ func (r *tenantRepo) FindByCode(code string) (*domain.Tenant, error) {
 	return &t, nil
 }
 
+func (r *tenantRepo) FindByID(id string) (*domain.Tenant, error) {
+	var t domain.Tenant
+	if err := r.db.Where("id = ?", id).First(&t).Error; err != nil {
+		logging.RepoError("TenantRepository.FindByID", "query failed", "query_failed", err, "tenant_id", id)
+		return nil, err
+	}
+	logging.RepoInfo("TenantRepository.FindByID", "tenant found", "tenant_found", "tenant_id", id, "tenant_code", t.Code)
+	return &t, nil
+}
+
 func (r *tenantRepo) Create(t *domain.Tenant) error {
 	if err := r.db.Create(t).Error; err != nil {
 		logging.RepoError("TenantRepository.Create", "insert failed", "insert_failed", err, "tenant_code", t.Code)
@@ -34,3 +46,13 @@ func (r *tenantRepo) Create(t *domain.Tenant) error {
 	logging.RepoInfo("TenantRepository.Create", "tenant created", "tenant_created", "tenant_code", t.Code, "tenant_id", t.ID)
 	return nil
 }
+
+// UpdateSettings writes the given tenant setting columns and returns the updated tenant.
+func (r *tenantRepo) UpdateSettings(id string, fields map[string]any) (*domain.Tenant, error) {
+	if err := r.db.Model(&domain.Tenant{}).Where("id = ?", id).Updates(fields).Error; err != nil {
+		logging.RepoError("TenantRepository.UpdateSettings", "update failed", "update_failed", err, "tenant_id", id)
+		return nil, err
+	}
+	logging.RepoInfo("TenantRepository.UpdateSettings", "tenant settings updated", "tenant_settings_updated", "tenant_id", id, "fields", len(fields))
+	return r.FindByID(id)
+}
@@ -15,6 +15,17 @@ type Deps struct {
 	OrderPub  *handler.OrderPublicHandler
 	AdminMenu *handler.AdminMenuHandler
 	AdminOrd  *handler.AdminOrdersHandler
+	AdminPay  *handler.AdminPaymentsHandler
+	PayPub    *handler.PaymentPublicHandler
+	Kitchen   *handler.AdminKitchenHandler
+	Sessions  *handler.AdminTableSessionsHandler
+	Bills     *handler.AdminBillsHandler
+	Settings  *handler.AdminSettingsHandler
+	SvcPub    *handler.ServiceRequestPublicHandler
+	AdminSvc  *handler.AdminServiceRequestsHandler
+	Tables    *handler.AdminTablesHandler
+	FloorPlan *handler.AdminFloorPlanHandler
+	TableStat *handler.AdminTableStatusHandler
 	Setup     *handler.SetupHandler
 	JWTSecret string
 }
@@ -30,8 +41,16 @@ func Register(app *fiber.App, d Deps) {
 
 	// ---- Public / Customer ----
 	app.Get("/api/v1/table/:token", d.Table.Resolve)
+	app.Post("/api/v1/table/:token/requests", d.SvcPub.Create)
 	app.Get("/api/v1/menu", d.Menu.Get)
 	app.Post("/api/v1/orders", d.OrderPub.Create)
+	app.Get("/api/v1/orders/:id", d.OrderPub.Get)
+	app.Get("/api/v1/orders/:id/stream", d.OrderPub.Stream)
+	app.Post("/api/v1/orders/:id/items", d.OrderPub.AddItems)
+	app.Patch("/api/v1/orders/:id/items/:item_id", d.OrderPub.SetItemQty)
+	app.Post("/api/v1/orders/:id/cancel", d.OrderPub.Cancel)
+	app.Post("/api/v1/orders/:id/pay", d.PayPub.Start)
+	app.Post("/api/v1/payments/webhook/:provider", d.PayPub.Webhook)
 
 	// ---- Auth (cookie) ----
 	app.Post("/auth/login", d.Auth.Login)
@@ -40,9 +59,21 @@ func Register(app *fiber.App, d Deps) {
 	// ---- Admin (cookie protected) ----
 	admin := app.Group("/admin", middleware.AdminCookieOnly(d.JWTSecret))
 
+	// Tenant settings
+	admin.Get("/settings", d.Settings.Get)
+	admin.Patch("/settings", d.Settings.Patch)
+
 	// Orders
 	admin.Get("/orders", d.AdminOrd.List)
+	admin.Get("/orders/stream", d.AdminOrd.Stream)
+	admin.Get("/orders/:id", d.AdminOrd.Get)
 	admin.Patch("/orders/:id/status", d.AdminOrd.PatchStatus)
+	admin.Patch("/orders/:id/items/:item_id/status", d.AdminOrd.PatchItemStatus)
+
+	// Payments
+	admin.Get("/orders/:id/payments", d.AdminPay.ListOrderPayments)
+	admin.Post("/orders/:id/payments", d.AdminPay.PayOrder)
+	admin.Post("/tables/:id/payments", d.AdminPay.PayTable)
 
 	// Categories
 	admin.Get("/categories", d.AdminMenu.ListCategories)
@@ -62,9 +93,69 @@ func Register(app *fiber.App, d Deps) {
 	// Options
 	admin.Get("/items/:id/options", d.AdminMenu.ListItemOptions)
 	admin.Post("/items/:id/options", d.AdminMenu.CreateItemOption)
+	admin.Get("/options", d.AdminMenu.ListOptions)
+	admin.Post("/options", d.AdminMenu.CreateOption)
+	admin.Patch("/options/:option_id", d.AdminMenu.PatchItemOption)
+	admin.Delete("/options/:option_id", d.AdminMenu.DeleteItemOption)
 	admin.Get("/options/:option_id/values", d.AdminMenu.ListOptionValues)
 	admin.Post("/options/:option_id/values", d.AdminMenu.CreateOptionValue)
+	admin.Patch("/options/:option_id/values/:value_id", d.AdminMenu.PatchOptionValue)
+	admin.Delete("/options/:option_id/values/:value_id", d.AdminMenu.DeleteOptionValue)
+	admin.Get("/options/:option_id/attachments", d.AdminMenu.ListAttachments)
+	admin.Put("/options/:option_id/attachments", d.AdminMenu.Attach)
+	admin.Delete("/options/:option_id/attachments/:attachment_id", d.AdminMenu.Detach)
+
+	// Kitchen stations & display
+	admin.Get("/stations", d.Kitchen.ListStations)
+	admin.Post("/stations", d.Kitchen.CreateStation)
+	admin.Patch("/stations/:id", d.Kitchen.PatchStation)
+	admin.Delete("/stations/:id", d.Kitchen.DeleteStation)
+	admin.Get("/kds/tickets", d.Kitchen.ListTickets)
+	admin.Post("/kds/tickets/:id/bump", d.Kitchen.Bump)
+	admin.Post("/kds/tickets/:id/recall", d.Kitchen.Recall)
 
 	// Tables
-	admin.Post("/tables/:id/qr", d.AdminMenu.GenerateQR)
+	admin.Get("/tables", d.Tables.List)
+	admin.Post("/tables", d.Tables.Create)
+	admin.Get("/tables/qr-sheet", d.Tables.QRSheet)
+	admin.Get("/tables/status", d.TableStat.List)
+	admin.Get("/tables/:id", d.Tables.Get)
+	admin.Patch("/tables/:id", d.Tables.Patch)
+	admin.Delete("/tables/:id", d.Tables.Delete)
+	admin.Post("/tables/:id/rotate-token", d.Tables.RotateToken)
+	admin.Get("/tables/:id/status", d.TableStat.Get)
+	admin.Put("/tables/:id/status", d.TableStat.Override)
+	admin.Delete("/tables/:id/status", d.TableStat.ClearOverride)
+
+	// Areas & floor plan
+	admin.Get("/areas", d.FloorPlan.ListAreas)
+	admin.Post("/areas", d.FloorPlan.CreateArea)
+	admin.Patch("/areas/:id", d.FloorPlan.PatchArea)
+	admin.Delete("/areas/:id", d.FloorPlan.DeleteArea)
+	admin.Get("/floor-plan", d.FloorPlan.Plan)
+	admin.Put("/floor-plan", d.FloorPlan.Place)
+	admin.Get("/tables/:id/qr", d.Tables.QR)
+	admin.Post("/tables/:id/qr", d.Tables.GenerateQR)
+
+	// Table sessions (tabs)
+	admin.Get("/tables/:id/session", d.Sessions.Current)
+	admin.Post("/tables/:id/session/bill", d.Sessions.RequestBill)
+	admin.Post("/tables/:id/session/reopen", d.Sessions.Reopen)
+	admin.Post("/tables/:id/session/close", d.Sessions.Close)
+	admin.Post("/tables/:id/sessions", d.Sessions.Start)
+	admin.Post("/tables/:id/orders", d.Sessions.MoveOrders)
+	admin.Post("/tables/:id/merge", d.Sessions.Merge)
+	admin.Get("/sessions/:id", d.Sessions.Get)
+
+	// Service requests
+	admin.Get("/service-requests", d.AdminSvc.List)
+	admin.Post("/service-requests/:id/ack", d.AdminSvc.Acknowledge)
+	admin.Post("/service-requests/:id/resolve", d.AdminSvc.Resolve)
+
+	// Split bills
+	admin.Get("/tables/:id/bills", d.Bills.List)
+	admin.Post("/tables/:id/bills", d.Bills.Split)
+	admin.Delete("/tables/:id/bills", d.Bills.Clear)
+	admin.Get("/bills/:id", d.Bills.Get)
+	admin.Post("/bills/:id/payments", d.Bills.Pay)
 }
@@ -1,19 +1,78 @@
 package usecase
 
 import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
 	"qrmenu/internal/domain"
 	"qrmenu/internal/platform/logging"
 	"qrmenu/internal/repository"
 )
 
+// MenuChangeNotifier is told whenever an admin mutation changes what guests see
+// on a tenant's menu (implemented by MenuUC to drop the cached payload).
+type MenuChangeNotifier interface {
+	MenuChanged(tenantID string)
+}
+
 type AdminMenuUC struct {
 	catRepo  repository.CategoryRepository
 	itemRepo repository.ItemRepository
 	optRepo  repository.OptionRepository
+	kitchen  repository.KitchenRepository
+	notifier MenuChangeNotifier
+}
+
+func NewAdminMenuUC(cat repository.CategoryRepository, it repository.ItemRepository, op repository.OptionRepository, k repository.KitchenRepository, n MenuChangeNotifier) *AdminMenuUC {
+	return &AdminMenuUC{catRepo: cat, itemRepo: it, optRepo: op, kitchen: k, notifier: n}
 }
 
-func NewAdminMenuUC(cat repository.CategoryRepository, it repository.ItemRepository, op repository.OptionRepository) *AdminMenuUC {
-	return &AdminMenuUC{catRepo: cat, itemRepo: it, optRepo: op}
+// menuChanged publishes a menu-change event for the tenant after a successful write.
+func (u *AdminMenuUC) menuChanged(tenantID string) {
+	if u.notifier != nil {
+		u.notifier.MenuChanged(tenantID)
+	}
+}
+
+// stationFromBody reads an optional "station_id" from a request body and checks
+// that the station belongs to the tenant. A null or empty value clears the station.
+func (u *AdminMenuUC) stationFromBody(tenantID string, body map[string]any) (station *string, present bool, err error) {
+	raw, present := body["station_id"]
+	if !present || raw == nil {
+		return nil, present, nil
+	}
+	id, ok := raw.(string)
+	if !ok {
+		return nil, true, fmt.Errorf("station_id must be a string")
+	}
+	if id == "" {
+		return nil, true, nil
+	}
+	if _, err := u.kitchen.FindStation(tenantID, id); err != nil {
+		return nil, true, err
+	}
+	return &id, true, nil
+}
+
+// availabilityFromBody reads an optional "availability" schedule from a request
+// body and validates it. A null value clears the schedule (always available).
+func availabilityFromBody(body map[string]any) (sched domain.Availability, present bool, err error) {
+	raw, present := body["availability"]
+	if !present || raw == nil {
+		return domain.Availability{}, present, nil
+	}
+	b, err := json.Marshal(raw)
+	if err != nil {
+		return nil, true, fmt.Errorf("availability must be a list of windows")
+	}
+	if err := json.Unmarshal(b, &sched); err != nil {
+		return nil, true, fmt.Errorf("availability must be a list of windows")
+	}
+	if err := sched.Validate(); err != nil {
+		return nil, true, err
+	}
+	return sched, true, nil
 }
 
 // ===== Categories
@@ -29,43 +88,102 @@ func (u *AdminMenuUC) ListCategories(tenantID string) ([]domain.Category, error)
 }
 func (u *AdminMenuUC) CreateCategory(tenantID string, body map[string]any) (*domain.Category, error) {
 	logging.UsecaseInfo("AdminMenu.CreateCategory", "creating category", "category_create_requested", "tenant_id", tenantID)
-	c := &domain.Category{TenantID: tenantID, Name: body["name"].(string)}
+	name, err := requiredString(body, "name")
+	if err != nil {
+		logging.UsecaseError("AdminMenu.CreateCategory", "invalid body", "category_invalid", err, "tenant_id", tenantID)
+		return nil, err
+	}
+	c := &domain.Category{TenantID: tenantID, Name: name}
 	if v, ok := body["sort"].(float64); ok {
 		c.Sort = int(v)
 	}
 	if v, ok := body["is_active"].(bool); ok {
 		c.IsActive = v
 	}
+	station, _, err := u.stationFromBody(tenantID, body)
+	if err != nil {
+		logging.UsecaseError("AdminMenu.CreateCategory", "invalid station", "station_invalid", err, "tenant_id", tenantID)
+		return nil, err
+	}
+	c.StationID = station
+	sched, _, err := availabilityFromBody(body)
+	if err != nil {
+		logging.UsecaseError("AdminMenu.CreateCategory", "invalid availability", "availability_invalid", err, "tenant_id", tenantID)
+		return nil, err
+	}
+	c.Availability = sched
 	if err := u.catRepo.Create(c); err != nil {
 		logging.UsecaseError("AdminMenu.CreateCategory", "repository error", "category_create_failed", err, "tenant_id", tenantID)
 		return nil, err
 	}
+	u.menuChanged(tenantID)
 	logging.UsecaseInfo("AdminMenu.CreateCategory", "category created", "category_created", "tenant_id", tenantID, "category_id", c.ID)
 	return c, nil
 }
 func (u *AdminMenuUC) ReplaceCategory(tenantID, id string, body map[string]any) (*domain.Category, error) {
 	logging.UsecaseInfo("AdminMenu.ReplaceCategory", "replacing category", "category_replace_requested", "tenant_id", tenantID, "category_id", id)
-	c := &domain.Category{ID: id, TenantID: tenantID, Name: body["name"].(string)}
+	name, err := requiredString(body, "name")
+	if err != nil {
+		logging.UsecaseError("AdminMenu.ReplaceCategory", "invalid body", "category_invalid", err, "tenant_id", tenantID, "category_id", id)
+		return nil, err
+	}
+	cur, err := u.catRepo.FindByID(tenantID, id)
+	if err != nil {
+		logging.UsecaseError("AdminMenu.ReplaceCategory", "repository error", "category_replace_failed", err, "tenant_id", tenantID, "category_id", id)
+		return nil, err
+	}
+	// is_active is kept when omitted, so a replace never re-shows a hidden category.
+	c := &domain.Category{ID: id, TenantID: tenantID, Name: name, IsActive: cur.IsActive}
 	if v, ok := body["sort"].(float64); ok {
 		c.Sort = int(v)
 	}
 	if v, ok := body["is_active"].(bool); ok {
 		c.IsActive = v
 	}
+	station, _, err := u.stationFromBody(tenantID, body)
+	if err != nil {
+		logging.UsecaseError("AdminMenu.ReplaceCategory", "invalid station", "station_invalid", err, "tenant_id", tenantID, "category_id", id)
+		return nil, err
+	}
+	c.StationID = station
+	sched, _, err := availabilityFromBody(body)
+	if err != nil {
+		logging.UsecaseError("AdminMenu.ReplaceCategory", "invalid availability", "availability_invalid", err, "tenant_id", tenantID, "category_id", id)
+		return nil, err
+	}
+	c.Availability = sched
 	if err := u.catRepo.Replace(c); err != nil {
 		logging.UsecaseError("AdminMenu.ReplaceCategory", "repository error", "category_replace_failed", err, "tenant_id", tenantID, "category_id", id)
 		return nil, err
 	}
+	u.menuChanged(tenantID)
 	logging.UsecaseInfo("AdminMenu.ReplaceCategory", "category updated", "category_replaced", "tenant_id", tenantID, "category_id", id)
 	return c, nil
 }
 func (u *AdminMenuUC) PatchCategory(tenantID, id string, body map[string]any) (*domain.Category, error) {
 	logging.UsecaseInfo("AdminMenu.PatchCategory", "patching category", "category_patch_requested", "tenant_id", tenantID, "category_id", id)
+	station, present, err := u.stationFromBody(tenantID, body)
+	if err != nil {
+		logging.UsecaseError("AdminMenu.PatchCategory", "invalid station", "station_invalid", err, "tenant_id", tenantID, "category_id", id)
+		return nil, err
+	}
+	if present {
+		body["station_id"] = station
+	}
+	sched, present, err := availabilityFromBody(body)
+	if err != nil {
+		logging.UsecaseError("AdminMenu.PatchCategory", "invalid availability", "availability_invalid", err, "tenant_id", tenantID, "category_id", id)
+		return nil, err
+	}
+	if present {
+		body["availability"] = sched
+	}
 	obj, err := u.catRepo.Patch(tenantID, id, body)
 	if err != nil {
 		logging.UsecaseError("AdminMenu.PatchCategory", "repository error", "category_patch_failed", err, "tenant_id", tenantID, "category_id", id)
 		return nil, err
 	}
+	u.menuChanged(tenantID)
 	logging.UsecaseInfo("AdminMenu.PatchCategory", "category patched", "category_patched", "tenant_id", tenantID, "category_id", id)
 	return obj, nil
 }
@@ -75,6 +193,7 @@ func (u *AdminMenuUC) DeleteCategory(tenantID, id string) error {
 		logging.UsecaseError("AdminMenu.DeleteCategory", "repository error", "category_delete_failed", err, "tenant_id", tenantID, "category_id", id)
 		return err
 	}
+	u.menuChanged(tenantID)
 	logging.UsecaseInfo("AdminMenu.DeleteCategory", "category deleted", "category_deleted", "tenant_id", tenantID, "category_id", id)
 	return nil
 }
@@ -111,52 +230,107 @@ func (u *AdminMenuUC) CreateItem(tenantID string, body map[string]any) (*domain.
 	if v, ok := body["is_active"].(bool); ok {
 		i.IsActive = v
 	}
+	station, _, err := u.stationFromBody(tenantID, body)
+	if err != nil {
+		logging.UsecaseError("AdminMenu.CreateItem", "invalid station", "station_invalid", err, "tenant_id", tenantID)
+		return nil, err
+	}
+	i.StationID = station
+	sched, _, err := availabilityFromBody(body)
+	if err != nil {
+		logging.UsecaseError("AdminMenu.CreateItem", "invalid availability", "availability_invalid", err, "tenant_id", tenantID)
+		return nil, err
+	}
+	i.Availability = sched
 	if err := u.itemRepo.Create(i); err != nil {
 		logging.UsecaseError("AdminMenu.CreateItem", "repository error", "item_create_failed", err, "tenant_id", tenantID)
 		return nil, err
 	}
+	u.menuChanged(tenantID)
 	logging.UsecaseInfo("AdminMenu.CreateItem", "item created", "item_created", "tenant_id", tenantID, "item_id", i.ID)
 	return i, nil
 }
 func (u *AdminMenuUC) ReplaceItem(tenantID, id string, body map[string]any) (*domain.Item, error) {
 	logging.UsecaseInfo("AdminMenu.ReplaceItem", "replacing item", "item_replace_requested", "tenant_id", tenantID, "item_id", id)
-	i := &domain.Item{ID: id, TenantID: tenantID}
-	if v, ok := body["category_id"].(string); ok {
-		i.CategoryID = v
+	name, err := requiredString(body, "name")
+	if err != nil {
+		logging.UsecaseError("AdminMenu.ReplaceItem", "invalid body", "item_invalid", err, "tenant_id", tenantID, "item_id", id)
+		return nil, err
 	}
-	if v, ok := body["name"].(string); ok {
-		i.Name = v
+	categoryID, err := requiredString(body, "category_id")
+	if err != nil {
+		logging.UsecaseError("AdminMenu.ReplaceItem", "invalid body", "item_invalid", err, "tenant_id", tenantID, "item_id", id)
+		return nil, err
+	}
+	price, err := wholeNumber(body, "price")
+	if err == nil && price < 0 {
+		err = fmt.Errorf("price must not be negative")
+	}
+	if err != nil {
+		logging.UsecaseError("AdminMenu.ReplaceItem", "invalid body", "item_invalid", err, "tenant_id", tenantID, "item_id", id)
+		return nil, err
 	}
+	cur, err := u.itemRepo.FindByID(tenantID, id)
+	if err != nil {
+		logging.UsecaseError("AdminMenu.ReplaceItem", "repository error", "item_replace_failed", err, "tenant_id", tenantID, "item_id", id)
+		return nil, err
+	}
+	// is_active is kept when omitted, so a replace never brings back an item
+	// marked out of stock; flags are not part of the replace.
+	i := &domain.Item{ID: id, TenantID: tenantID, CategoryID: categoryID, Name: name, Price: price, IsActive: cur.IsActive, Flags: cur.Flags}
 	if v, ok := body["description"].(string); ok {
 		i.Description = &v
-	} else {
-		i.Description = nil
-	}
-	if v, ok := body["price"].(float64); ok {
-		i.Price = int64(v)
 	}
 	if v, ok := body["photo_url"].(string); ok {
 		i.PhotoURL = &v
-	} else {
-		i.PhotoURL = nil
 	}
 	if v, ok := body["is_active"].(bool); ok {
 		i.IsActive = v
 	}
+	station, _, err := u.stationFromBody(tenantID, body)
+	if err != nil {
+		logging.UsecaseError("AdminMenu.ReplaceItem", "invalid station", "station_invalid", err, "tenant_id", tenantID, "item_id", id)
+		return nil, err
+	}
+	i.StationID = station
+	sched, _, err := availabilityFromBody(body)
+	if err != nil {
+		logging.UsecaseError("AdminMenu.ReplaceItem", "invalid availability", "availability_invalid", err, "tenant_id", tenantID, "item_id", id)
+		return nil, err
+	}
+	i.Availability = sched
 	if err := u.itemRepo.Replace(i); err != nil {
 		logging.UsecaseError("AdminMenu.ReplaceItem", "repository error", "item_replace_failed", err, "tenant_id", tenantID, "item_id", id)
 		return nil, err
 	}
+	u.menuChanged(tenantID)
 	logging.UsecaseInfo("AdminMenu.ReplaceItem", "item updated", "item_replaced", "tenant_id", tenantID, "item_id", id)
 	return i, nil
 }
 func (u *AdminMenuUC) PatchItem(tenantID, id string, body map[string]any) (*domain.Item, error) {
 	logging.UsecaseInfo("AdminMenu.PatchItem", "patching item", "item_patch_requested", "tenant_id", tenantID, "item_id", id)
+	station, present, err := u.stationFromBody(tenantID, body)
+	if err != nil {
+		logging.UsecaseError("AdminMenu.PatchItem", "invalid station", "station_invalid", err, "tenant_id", tenantID, "item_id", id)
+		return nil, err
+	}
+	if present {
+		body["station_id"] = station
+	}
+	sched, present, err := availabilityFromBody(body)
+	if err != nil {
+		logging.UsecaseError("AdminMenu.PatchItem", "invalid availability", "availability_invalid", err, "tenant_id", tenantID, "item_id", id)
+		return nil, err
+	}
+	if present {
+		body["availability"] = sched
+	}
 	obj, err := u.itemRepo.Patch(tenantID, id, body)
 	if err != nil {
 		logging.UsecaseError("AdminMenu.PatchItem", "repository error", "item_patch_failed", err, "tenant_id", tenantID, "item_id", id)
 		return nil, err
 	}
+	u.menuChanged(tenantID)
 	logging.UsecaseInfo("AdminMenu.PatchItem", "item patched", "item_patched", "tenant_id", tenantID, "item_id", id)
 	return obj, nil
 }
@@ -166,6 +340,7 @@ func (u *AdminMenuUC) DeleteItem(tenantID, id string) error {
 		logging.UsecaseError("AdminMenu.DeleteItem", "repository error", "item_delete_failed", err, "tenant_id", tenantID, "item_id", id)
 		return err
 	}
+	u.menuChanged(tenantID)
 	logging.UsecaseInfo("AdminMenu.DeleteItem", "item deleted", "item_deleted", "tenant_id", tenantID, "item_id", id)
 	return nil
 }
@@ -176,11 +351,42 @@ func (u *AdminMenuUC) ToggleOOS(tenantID, id string, isActive bool) (*domain.Ite
 		logging.UsecaseError("AdminMenu.ToggleOOS", "repository error", "item_toggle_failed", err, "tenant_id", tenantID, "item_id", id, "is_active", isActive)
 		return nil, err
 	}
+	u.menuChanged(tenantID)
 	logging.UsecaseInfo("AdminMenu.ToggleOOS", "item toggled", "item_toggled", "tenant_id", tenantID, "item_id", id, "is_active", isActive)
 	return obj, nil
 }
 
-// ===== Options
+// ===== Options (tenant-wide modifier groups)
+func (u *AdminMenuUC) ListOptions(tenantID string) ([]domain.ItemOption, error) {
+	logging.UsecaseInfo("AdminMenu.ListOptions", "listing options", "options_list_requested", "tenant_id", tenantID)
+	xs, err := u.optRepo.ListOptions(tenantID)
+	if err != nil {
+		logging.UsecaseError("AdminMenu.ListOptions", "repository error", "options_list_failed", err, "tenant_id", tenantID)
+		return nil, err
+	}
+	logging.UsecaseInfo("AdminMenu.ListOptions", "options loaded", "options_listed", "tenant_id", tenantID, "count", len(xs))
+	return xs, nil
+}
+func (u *AdminMenuUC) CreateOption(tenantID string, body map[string]any) (*domain.ItemOption, error) {
+	logging.UsecaseInfo("AdminMenu.CreateOption", "creating option", "option_create_requested", "tenant_id", tenantID)
+	fields, err := optionFields(body, true)
+	if err != nil {
+		logging.UsecaseError("AdminMenu.CreateOption", "invalid body", "option_invalid", err, "tenant_id", tenantID)
+		return nil, err
+	}
+	o := &domain.ItemOption{TenantID: tenantID}
+	applyOptionFields(o, fields)
+	if err := o.CheckLimits(); err != nil {
+		logging.UsecaseError("AdminMenu.CreateOption", "invalid limits", "option_invalid", err, "tenant_id", tenantID)
+		return nil, err
+	}
+	if err := u.optRepo.CreateOption(o); err != nil {
+		logging.UsecaseError("AdminMenu.CreateOption", "repository error", "option_create_failed", err, "tenant_id", tenantID)
+		return nil, err
+	}
+	logging.UsecaseInfo("AdminMenu.CreateOption", "option created", "option_created", "tenant_id", tenantID, "option_id", o.ID)
+	return o, nil
+}
 func (u *AdminMenuUC) ListItemOptions(itemID, tenantID string) ([]domain.ItemOption, error) {
 	logging.UsecaseInfo("AdminMenu.ListItemOptions", "listing options", "options_list_requested", "tenant_id", tenantID, "item_id", itemID)
 	xs, err := u.optRepo.ListItemOptions(itemID, tenantID)
@@ -193,17 +399,75 @@ func (u *AdminMenuUC) ListItemOptions(itemID, tenantID string) ([]domain.ItemOpt
 }
 func (u *AdminMenuUC) CreateItemOption(itemID, tenantID string, body map[string]any) (*domain.ItemOption, error) {
 	logging.UsecaseInfo("AdminMenu.CreateItemOption", "creating option", "option_create_requested", "tenant_id", tenantID, "item_id", itemID)
-	o := &domain.ItemOption{Name: body["name"].(string), Type: body["type"].(string)}
-	if v, ok := body["required"].(bool); ok {
-		o.Required = v
+	fields, err := optionFields(body, true)
+	if err != nil {
+		logging.UsecaseError("AdminMenu.CreateItemOption", "invalid body", "option_invalid", err, "tenant_id", tenantID, "item_id", itemID)
+		return nil, err
+	}
+	o := &domain.ItemOption{}
+	applyOptionFields(o, fields)
+	if err := o.CheckLimits(); err != nil {
+		logging.UsecaseError("AdminMenu.CreateItemOption", "invalid limits", "option_invalid", err, "tenant_id", tenantID, "item_id", itemID)
+		return nil, err
 	}
 	if err := u.optRepo.CreateItemOption(itemID, tenantID, o); err != nil {
 		logging.UsecaseError("AdminMenu.CreateItemOption", "repository error", "option_create_failed", err, "tenant_id", tenantID, "item_id", itemID)
 		return nil, err
 	}
+	u.menuChanged(tenantID)
 	logging.UsecaseInfo("AdminMenu.CreateItemOption", "option created", "option_created", "tenant_id", tenantID, "item_id", itemID, "option_id", o.ID)
 	return o, nil
 }
+func (u *AdminMenuUC) PatchItemOption(optionID, tenantID string, body map[string]any) (*domain.ItemOption, error) {
+	logging.UsecaseInfo("AdminMenu.PatchItemOption", "patching option", "option_patch_requested", "tenant_id", tenantID, "option_id", optionID)
+	fields, err := optionFields(body, false)
+	if err != nil {
+		logging.UsecaseError("AdminMenu.PatchItemOption", "invalid body", "option_invalid", err, "tenant_id", tenantID, "option_id", optionID)
+		return nil, err
+	}
+	current, err := u.optRepo.FindItemOption(optionID, tenantID)
+	if err != nil {
+		logging.UsecaseError("AdminMenu.PatchItemOption", "repository error", "option_patch_failed", err, "tenant_id", tenantID, "option_id", optionID)
+		return nil, err
+	}
+	applyOptionFields(current, fields)
+	if err := current.CheckLimits(); err != nil {
+		logging.UsecaseError("AdminMenu.PatchItemOption", "invalid limits", "option_invalid", err, "tenant_id", tenantID, "option_id", optionID)
+		return nil, err
+	}
+	defaults, err := u.countDefaults(optionID, "", tenantID)
+	if err != nil {
+		logging.UsecaseError("AdminMenu.PatchItemOption", "repository error", "option_patch_failed", err, "tenant_id", tenantID, "option_id", optionID)
+		return nil, err
+	}
+	atts, err := u.optRepo.ListAttachments(optionID, tenantID)
+	if err != nil {
+		logging.UsecaseError("AdminMenu.PatchItemOption", "repository error", "option_patch_failed", err, "tenant_id", tenantID, "option_id", optionID)
+		return nil, err
+	}
+	if err := checkEffective(*current, atts, defaults); err != nil {
+		logging.UsecaseError("AdminMenu.PatchItemOption", "invalid effective limits", "option_invalid", err, "tenant_id", tenantID, "option_id", optionID)
+		return nil, err
+	}
+	o, err := u.optRepo.PatchItemOption(optionID, tenantID, fields)
+	if err != nil {
+		logging.UsecaseError("AdminMenu.PatchItemOption", "repository error", "option_patch_failed", err, "tenant_id", tenantID, "option_id", optionID)
+		return nil, err
+	}
+	u.menuChanged(tenantID)
+	logging.UsecaseInfo("AdminMenu.PatchItemOption", "option patched", "option_patched", "tenant_id", tenantID, "option_id", optionID)
+	return o, nil
+}
+func (u *AdminMenuUC) DeleteItemOption(optionID, tenantID string) error {
+	logging.UsecaseInfo("AdminMenu.DeleteItemOption", "deleting option", "option_delete_requested", "tenant_id", tenantID, "option_id", optionID)
+	if err := u.optRepo.DeleteItemOption(optionID, tenantID); err != nil {
+		logging.UsecaseError("AdminMenu.DeleteItemOption", "repository error", "option_delete_failed", err, "tenant_id", tenantID, "option_id", optionID)
+		return err
+	}
+	u.menuChanged(tenantID)
+	logging.UsecaseInfo("AdminMenu.DeleteItemOption", "option deleted", "option_deleted", "tenant_id", tenantID, "option_id", optionID)
+	return nil
+}
 func (u *AdminMenuUC) ListOptionValues(optionID, tenantID string) ([]domain.ItemOptionValue, error) {
 	logging.UsecaseInfo("AdminMenu.ListOptionValues", "listing option values", "option_values_list_requested", "tenant_id", tenantID, "option_id", optionID)
 	xs, err := u.optRepo.ListOptionValues(optionID, tenantID)
@@ -216,23 +480,354 @@ func (u *AdminMenuUC) ListOptionValues(optionID, tenantID string) ([]domain.Item
 }
 func (u *AdminMenuUC) CreateOptionValue(optionID, tenantID string, body map[string]any) (*domain.ItemOptionValue, error) {
 	logging.UsecaseInfo("AdminMenu.CreateOptionValue", "creating option value", "option_value_create_requested", "tenant_id", tenantID, "option_id", optionID)
-	v := &domain.ItemOptionValue{Label: body["label"].(string)}
-	if dp, ok := body["delta_price"].(float64); ok {
-		v.DeltaPrice = int64(dp)
+	fields, err := optionValueFields(body, true)
+	if err != nil {
+		logging.UsecaseError("AdminMenu.CreateOptionValue", "invalid body", "option_value_invalid", err, "tenant_id", tenantID, "option_id", optionID)
+		return nil, err
+	}
+	if err := u.checkDefault(optionID, "", tenantID, fields); err != nil {
+		logging.UsecaseError("AdminMenu.CreateOptionValue", "invalid default", "option_value_invalid", err, "tenant_id", tenantID, "option_id", optionID)
+		return nil, err
+	}
+	v := &domain.ItemOptionValue{Label: fields["label"].(string), MaxQty: 1}
+	if dp, ok := fields["delta_price"].(int64); ok {
+		v.DeltaPrice = dp
+	}
+	if n, ok := fields["max_qty"].(int); ok {
+		v.MaxQty = n
+	}
+	if d, ok := fields["is_default"].(bool); ok {
+		v.IsDefault = d
+	}
+	if n, ok := fields["sort"].(int); ok {
+		v.Sort = n
 	}
 	if err := u.optRepo.CreateOptionValue(optionID, tenantID, v); err != nil {
 		logging.UsecaseError("AdminMenu.CreateOptionValue", "repository error", "option_value_create_failed", err, "tenant_id", tenantID, "option_id", optionID)
 		return nil, err
 	}
+	u.menuChanged(tenantID)
 	logging.UsecaseInfo("AdminMenu.CreateOptionValue", "option value created", "option_value_created", "tenant_id", tenantID, "option_id", optionID, "value_id", v.ID)
 	return v, nil
 }
+func (u *AdminMenuUC) PatchOptionValue(optionID, valueID, tenantID string, body map[string]any) (*domain.ItemOptionValue, error) {
+	logging.UsecaseInfo("AdminMenu.PatchOptionValue", "patching option value", "option_value_patch_requested", "tenant_id", tenantID, "option_id", optionID, "value_id", valueID)
+	fields, err := optionValueFields(body, false)
+	if err != nil {
+		logging.UsecaseError("AdminMenu.PatchOptionValue", "invalid body", "option_value_invalid", err, "tenant_id", tenantID, "option_id", optionID, "value_id", valueID)
+		return nil, err
+	}
+	if err := u.checkDefault(optionID, valueID, tenantID, fields); err != nil {
+		logging.UsecaseError("AdminMenu.PatchOptionValue", "invalid default", "option_value_invalid", err, "tenant_id", tenantID, "option_id", optionID, "value_id", valueID)
+		return nil, err
+	}
+	v, err := u.optRepo.PatchOptionValue(optionID, valueID, tenantID, fields)
+	if err != nil {
+		logging.UsecaseError("AdminMenu.PatchOptionValue", "repository error", "option_value_patch_failed", err, "tenant_id", tenantID, "option_id", optionID, "value_id", valueID)
+		return nil, err
+	}
+	u.menuChanged(tenantID)
+	logging.UsecaseInfo("AdminMenu.PatchOptionValue", "option value patched", "option_value_patched", "tenant_id", tenantID, "option_id", optionID, "value_id", valueID)
+	return v, nil
+}
+func (u *AdminMenuUC) DeleteOptionValue(optionID, valueID, tenantID string) error {
+	logging.UsecaseInfo("AdminMenu.DeleteOptionValue", "deleting option value", "option_value_delete_requested", "tenant_id", tenantID, "option_id", optionID, "value_id", valueID)
+	if err := u.optRepo.DeleteOptionValue(optionID, valueID, tenantID); err != nil {
+		logging.UsecaseError("AdminMenu.DeleteOptionValue", "repository error", "option_value_delete_failed", err, "tenant_id", tenantID, "option_id", optionID, "value_id", valueID)
+		return err
+	}
+	u.menuChanged(tenantID)
+	logging.UsecaseInfo("AdminMenu.DeleteOptionValue", "option value deleted", "option_value_deleted", "tenant_id", tenantID, "option_id", optionID, "value_id", valueID)
+	return nil
+}
+
+func (u *AdminMenuUC) ListAttachments(optionID, tenantID string) ([]domain.OptionAttachment, error) {
+	logging.UsecaseInfo("AdminMenu.ListAttachments", "listing option attachments", "attachments_list_requested", "tenant_id", tenantID, "option_id", optionID)
+	xs, err := u.optRepo.ListAttachments(optionID, tenantID)
+	if err != nil {
+		logging.UsecaseError("AdminMenu.ListAttachments", "repository error", "attachments_list_failed", err, "tenant_id", tenantID, "option_id", optionID)
+		return nil, err
+	}
+	logging.UsecaseInfo("AdminMenu.ListAttachments", "option attachments loaded", "attachments_listed", "tenant_id", tenantID, "option_id", optionID, "count", len(xs))
+	return xs, nil
+}
+
+// Attach offers an option on an item or a whole category, replacing the
+// overrides of an existing attachment to the same target.
+func (u *AdminMenuUC) Attach(optionID, tenantID string, body map[string]any) (*domain.OptionAttachment, error) {
+	logging.UsecaseInfo("AdminMenu.Attach", "attaching option", "option_attach_requested", "tenant_id", tenantID, "option_id", optionID)
+	a, err := attachmentFromBody(body)
+	if err != nil {
+		logging.UsecaseError("AdminMenu.Attach", "invalid body", "attachment_invalid", err, "tenant_id", tenantID, "option_id", optionID)
+		return nil, err
+	}
+	a.TenantID, a.OptionID = tenantID, optionID
+	opt, err := u.optRepo.FindItemOption(optionID, tenantID)
+	if err != nil {
+		logging.UsecaseError("AdminMenu.Attach", "repository error", "option_attach_failed", err, "tenant_id", tenantID, "option_id", optionID)
+		return nil, err
+	}
+	effective := a.Apply(*opt)
+	if err := effective.CheckLimits(); err != nil {
+		logging.UsecaseError("AdminMenu.Attach", "invalid overrides", "attachment_invalid", err, "tenant_id", tenantID, "option_id", optionID)
+		return nil, err
+	}
+	defaults, err := u.countDefaults(optionID, "", tenantID)
+	if err != nil {
+		logging.UsecaseError("AdminMenu.Attach", "repository error", "option_attach_failed", err, "tenant_id", tenantID, "option_id", optionID)
+		return nil, err
+	}
+	if err := defaultsFit(effective, defaults); err != nil {
+		logging.UsecaseError("AdminMenu.Attach", "defaults exceed overrides", "attachment_invalid", err, "tenant_id", tenantID, "option_id", optionID)
+		return nil, err
+	}
+	if err := u.optRepo.Attach(a); err != nil {
+		logging.UsecaseError("AdminMenu.Attach", "repository error", "option_attach_failed", err, "tenant_id", tenantID, "option_id", optionID)
+		return nil, err
+	}
+	u.menuChanged(tenantID)
+	logging.UsecaseInfo("AdminMenu.Attach", "option attached", "option_attached", "tenant_id", tenantID, "option_id", optionID, "attachment_id", a.ID)
+	return a, nil
+}
+func (u *AdminMenuUC) Detach(optionID, attachmentID, tenantID string) error {
+	logging.UsecaseInfo("AdminMenu.Detach", "detaching option", "option_detach_requested", "tenant_id", tenantID, "option_id", optionID, "attachment_id", attachmentID)
+	if err := u.optRepo.Detach(optionID, attachmentID, tenantID); err != nil {
+		logging.UsecaseError("AdminMenu.Detach", "repository error", "option_detach_failed", err, "tenant_id", tenantID, "option_id", optionID, "attachment_id", attachmentID)
+		return err
+	}
+	u.menuChanged(tenantID)
+	logging.UsecaseInfo("AdminMenu.Detach", "option detached", "option_detached", "tenant_id", tenantID, "option_id", optionID, "attachment_id", attachmentID)
+	return nil
+}
+
+// requiredString reads a non-blank string field of a request body.
+func requiredString(body map[string]any, key string) (string, error) {
+	v, ok := body[key].(string)
+	if !ok || strings.TrimSpace(v) == "" {
+		return "", fmt.Errorf("%s is required", key)
+	}
+	return strings.TrimSpace(v), nil
+}
+
+// wholeNumber reads a JSON number field that must not have a fraction.
+func wholeNumber(body map[string]any, key string) (int64, error) {
+	n, ok := body[key].(float64)
+	if !ok || n != float64(int64(n)) {
+		return 0, fmt.Errorf("%s must be a whole number", key)
+	}
+	return int64(n), nil
+}
+
+// optionFields validates an option body into the columns to write. On create,
+// name and type are required; on patch, only the keys present are checked.
+func optionFields(body map[string]any, create bool) (map[string]any, error) {
+	fields := map[string]any{}
+	for _, key := range []string{"name", "type"} {
+		if _, ok := body[key]; !ok && !create {
+			continue
+		}
+		v, err := requiredString(body, key)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", domain.ErrInvalidOption, err)
+		}
+		fields[key] = v
+	}
+	if t, ok := fields["type"].(string); ok && !domain.ValidOptionType(t) {
+		return nil, fmt.Errorf("%w: type must be one of %s, %s, %s", domain.ErrInvalidOption, domain.OptionTypeSize, domain.OptionTypeAddon, domain.OptionTypeLevel)
+	}
+	if raw, ok := body["required"]; ok {
+		v, isBool := raw.(bool)
+		if !isBool {
+			return nil, fmt.Errorf("%w: required must be a boolean", domain.ErrInvalidOption)
+		}
+		fields["required"] = v
+	}
+	for _, key := range []string{"min_select", "max_select"} {
+		if _, ok := body[key]; !ok {
+			continue
+		}
+		n, err := wholeNumber(body, key)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("%w: %s must be a whole number >= 0", domain.ErrInvalidOption, key)
+		}
+		fields[key] = int(n)
+	}
+	if _, ok := body["sort"]; ok {
+		n, err := wholeNumber(body, "sort")
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", domain.ErrInvalidOption, err)
+		}
+		fields["sort"] = int(n)
+	}
+	return fields, nil
+}
+
+// optionValueFields validates an option value body like optionFields; label is
+// required on create.
+func optionValueFields(body map[string]any, create bool) (map[string]any, error) {
+	fields := map[string]any{}
+	if _, ok := body["label"]; ok || create {
+		v, err := requiredString(body, "label")
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", domain.ErrInvalidOption, err)
+		}
+		fields["label"] = v
+	}
+	if _, ok := body["delta_price"]; ok {
+		n, err := wholeNumber(body, "delta_price")
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", domain.ErrInvalidOption, err)
+		}
+		fields["delta_price"] = n
+	}
+	if _, ok := body["max_qty"]; ok {
+		n, err := wholeNumber(body, "max_qty")
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("%w: max_qty must be a whole number >= 1", domain.ErrInvalidOption)
+		}
+		fields["max_qty"] = int(n)
+	}
+	if raw, ok := body["is_default"]; ok {
+		v, isBool := raw.(bool)
+		if !isBool {
+			return nil, fmt.Errorf("%w: is_default must be a boolean", domain.ErrInvalidOption)
+		}
+		fields["is_default"] = v
+	}
+	if _, ok := body["sort"]; ok {
+		n, err := wholeNumber(body, "sort")
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", domain.ErrInvalidOption, err)
+		}
+		fields["sort"] = int(n)
+	}
+	return fields, nil
+}
+
+// applyOptionFields copies validated option fields onto o.
+func applyOptionFields(o *domain.ItemOption, fields map[string]any) {
+	if v, ok := fields["name"].(string); ok {
+		o.Name = v
+	}
+	if v, ok := fields["type"].(string); ok {
+		o.Type = v
+	}
+	if v, ok := fields["required"].(bool); ok {
+		o.Required = v
+	}
+	if v, ok := fields["min_select"].(int); ok {
+		o.MinSelect = v
+	}
+	if v, ok := fields["max_select"].(int); ok {
+		o.MaxSelect = v
+	}
+	if v, ok := fields["sort"].(int); ok {
+		o.Sort = v
+	}
+}
+
+// checkDefault rejects making a value default when the option's defaults would
+// exceed what a guest may pick, on the option itself or under any attachment's
+// overrides, since defaults are applied as a guest selection. valueID is the
+// value being changed, empty on create.
+func (u *AdminMenuUC) checkDefault(optionID, valueID, tenantID string, fields map[string]any) error {
+	if d, _ := fields["is_default"].(bool); !d {
+		return nil
+	}
+	opt, err := u.optRepo.FindItemOption(optionID, tenantID)
+	if err != nil {
+		return err
+	}
+	defaults, err := u.countDefaults(optionID, valueID, tenantID)
+	if err != nil {
+		return err
+	}
+	atts, err := u.optRepo.ListAttachments(optionID, tenantID)
+	if err != nil {
+		return err
+	}
+	return checkEffective(*opt, atts, defaults+1)
+}
+
+// checkEffective validates opt as offered through each attachment: the
+// overridden limits must be consistent and leave room for the default values.
+func checkEffective(opt domain.ItemOption, atts []domain.OptionAttachment, defaults int) error {
+	if err := defaultsFit(opt, defaults); err != nil {
+		return err
+	}
+	for i := range atts {
+		eff := atts[i].Apply(opt)
+		if err := eff.CheckLimits(); err != nil {
+			return fmt.Errorf("attachment %s: %w", atts[i].ID, err)
+		}
+		if err := defaultsFit(eff, defaults); err != nil {
+			return fmt.Errorf("attachment %s: %w", atts[i].ID, err)
+		}
+	}
+	return nil
+}
+
+// countDefaults counts the option's default values, leaving out exceptID.
+func (u *AdminMenuUC) countDefaults(optionID, exceptID, tenantID string) (int, error) {
+	vals, err := u.optRepo.ListOptionValues(optionID, tenantID)
+	if err != nil {
+		return 0, err
+	}
+	n := 0
+	for _, v := range vals {
+		if v.IsDefault && v.ID != exceptID {
+			n++
+		}
+	}
+	return n, nil
+}
+
+// defaultsFit rejects more default values than a guest may pick from opt.
+func defaultsFit(opt domain.ItemOption, defaults int) error {
+	if max := opt.MaxChoices(); max > 0 && defaults > max {
+		return fmt.Errorf("%w: option %s allows at most %d default value(s)", domain.ErrInvalidOption, opt.Name, max)
+	}
+	return nil
+}
 
-// ===== Tables (QR)
-func (u *AdminMenuUC) GenerateTableQR(tableID, tenantID string) (string, error) {
-	logging.UsecaseInfo("AdminMenu.GenerateTableQR", "generating qr", "qr_generate_requested", "tenant_id", tenantID, "table_id", tableID)
-	// TODO: implement real QR generation / storage
-	url := "https://example.com/qr/" + tableID
-	logging.UsecaseInfo("AdminMenu.GenerateTableQR", "qr generated", "qr_generated", "tenant_id", tenantID, "table_id", tableID)
-	return url, nil
+// attachmentFromBody reads an attachment body: exactly one of item_id and
+// category_id, plus optional overrides (null or absent keeps the option's value).
+func attachmentFromBody(body map[string]any) (*domain.OptionAttachment, error) {
+	a := &domain.OptionAttachment{}
+	itemID, _ := body["item_id"].(string)
+	categoryID, _ := body["category_id"].(string)
+	switch {
+	case itemID != "" && categoryID == "":
+		a.ItemID = &itemID
+	case categoryID != "" && itemID == "":
+		a.CategoryID = &categoryID
+	default:
+		return nil, fmt.Errorf("%w: exactly one of item_id and category_id is required", domain.ErrInvalidOption)
+	}
+	if raw, ok := body["required"]; ok && raw != nil {
+		v, isBool := raw.(bool)
+		if !isBool {
+			return nil, fmt.Errorf("%w: required must be a boolean", domain.ErrInvalidOption)
+		}
+		a.Required = &v
+	}
+	for _, key := range []string{"min_select", "max_select", "sort"} {
+		if raw, ok := body[key]; !ok || raw == nil {
+			continue
+		}
+		n, err := wholeNumber(body, key)
+		if err != nil || (key != "sort" && n < 0) {
+			return nil, fmt.Errorf("%w: %s must be a whole number", domain.ErrInvalidOption, key)
+		}
+		v := int(n)
+		switch key {
+		case "min_select":
+			a.MinSelect = &v
+		case "max_select":
+			a.MaxSelect = &v
+		default:
+			a.Sort = &v
+		}
+	}
+	return a, nil
 }
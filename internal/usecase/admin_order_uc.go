@@ -1,6 +1,8 @@
 package usecase
 
 import (
+	"context"
+
 	"qrmenu/internal/domain"
 	"qrmenu/internal/platform/logging"
 	"qrmenu/internal/repository"
@@ -8,15 +10,16 @@ import (
 
 type AdminOrdersUC struct {
 	orders repository.OrderRepository
+	events OrderEventBus
 }
 
-func NewAdminOrdersUC(r repository.OrderRepository) *AdminOrdersUC {
-	return &AdminOrdersUC{orders: r}
+func NewAdminOrdersUC(r repository.OrderRepository, ev OrderEventBus) *AdminOrdersUC {
+	return &AdminOrdersUC{orders: r, events: ev}
 }
 
-func (u *AdminOrdersUC) List(tenantID, status, cursor string) (repository.OrdersPage, error) {
-	logging.UsecaseInfo("AdminOrders.List", "listing orders", "orders_list_requested", "tenant_id", tenantID, "status", status, "cursor", cursor)
-	page, err := u.orders.ListAdmin(tenantID, status, cursor, 20)
+func (u *AdminOrdersUC) List(tenantID, status, areaID, cursor string) (repository.OrdersPage, error) {
+	logging.UsecaseInfo("AdminOrders.List", "listing orders", "orders_list_requested", "tenant_id", tenantID, "status", status, "area_id", areaID, "cursor", cursor)
+	page, err := u.orders.ListAdmin(tenantID, status, areaID, cursor, 20)
 	if err != nil {
 		logging.UsecaseError("AdminOrders.List", "repository error", "orders_list_failed", err, "tenant_id", tenantID, "status", status, "cursor", cursor)
 		return repository.OrdersPage{}, err
@@ -25,13 +28,69 @@ func (u *AdminOrdersUC) List(tenantID, status, cursor string) (repository.Orders
 	return page, nil
 }
 
-func (u *AdminOrdersUC) UpdateStatus(tenantID, id, status string) (*domain.Order, error) {
+// UpdateStatus moves an order along its lifecycle on behalf of an admin user.
+// Unknown statuses and transitions not allowed from the current status are rejected.
+func (u *AdminOrdersUC) UpdateStatus(tenantID, id, status, adminID, reason string) (*domain.Order, error) {
 	logging.UsecaseInfo("AdminOrders.UpdateStatus", "updating status", "order_status_update_requested", "tenant_id", tenantID, "order_id", id, "status", status)
-	ord, err := u.orders.UpdateStatus(tenantID, id, domain.OrderStatus(status))
+	next := domain.OrderStatus(status)
+	if !next.Valid() {
+		logging.UsecaseError("AdminOrders.UpdateStatus", "unknown status", "invalid_order_status", domain.ErrInvalidOrderStatus, "tenant_id", tenantID, "order_id", id, "status", status)
+		return nil, domain.ErrInvalidOrderStatus
+	}
+	ord, err := u.orders.UpdateStatus(tenantID, id, domain.OrderStatusChange{To: next, ChangedBy: adminID, Reason: reason})
 	if err != nil {
 		logging.UsecaseError("AdminOrders.UpdateStatus", "repository error", "order_status_update_failed", err, "tenant_id", tenantID, "order_id", id, "status", status)
 		return nil, err
 	}
+	publishOrderEvent(u.events, domain.OrderEventStatusChanged, ord)
 	logging.UsecaseInfo("AdminOrders.UpdateStatus", "status updated", "order_status_updated", "tenant_id", tenantID, "order_id", id, "status", status)
 	return ord, nil
 }
+
+// UpdateItemStatus moves a single order line along its preparation lifecycle.
+// The order status follows the lines (see domain.DeriveOrderStatus).
+func (u *AdminOrdersUC) UpdateItemStatus(tenantID, orderID, itemID, status, adminID, reason string) (*domain.Order, error) {
+	logging.UsecaseInfo("AdminOrders.UpdateItemStatus", "updating item status", "order_item_update_requested", "tenant_id", tenantID, "order_id", orderID, "order_item_id", itemID, "status", status)
+	next := domain.OrderItemStatus(status)
+	if !next.Valid() {
+		logging.UsecaseError("AdminOrders.UpdateItemStatus", "unknown status", "invalid_item_status", domain.ErrInvalidItemStatus, "tenant_id", tenantID, "order_id", orderID, "status", status)
+		return nil, domain.ErrInvalidItemStatus
+	}
+	ord, statusChanged, err := u.orders.UpdateItemStatus(tenantID, orderID, itemID, domain.OrderItemStatusChange{To: next, ChangedBy: adminID, Reason: reason})
+	if err != nil {
+		logging.UsecaseError("AdminOrders.UpdateItemStatus", "repository error", "order_item_update_failed", err, "tenant_id", tenantID, "order_id", orderID, "order_item_id", itemID)
+		return nil, err
+	}
+
+	ev := domain.NewOrderEvent(domain.OrderEventItemChanged, ord)
+	ev.ItemID, ev.ItemStatus = itemID, next
+	publishEvent(u.events, ev)
+	if statusChanged {
+		publishOrderEvent(u.events, domain.OrderEventStatusChanged, ord)
+	}
+	logging.UsecaseInfo("AdminOrders.UpdateItemStatus", "item status updated", "order_item_updated", "tenant_id", tenantID, "order_id", orderID, "order_item_id", itemID, "status", status, "order_status", ord.Status)
+	return ord, nil
+}
+
+func (u *AdminOrdersUC) Get(tenantID, id string) (*domain.Order, error) {
+	logging.UsecaseInfo("AdminOrders.Get", "loading order", "order_get_requested", "tenant_id", tenantID, "order_id", id)
+	ord, err := u.orders.FindByID(tenantID, id)
+	if err != nil {
+		logging.UsecaseError("AdminOrders.Get", "repository error", "order_get_failed", err, "tenant_id", tenantID, "order_id", id)
+		return nil, err
+	}
+	logging.UsecaseInfo("AdminOrders.Get", "order loaded", "order_loaded", "tenant_id", tenantID, "order_id", id, "status", ord.Status)
+	return ord, nil
+}
+
+// Stream subscribes to the tenant's live order feed until ctx is canceled.
+// lastEventID resumes the feed after the given event.
+func (u *AdminOrdersUC) Stream(ctx context.Context, tenantID, lastEventID string) (<-chan domain.OrderEvent, error) {
+	logging.UsecaseInfo("AdminOrders.Stream", "opening order feed", "order_stream_requested", "tenant_id", tenantID, "last_event_id", lastEventID)
+	ch, err := u.events.Subscribe(ctx, tenantID, lastEventID)
+	if err != nil {
+		logging.UsecaseError("AdminOrders.Stream", "subscribe failed", "order_stream_failed", err, "tenant_id", tenantID, "last_event_id", lastEventID)
+		return nil, err
+	}
+	return ch, nil
+}
@@ -0,0 +1,321 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+
+	"qrmenu/internal/domain"
+	"qrmenu/internal/platform/logging"
+	"qrmenu/internal/platform/qr"
+	"qrmenu/internal/platform/security"
+	"qrmenu/internal/repository"
+)
+
+// tableTokenBytes is the entropy of a table's QR token (32 base64url characters).
+const tableTokenBytes = 24
+
+// AdminTablesUC manages a tenant's tables, their QR tokens and QR codes.
+type AdminTablesUC struct {
+	tables   repository.TableRepository
+	areas    repository.TableAreaRepository
+	tenants  repository.TenantRepository
+	guestURL string
+}
+
+// NewAdminTablesUC builds the use case; guestURL is the default QR link template
+// for tenants that have not set their own.
+func NewAdminTablesUC(t repository.TableRepository, a repository.TableAreaRepository, tn repository.TenantRepository, guestURL string) *AdminTablesUC {
+	return &AdminTablesUC{tables: t, areas: a, tenants: tn, guestURL: guestURL}
+}
+
+// tableAreaFields reads the optional "area_id" and "capacity" of a table body. A null
+// or empty area_id takes the table out of its area.
+func (u *AdminTablesUC) tableAreaFields(tenantID string, body map[string]any) (map[string]any, error) {
+	fields := map[string]any{}
+	if raw, ok := body["area_id"]; ok {
+		id, isString := raw.(string)
+		switch {
+		case raw == nil || (isString && id == ""):
+			fields["area_id"] = nil
+		case !isString:
+			return nil, fmt.Errorf("area_id must be a string")
+		default:
+			if _, err := u.areas.Find(tenantID, id); err != nil {
+				return nil, err
+			}
+			fields["area_id"] = id
+		}
+	}
+	if raw, ok := body["capacity"]; ok {
+		n, isNum := raw.(float64)
+		if !isNum || n < 0 || n != float64(int(n)) {
+			return nil, fmt.Errorf("capacity must be a whole number >= 0")
+		}
+		fields["capacity"] = int(n)
+	}
+	return fields, nil
+}
+
+// TableQR is a rendered QR code of a table together with the link it encodes.
+type TableQR struct {
+	Table  domain.Table
+	URL    string
+	Format qr.Format
+	Image  []byte
+}
+
+func (u *AdminTablesUC) List(tenantID string) ([]domain.Table, error) {
+	logging.UsecaseInfo("AdminTables.List", "listing tables", "tables_list_requested", "tenant_id", tenantID)
+	xs, err := u.tables.List(tenantID)
+	if err != nil {
+		logging.UsecaseError("AdminTables.List", "repository error", "tables_list_failed", err, "tenant_id", tenantID)
+		return nil, err
+	}
+	return xs, nil
+}
+
+func (u *AdminTablesUC) Get(tenantID, id string) (*domain.Table, error) {
+	logging.UsecaseInfo("AdminTables.Get", "loading table", "table_requested", "tenant_id", tenantID, "table_id", id)
+	t, err := u.tables.Find(tenantID, id)
+	if err != nil {
+		logging.UsecaseError("AdminTables.Get", "repository error", "table_failed", err, "tenant_id", tenantID, "table_id", id)
+		return nil, err
+	}
+	return t, nil
+}
+
+// Create adds a table with a freshly generated token.
+func (u *AdminTablesUC) Create(tenantID string, body map[string]any) (*domain.Table, error) {
+	logging.UsecaseInfo("AdminTables.Create", "creating table", "table_create_requested", "tenant_id", tenantID)
+	name, _ := body["name"].(string)
+	if name = strings.TrimSpace(name); name == "" {
+		err := fmt.Errorf("name is required")
+		logging.UsecaseError("AdminTables.Create", "invalid payload", "table_invalid", err, "tenant_id", tenantID)
+		return nil, err
+	}
+	token, err := security.RandomToken(tableTokenBytes)
+	if err != nil {
+		logging.UsecaseError("AdminTables.Create", "token generation failed", "token_generate_failed", err, "tenant_id", tenantID)
+		return nil, err
+	}
+	t := &domain.Table{TenantID: tenantID, Name: name, Token: token, IsActive: true}
+	if v, ok := body["code"].(string); ok {
+		t.Code = strings.TrimSpace(v)
+	}
+	if v, ok := body["is_active"].(bool); ok {
+		t.IsActive = v
+	}
+	extra, err := u.tableAreaFields(tenantID, body)
+	if err != nil {
+		logging.UsecaseError("AdminTables.Create", "invalid payload", "table_invalid", err, "tenant_id", tenantID)
+		return nil, err
+	}
+	if v, ok := extra["area_id"].(string); ok {
+		t.AreaID = &v
+	}
+	if v, ok := extra["capacity"].(int); ok {
+		t.Capacity = v
+	}
+	if err := u.tables.Create(t); err != nil {
+		logging.UsecaseError("AdminTables.Create", "repository error", "table_create_failed", err, "tenant_id", tenantID)
+		return nil, err
+	}
+	logging.UsecaseInfo("AdminTables.Create", "table created", "table_created", "tenant_id", tenantID, "table_id", t.ID)
+	return t, nil
+}
+
+// Patch renames, recodes, (de)activates a table or changes its area and capacity;
+// other keys are ignored.
+func (u *AdminTablesUC) Patch(tenantID, id string, body map[string]any) (*domain.Table, error) {
+	logging.UsecaseInfo("AdminTables.Patch", "patching table", "table_patch_requested", "tenant_id", tenantID, "table_id", id)
+	fields, err := u.tableAreaFields(tenantID, body)
+	if err != nil {
+		logging.UsecaseError("AdminTables.Patch", "invalid payload", "table_invalid", err, "tenant_id", tenantID, "table_id", id)
+		return nil, err
+	}
+	if raw, ok := body["name"]; ok {
+		name, _ := raw.(string)
+		if name = strings.TrimSpace(name); name == "" {
+			err := fmt.Errorf("name must be a non-empty string")
+			logging.UsecaseError("AdminTables.Patch", "invalid payload", "table_invalid", err, "tenant_id", tenantID, "table_id", id)
+			return nil, err
+		}
+		fields["name"] = name
+	}
+	if v, ok := body["code"].(string); ok {
+		fields["code"] = strings.TrimSpace(v)
+	}
+	if v, ok := body["is_active"].(bool); ok {
+		fields["is_active"] = v
+	}
+	if len(fields) == 0 {
+		return u.tables.Find(tenantID, id)
+	}
+	t, err := u.tables.Patch(tenantID, id, fields)
+	if err != nil {
+		logging.UsecaseError("AdminTables.Patch", "repository error", "table_patch_failed", err, "tenant_id", tenantID, "table_id", id)
+		return nil, err
+	}
+	logging.UsecaseInfo("AdminTables.Patch", "table patched", "table_patched", "tenant_id", tenantID, "table_id", id)
+	return t, nil
+}
+
+func (u *AdminTablesUC) Delete(tenantID, id string) error {
+	logging.UsecaseInfo("AdminTables.Delete", "deleting table", "table_delete_requested", "tenant_id", tenantID, "table_id", id)
+	if err := u.tables.Delete(tenantID, id); err != nil {
+		logging.UsecaseError("AdminTables.Delete", "repository error", "table_delete_failed", err, "tenant_id", tenantID, "table_id", id)
+		return err
+	}
+	return nil
+}
+
+// RotateToken gives the table a new token; QR codes printed with the old one stop working.
+func (u *AdminTablesUC) RotateToken(tenantID, id string) (*domain.Table, error) {
+	logging.UsecaseInfo("AdminTables.RotateToken", "rotating token", "token_rotate_requested", "tenant_id", tenantID, "table_id", id)
+	token, err := security.RandomToken(tableTokenBytes)
+	if err != nil {
+		logging.UsecaseError("AdminTables.RotateToken", "token generation failed", "token_generate_failed", err, "tenant_id", tenantID, "table_id", id)
+		return nil, err
+	}
+	t, err := u.tables.Patch(tenantID, id, map[string]any{"token": token})
+	if err != nil {
+		logging.UsecaseError("AdminTables.RotateToken", "repository error", "token_rotate_failed", err, "tenant_id", tenantID, "table_id", id)
+		return nil, err
+	}
+	logging.UsecaseInfo("AdminTables.RotateToken", "token rotated", "token_rotated", "tenant_id", tenantID, "table_id", id)
+	return t, nil
+}
+
+// QR renders the table's QR code pointing at the tenant's guest URL.
+func (u *AdminTablesUC) QR(tenantID, id string, opts qr.Options) (*TableQR, error) {
+	logging.UsecaseInfo("AdminTables.QR", "generating qr", "qr_generate_requested", "tenant_id", tenantID, "table_id", id, "format", opts.Format)
+	opts, err := opts.Normalize()
+	if err != nil {
+		logging.UsecaseError("AdminTables.QR", "invalid options", "qr_invalid", err, "tenant_id", tenantID, "table_id", id)
+		return nil, err
+	}
+	t, err := u.tables.Find(tenantID, id)
+	if err != nil {
+		logging.UsecaseError("AdminTables.QR", "repository error", "qr_generate_failed", err, "tenant_id", tenantID, "table_id", id)
+		return nil, err
+	}
+	tn, err := u.tenants.FindByID(tenantID)
+	if err != nil {
+		logging.UsecaseError("AdminTables.QR", "repository error", "qr_generate_failed", err, "tenant_id", tenantID, "table_id", id)
+		return nil, err
+	}
+	link := tn.GuestURL(u.guestURL, t.Token)
+	img, err := qr.Render(link, opts)
+	if err != nil {
+		logging.UsecaseError("AdminTables.QR", "render failed", "qr_render_failed", err, "tenant_id", tenantID, "table_id", id)
+		return nil, err
+	}
+	logging.UsecaseInfo("AdminTables.QR", "qr generated", "qr_generated", "tenant_id", tenantID, "table_id", id, "format", opts.Format, "size", opts.Size, "level", opts.Level)
+	return &TableQR{Table: *t, URL: link, Format: opts.Format, Image: img}, nil
+}
+
+// GenerateTableQR returns the guest link encoded in the table's QR code, for
+// clients that render the code themselves.
+func (u *AdminTablesUC) GenerateTableQR(tenantID, id string) (string, error) {
+	logging.UsecaseInfo("AdminTables.GenerateTableQR", "resolving qr link", "qr_link_requested", "tenant_id", tenantID, "table_id", id)
+	t, err := u.tables.Find(tenantID, id)
+	if err != nil {
+		logging.UsecaseError("AdminTables.GenerateTableQR", "repository error", "qr_generate_failed", err, "tenant_id", tenantID, "table_id", id)
+		return "", err
+	}
+	tn, err := u.tenants.FindByID(tenantID)
+	if err != nil {
+		logging.UsecaseError("AdminTables.GenerateTableQR", "repository error", "qr_generate_failed", err, "tenant_id", tenantID, "table_id", id)
+		return "", err
+	}
+	logging.UsecaseInfo("AdminTables.GenerateTableQR", "qr link resolved", "qr_generated", "tenant_id", tenantID, "table_id", id)
+	return tn.GuestURL(u.guestURL, t.Token), nil
+}
+
+// QRSheet renders the tenant's active tables (or only ids, when given) into a
+// printable HTML sheet with the tenant logo, table name/code and QR code.
+func (u *AdminTablesUC) QRSheet(tenantID string, ids []string, layout qr.Layout, level qr.Level) ([]byte, error) {
+	logging.UsecaseInfo("AdminTables.QRSheet", "generating qr sheet", "qr_sheet_requested", "tenant_id", tenantID, "layout", layout, "tables", len(ids))
+	if layout == "" {
+		layout = qr.LayoutGrid
+	}
+	if !layout.Valid() {
+		err := fmt.Errorf("layout must be grid or tent")
+		logging.UsecaseError("AdminTables.QRSheet", "invalid options", "qr_invalid", err, "tenant_id", tenantID)
+		return nil, err
+	}
+	opts, err := qr.Options{Format: qr.FormatSVG, Level: level}.Normalize()
+	if err != nil {
+		logging.UsecaseError("AdminTables.QRSheet", "invalid options", "qr_invalid", err, "tenant_id", tenantID)
+		return nil, err
+	}
+	tn, err := u.tenants.FindByID(tenantID)
+	if err != nil {
+		logging.UsecaseError("AdminTables.QRSheet", "repository error", "qr_sheet_failed", err, "tenant_id", tenantID)
+		return nil, err
+	}
+	all, err := u.tables.List(tenantID)
+	if err != nil {
+		logging.UsecaseError("AdminTables.QRSheet", "repository error", "qr_sheet_failed", err, "tenant_id", tenantID)
+		return nil, err
+	}
+	tables, err := selectTables(all, ids)
+	if err != nil {
+		logging.UsecaseError("AdminTables.QRSheet", "invalid selection", "qr_sheet_failed", err, "tenant_id", tenantID)
+		return nil, err
+	}
+
+	sheet := qr.Sheet{Title: tn.Name + " – table QR codes", Layout: layout}
+	if tn.LogoURL != nil {
+		sheet.LogoURL = *tn.LogoURL
+	}
+	for _, t := range tables {
+		link := tn.GuestURL(u.guestURL, t.Token)
+		img, err := qr.Render(link, opts)
+		if err != nil {
+			logging.UsecaseError("AdminTables.QRSheet", "render failed", "qr_render_failed", err, "tenant_id", tenantID, "table_id", t.ID)
+			return nil, err
+		}
+		sheet.Cards = append(sheet.Cards, qr.SheetCard{Name: t.Name, Code: t.Code, URL: link, SVG: img})
+	}
+	doc, err := qr.RenderSheet(sheet)
+	if err != nil {
+		logging.UsecaseError("AdminTables.QRSheet", "render failed", "qr_render_failed", err, "tenant_id", tenantID)
+		return nil, err
+	}
+	logging.UsecaseInfo("AdminTables.QRSheet", "qr sheet generated", "qr_sheet_generated", "tenant_id", tenantID, "layout", layout, "tables", len(tables))
+	return doc, nil
+}
+
+// selectTables keeps the active tables, restricted to ids when any are given.
+// Unknown ids are reported as ErrTableNotFound.
+func selectTables(all []domain.Table, ids []string) ([]domain.Table, error) {
+	var out []domain.Table
+	if len(ids) == 0 {
+		for _, t := range all {
+			if t.IsActive {
+				out = append(out, t)
+			}
+		}
+	} else {
+		byID := make(map[string]domain.Table, len(all))
+		for _, t := range all {
+			byID[t.ID] = t
+		}
+		seen := map[string]bool{}
+		for _, id := range ids {
+			t, ok := byID[id]
+			if !ok {
+				return nil, fmt.Errorf("%w: %s", domain.ErrTableNotFound, id)
+			}
+			if t.IsActive && !seen[id] {
+				seen[id] = true
+				out = append(out, t)
+			}
+		}
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("no active tables to print")
+	}
+	return out, nil
+}
@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"qrmenu/internal/domain"
+	"qrmenu/internal/platform/logging"
+	"qrmenu/internal/repository"
+)
+
+// BillUC splits a table's tab into sub-bills and records their payments.
+type BillUC struct {
+	bills repository.BillRepository
+}
+
+func NewBillUC(b repository.BillRepository) *BillUC { return &BillUC{bills: b} }
+
+// Split replaces the bills of the table's current session with a new split.
+func (u *BillUC) Split(tenantID, tableID string, req domain.SplitRequest) ([]domain.Bill, error) {
+	logging.UsecaseInfo("Bill.Split", "splitting table bill", "bill_split_requested", "tenant_id", tenantID, "table_id", tableID, "mode", req.Mode)
+	if !req.Mode.Valid() {
+		logging.UsecaseError("Bill.Split", "invalid split mode", "split_invalid", domain.ErrInvalidSplit, "tenant_id", tenantID, "table_id", tableID, "mode", req.Mode)
+		return nil, domain.ErrInvalidSplit
+	}
+	bills, err := u.bills.Split(tenantID, tableID, req)
+	if err != nil {
+		logging.UsecaseError("Bill.Split", "repository error", "bill_split_failed", err, "tenant_id", tenantID, "table_id", tableID)
+		return nil, err
+	}
+	logging.UsecaseInfo("Bill.Split", "table bill split", "bill_split", "tenant_id", tenantID, "table_id", tableID, "bills", len(bills))
+	return bills, nil
+}
+
+func (u *BillUC) ListByTable(tenantID, tableID string) ([]domain.Bill, error) {
+	logging.UsecaseInfo("Bill.ListByTable", "listing bills", "bills_list_requested", "tenant_id", tenantID, "table_id", tableID)
+	xs, err := u.bills.ListByTable(tenantID, tableID)
+	if err != nil {
+		logging.UsecaseError("Bill.ListByTable", "repository error", "bills_list_failed", err, "tenant_id", tenantID, "table_id", tableID)
+		return nil, err
+	}
+	return xs, nil
+}
+
+func (u *BillUC) Get(tenantID, id string) (*domain.Bill, error) {
+	logging.UsecaseInfo("Bill.Get", "loading bill", "bill_requested", "tenant_id", tenantID, "bill_id", id)
+	b, err := u.bills.Find(tenantID, id)
+	if err != nil {
+		logging.UsecaseError("Bill.Get", "repository error", "bill_failed", err, "tenant_id", tenantID, "bill_id", id)
+		return nil, err
+	}
+	return b, nil
+}
+
+// Clear drops an unpaid split so the table is billed as a whole again.
+func (u *BillUC) Clear(tenantID, tableID string) error {
+	logging.UsecaseInfo("Bill.Clear", "clearing bills", "bills_clear_requested", "tenant_id", tenantID, "table_id", tableID)
+	if err := u.bills.Clear(tenantID, tableID); err != nil {
+		logging.UsecaseError("Bill.Clear", "repository error", "bills_clear_failed", err, "tenant_id", tenantID, "table_id", tableID)
+		return err
+	}
+	return nil
+}
+
+// Pay records a cashier payment against one bill.
+func (u *BillUC) Pay(tenantID, id string, req domain.PaymentRequest) (*domain.Payment, error) {
+	logging.UsecaseInfo("Bill.Pay", "recording bill payment", "bill_payment_requested", "tenant_id", tenantID, "bill_id", id, "method", req.Method, "amount", req.Amount)
+	if err := validatePaymentRequest(req); err != nil {
+		logging.UsecaseError("Bill.Pay", "invalid payment", "payment_invalid", err, "tenant_id", tenantID, "bill_id", id, "method", req.Method)
+		return nil, err
+	}
+	p, err := u.bills.Pay(tenantID, id, req)
+	if err != nil {
+		logging.UsecaseError("Bill.Pay", "repository error", "bill_payment_failed", err, "tenant_id", tenantID, "bill_id", id)
+		return nil, err
+	}
+	logging.UsecaseInfo("Bill.Pay", "bill payment recorded", "bill_payment_recorded", "tenant_id", tenantID, "bill_id", id, "payment_id", p.ID, "amount", p.Amount)
+	return p, nil
+}
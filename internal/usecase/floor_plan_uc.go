@@ -0,0 +1,143 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+
+	"qrmenu/internal/domain"
+	"qrmenu/internal/platform/logging"
+	"qrmenu/internal/repository"
+)
+
+// FloorPlanUC manages table areas and the positions of tables on each area's floor plan.
+type FloorPlanUC struct {
+	areas  repository.TableAreaRepository
+	tables repository.TableRepository
+}
+
+func NewFloorPlanUC(a repository.TableAreaRepository, t repository.TableRepository) *FloorPlanUC {
+	return &FloorPlanUC{areas: a, tables: t}
+}
+
+func (u *FloorPlanUC) ListAreas(tenantID string) ([]domain.TableArea, error) {
+	logging.UsecaseInfo("FloorPlan.ListAreas", "listing areas", "areas_list_requested", "tenant_id", tenantID)
+	xs, err := u.areas.List(tenantID)
+	if err != nil {
+		logging.UsecaseError("FloorPlan.ListAreas", "repository error", "areas_list_failed", err, "tenant_id", tenantID)
+		return nil, err
+	}
+	return xs, nil
+}
+
+func (u *FloorPlanUC) CreateArea(tenantID string, body map[string]any) (*domain.TableArea, error) {
+	logging.UsecaseInfo("FloorPlan.CreateArea", "creating area", "area_create_requested", "tenant_id", tenantID)
+	name, _ := body["name"].(string)
+	if name = strings.TrimSpace(name); name == "" {
+		err := fmt.Errorf("name is required")
+		logging.UsecaseError("FloorPlan.CreateArea", "invalid payload", "area_invalid", err, "tenant_id", tenantID)
+		return nil, err
+	}
+	fields, err := areaFields(body)
+	if err != nil {
+		logging.UsecaseError("FloorPlan.CreateArea", "invalid payload", "area_invalid", err, "tenant_id", tenantID)
+		return nil, err
+	}
+	a := &domain.TableArea{TenantID: tenantID, Name: name}
+	if v, ok := fields["sort"].(int); ok {
+		a.Sort = v
+	}
+	if v, ok := fields["plan_width"].(int); ok {
+		a.PlanWidth = v
+	}
+	if v, ok := fields["plan_height"].(int); ok {
+		a.PlanHeight = v
+	}
+	if err := u.areas.Create(a); err != nil {
+		logging.UsecaseError("FloorPlan.CreateArea", "repository error", "area_create_failed", err, "tenant_id", tenantID)
+		return nil, err
+	}
+	logging.UsecaseInfo("FloorPlan.CreateArea", "area created", "area_created", "tenant_id", tenantID, "area_id", a.ID)
+	return a, nil
+}
+
+func (u *FloorPlanUC) PatchArea(tenantID, id string, body map[string]any) (*domain.TableArea, error) {
+	logging.UsecaseInfo("FloorPlan.PatchArea", "patching area", "area_patch_requested", "tenant_id", tenantID, "area_id", id)
+	fields, err := areaFields(body)
+	if err != nil {
+		logging.UsecaseError("FloorPlan.PatchArea", "invalid payload", "area_invalid", err, "tenant_id", tenantID, "area_id", id)
+		return nil, err
+	}
+	if v, ok := body["name"].(string); ok && strings.TrimSpace(v) != "" {
+		fields["name"] = strings.TrimSpace(v)
+	}
+	if len(fields) == 0 {
+		return u.areas.Find(tenantID, id)
+	}
+	a, err := u.areas.Patch(tenantID, id, fields)
+	if err != nil {
+		logging.UsecaseError("FloorPlan.PatchArea", "repository error", "area_patch_failed", err, "tenant_id", tenantID, "area_id", id)
+		return nil, err
+	}
+	logging.UsecaseInfo("FloorPlan.PatchArea", "area patched", "area_patched", "tenant_id", tenantID, "area_id", id)
+	return a, nil
+}
+
+// DeleteArea removes an area; its tables are kept without an area.
+func (u *FloorPlanUC) DeleteArea(tenantID, id string) error {
+	logging.UsecaseInfo("FloorPlan.DeleteArea", "deleting area", "area_delete_requested", "tenant_id", tenantID, "area_id", id)
+	if err := u.areas.Delete(tenantID, id); err != nil {
+		logging.UsecaseError("FloorPlan.DeleteArea", "repository error", "area_delete_failed", err, "tenant_id", tenantID, "area_id", id)
+		return err
+	}
+	return nil
+}
+
+// Plan returns the tenant's areas with their tables, plus the tables without an area.
+func (u *FloorPlanUC) Plan(tenantID string) (*domain.FloorPlan, error) {
+	logging.UsecaseInfo("FloorPlan.Plan", "loading floor plan", "floor_plan_requested", "tenant_id", tenantID)
+	areas, err := u.areas.List(tenantID)
+	if err != nil {
+		logging.UsecaseError("FloorPlan.Plan", "repository error", "floor_plan_failed", err, "tenant_id", tenantID)
+		return nil, err
+	}
+	tables, err := u.tables.List(tenantID)
+	if err != nil {
+		logging.UsecaseError("FloorPlan.Plan", "repository error", "floor_plan_failed", err, "tenant_id", tenantID)
+		return nil, err
+	}
+	plan := domain.NewFloorPlan(areas, tables)
+	return &plan, nil
+}
+
+// Place moves tables on the floor plan in one go and returns the updated plan.
+func (u *FloorPlanUC) Place(tenantID string, placements []domain.TablePlacement) (*domain.FloorPlan, error) {
+	logging.UsecaseInfo("FloorPlan.Place", "placing tables", "floor_plan_update_requested", "tenant_id", tenantID, "tables", len(placements))
+	if len(placements) == 0 {
+		err := fmt.Errorf("tables must not be empty")
+		logging.UsecaseError("FloorPlan.Place", "invalid payload", "floor_plan_invalid", err, "tenant_id", tenantID)
+		return nil, err
+	}
+	if err := u.tables.Place(tenantID, placements); err != nil {
+		logging.UsecaseError("FloorPlan.Place", "repository error", "floor_plan_update_failed", err, "tenant_id", tenantID)
+		return nil, err
+	}
+	logging.UsecaseInfo("FloorPlan.Place", "tables placed", "floor_plan_updated", "tenant_id", tenantID, "tables", len(placements))
+	return u.Plan(tenantID)
+}
+
+// areaFields reads the optional numeric settings of an area body.
+func areaFields(body map[string]any) (map[string]any, error) {
+	fields := map[string]any{}
+	for _, key := range []string{"sort", "plan_width", "plan_height"} {
+		raw, ok := body[key]
+		if !ok {
+			continue
+		}
+		n, isNum := raw.(float64)
+		if !isNum || n != float64(int(n)) || (key != "sort" && n < 0) {
+			return nil, fmt.Errorf("%s must be a whole number", key)
+		}
+		fields[key] = int(n)
+	}
+	return fields, nil
+}
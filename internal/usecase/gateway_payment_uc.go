@@ -0,0 +1,123 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"qrmenu/internal/domain"
+	"qrmenu/internal/platform/logging"
+	"qrmenu/internal/platform/payment"
+	"qrmenu/internal/repository"
+)
+
+const gatewayTimeout = 10 * time.Second
+
+// GatewayPaymentUC lets guests pay their own orders through an online payment provider.
+type GatewayPaymentUC struct {
+	orders    repository.OrderRepository
+	payments  repository.PaymentRepository
+	providers map[string]payment.Provider
+	defaultPv string
+}
+
+// NewGatewayPaymentUC registers the available providers; the first one is used for new charges.
+func NewGatewayPaymentUC(o repository.OrderRepository, p repository.PaymentRepository, providers ...payment.Provider) *GatewayPaymentUC {
+	u := &GatewayPaymentUC{orders: o, payments: p, providers: map[string]payment.Provider{}}
+	for _, pv := range providers {
+		if u.defaultPv == "" {
+			u.defaultPv = pv.Name()
+		}
+		u.providers[pv.Name()] = pv
+	}
+	return u
+}
+
+// StartGuestPayment opens a charge for the outstanding balance of a guest's order.
+func (u *GatewayPaymentUC) StartGuestPayment(orderID, guestSession string, method domain.PaymentMethod) (*domain.PaymentIntent, error) {
+	logging.UsecaseInfo("GatewayPayment.StartGuestPayment", "starting payment", "gateway_payment_requested", "order_id", orderID, "method", method)
+	if method == "" {
+		method = domain.PaymentQRIS
+	}
+	if method != domain.PaymentQRIS && method != domain.PaymentCard {
+		logging.UsecaseError("GatewayPayment.StartGuestPayment", "method not supported online", "invalid_payment_method", domain.ErrInvalidPaymentMethod, "order_id", orderID, "method", method)
+		return nil, domain.ErrInvalidPaymentMethod
+	}
+	pv, ok := u.providers[u.defaultPv]
+	if !ok {
+		logging.UsecaseError("GatewayPayment.StartGuestPayment", "no provider configured", "provider_missing", domain.ErrUnknownPaymentProvider, "order_id", orderID)
+		return nil, domain.ErrUnknownPaymentProvider
+	}
+
+	ord, err := u.orders.FindForGuest(orderID, guestSession)
+	if err != nil {
+		logging.UsecaseError("GatewayPayment.StartGuestPayment", "order lookup failed", "order_lookup_failed", err, "order_id", orderID)
+		return nil, err
+	}
+	if ord.Status == domain.OrderCanceled {
+		logging.UsecaseError("GatewayPayment.StartGuestPayment", "order canceled", "order_not_payable", domain.ErrOrderNotPayable, "order_id", orderID)
+		return nil, domain.ErrOrderNotPayable
+	}
+	amount := ord.Outstanding()
+	if amount == 0 {
+		logging.UsecaseError("GatewayPayment.StartGuestPayment", "nothing to pay", "nothing_to_pay", domain.ErrNothingToPay, "order_id", orderID)
+		return nil, domain.ErrNothingToPay
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), gatewayTimeout)
+	defer cancel()
+	ch, err := pv.CreateCharge(ctx, payment.ChargeRequest{
+		OrderID:     ord.ID,
+		Amount:      amount,
+		Method:      string(method),
+		Description: "Order " + ord.ID,
+	})
+	if err != nil {
+		logging.UsecaseError("GatewayPayment.StartGuestPayment", "provider charge failed", "charge_failed", err, "order_id", orderID, "provider", pv.Name())
+		return nil, err
+	}
+
+	intent := &domain.PaymentIntent{
+		TenantID:    ord.TenantID,
+		OrderID:     ord.ID,
+		Provider:    pv.Name(),
+		ProviderRef: ch.ProviderRef,
+		Method:      method,
+		Amount:      amount,
+		Status:      domain.IntentPending,
+		CheckoutURL: ch.CheckoutURL,
+	}
+	if err := u.payments.CreateIntent(intent); err != nil {
+		logging.UsecaseError("GatewayPayment.StartGuestPayment", "repository error", "intent_create_failed", err, "order_id", orderID, "provider_ref", ch.ProviderRef)
+		return nil, err
+	}
+	logging.UsecaseInfo("GatewayPayment.StartGuestPayment", "payment started", "gateway_payment_started", "order_id", orderID, "intent_id", intent.ID, "provider", pv.Name(), "amount", amount)
+	return intent, nil
+}
+
+// HandleWebhook authenticates a provider callback and applies it to the matching intent.
+// Repeated callbacks for the same charge are accepted and ignored.
+func (u *GatewayPaymentUC) HandleWebhook(provider string, payload []byte, signature string) (*domain.PaymentIntent, error) {
+	logging.UsecaseInfo("GatewayPayment.HandleWebhook", "webhook received", "webhook_received", "provider", provider)
+	pv, ok := u.providers[provider]
+	if !ok {
+		logging.UsecaseError("GatewayPayment.HandleWebhook", "unknown provider", "provider_unknown", domain.ErrUnknownPaymentProvider, "provider", provider)
+		return nil, domain.ErrUnknownPaymentProvider
+	}
+	ev, err := pv.VerifyWebhook(payload, signature)
+	if err != nil {
+		if errors.Is(err, payment.ErrInvalidSignature) {
+			err = domain.ErrInvalidWebhookSignature
+		}
+		logging.UsecaseError("GatewayPayment.HandleWebhook", "webhook rejected", "webhook_rejected", err, "provider", provider)
+		return nil, err
+	}
+
+	intent, err := u.payments.SettleIntent(provider, ev.ProviderRef, domain.PaymentIntentStatus(ev.Status), ev.Amount)
+	if err != nil {
+		logging.UsecaseError("GatewayPayment.HandleWebhook", "repository error", "intent_settle_failed", err, "provider", provider, "provider_ref", ev.ProviderRef)
+		return nil, err
+	}
+	logging.UsecaseInfo("GatewayPayment.HandleWebhook", "webhook applied", "webhook_applied", "provider", provider, "intent_id", intent.ID, "status", intent.Status)
+	return intent, nil
+}
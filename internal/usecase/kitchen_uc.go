@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"fmt"
+
+	"qrmenu/internal/domain"
+	"qrmenu/internal/platform/logging"
+	"qrmenu/internal/repository"
+)
+
+// KitchenUC manages kitchen stations and the kitchen display (KDS) tickets.
+type KitchenUC struct {
+	kitchen repository.KitchenRepository
+	events  OrderEventBus
+}
+
+func NewKitchenUC(k repository.KitchenRepository, ev OrderEventBus) *KitchenUC {
+	return &KitchenUC{kitchen: k, events: ev}
+}
+
+// ===== Stations
+func (u *KitchenUC) ListStations(tenantID string) ([]domain.KitchenStation, error) {
+	logging.UsecaseInfo("Kitchen.ListStations", "listing stations", "stations_list_requested", "tenant_id", tenantID)
+	xs, err := u.kitchen.ListStations(tenantID)
+	if err != nil {
+		logging.UsecaseError("Kitchen.ListStations", "repository error", "stations_list_failed", err, "tenant_id", tenantID)
+		return nil, err
+	}
+	logging.UsecaseInfo("Kitchen.ListStations", "stations loaded", "stations_listed", "tenant_id", tenantID, "count", len(xs))
+	return xs, nil
+}
+
+func (u *KitchenUC) CreateStation(tenantID string, body map[string]any) (*domain.KitchenStation, error) {
+	logging.UsecaseInfo("Kitchen.CreateStation", "creating station", "station_create_requested", "tenant_id", tenantID)
+	name, _ := body["name"].(string)
+	if name == "" {
+		err := fmt.Errorf("name is required")
+		logging.UsecaseError("Kitchen.CreateStation", "invalid payload", "station_invalid", err, "tenant_id", tenantID)
+		return nil, err
+	}
+	st := &domain.KitchenStation{TenantID: tenantID, Name: name, IsActive: true}
+	if v, ok := body["sort"].(float64); ok {
+		st.Sort = int(v)
+	}
+	if v, ok := body["is_active"].(bool); ok {
+		st.IsActive = v
+	}
+	if err := u.kitchen.CreateStation(st); err != nil {
+		logging.UsecaseError("Kitchen.CreateStation", "repository error", "station_create_failed", err, "tenant_id", tenantID)
+		return nil, err
+	}
+	logging.UsecaseInfo("Kitchen.CreateStation", "station created", "station_created", "tenant_id", tenantID, "station_id", st.ID)
+	return st, nil
+}
+
+// PatchStation updates the name, sort and is_active fields; other keys are ignored.
+func (u *KitchenUC) PatchStation(tenantID, id string, body map[string]any) (*domain.KitchenStation, error) {
+	logging.UsecaseInfo("Kitchen.PatchStation", "patching station", "station_patch_requested", "tenant_id", tenantID, "station_id", id)
+	fields := map[string]any{}
+	if v, ok := body["name"].(string); ok && v != "" {
+		fields["name"] = v
+	}
+	if v, ok := body["sort"].(float64); ok {
+		fields["sort"] = int(v)
+	}
+	if v, ok := body["is_active"].(bool); ok {
+		fields["is_active"] = v
+	}
+	if len(fields) == 0 {
+		return u.kitchen.FindStation(tenantID, id)
+	}
+	st, err := u.kitchen.PatchStation(tenantID, id, fields)
+	if err != nil {
+		logging.UsecaseError("Kitchen.PatchStation", "repository error", "station_patch_failed", err, "tenant_id", tenantID, "station_id", id)
+		return nil, err
+	}
+	logging.UsecaseInfo("Kitchen.PatchStation", "station patched", "station_patched", "tenant_id", tenantID, "station_id", id)
+	return st, nil
+}
+
+// DeleteStation removes a station; its categories, items and tickets become unassigned.
+func (u *KitchenUC) DeleteStation(tenantID, id string) error {
+	logging.UsecaseInfo("Kitchen.DeleteStation", "deleting station", "station_delete_requested", "tenant_id", tenantID, "station_id", id)
+	if err := u.kitchen.DeleteStation(tenantID, id); err != nil {
+		logging.UsecaseError("Kitchen.DeleteStation", "repository error", "station_delete_failed", err, "tenant_id", tenantID, "station_id", id)
+		return err
+	}
+	logging.UsecaseInfo("Kitchen.DeleteStation", "station deleted", "station_deleted", "tenant_id", tenantID, "station_id", id)
+	return nil
+}
+
+// ===== Tickets
+func (u *KitchenUC) ListTickets(tenantID, stationID, status string) ([]domain.KitchenTicket, error) {
+	logging.UsecaseInfo("Kitchen.ListTickets", "listing tickets", "tickets_list_requested", "tenant_id", tenantID, "station_id", stationID, "status", status)
+	st := domain.TicketStatus(status)
+	if st != "" && st != domain.TicketOpen && st != domain.TicketBumped {
+		logging.UsecaseError("Kitchen.ListTickets", "unknown status", "invalid_ticket_status", domain.ErrInvalidTicketStatus, "tenant_id", tenantID, "status", status)
+		return nil, domain.ErrInvalidTicketStatus
+	}
+	xs, err := u.kitchen.ListTickets(tenantID, repository.TicketFilter{StationID: stationID, Status: st})
+	if err != nil {
+		logging.UsecaseError("Kitchen.ListTickets", "repository error", "tickets_list_failed", err, "tenant_id", tenantID, "station_id", stationID)
+		return nil, err
+	}
+	logging.UsecaseInfo("Kitchen.ListTickets", "tickets loaded", "tickets_listed", "tenant_id", tenantID, "station_id", stationID, "count", len(xs))
+	return xs, nil
+}
+
+// Bump marks a ticket as prepared.
+func (u *KitchenUC) Bump(tenantID, id, adminID string) (*domain.KitchenTicket, error) {
+	return u.setTicketStatus("Kitchen.Bump", tenantID, id, adminID, domain.TicketBumped)
+}
+
+// Recall reopens a bumped ticket.
+func (u *KitchenUC) Recall(tenantID, id, adminID string) (*domain.KitchenTicket, error) {
+	return u.setTicketStatus("Kitchen.Recall", tenantID, id, adminID, domain.TicketOpen)
+}
+
+func (u *KitchenUC) setTicketStatus(scope, tenantID, id, adminID string, status domain.TicketStatus) (*domain.KitchenTicket, error) {
+	logging.UsecaseInfo(scope, "updating ticket", "ticket_update_requested", "tenant_id", tenantID, "ticket_id", id, "status", status)
+	t, ord, err := u.kitchen.SetTicketStatus(tenantID, id, status, adminID)
+	if err != nil {
+		logging.UsecaseError(scope, "repository error", "ticket_update_failed", err, "tenant_id", tenantID, "ticket_id", id)
+		return nil, err
+	}
+	if ord != nil {
+		publishOrderEvent(u.events, domain.OrderEventStatusChanged, ord)
+	}
+	logging.UsecaseInfo(scope, "ticket updated", "ticket_updated", "tenant_id", tenantID, "ticket_id", id, "status", status, "order_id", t.OrderID)
+	return t, nil
+}
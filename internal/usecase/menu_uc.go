@@ -15,16 +15,18 @@ import (
 type MenuUC interface {
 	GetMenuByTenantCode(code string) (*domain.MenuResponse, error)
 	InvalidateTenantMenu(code string)
+	MenuChanged(tenantID string)
 }
 
 type menuUC struct {
-	query repository.MenuQuery
-	cache cache.Cache
-	ttl   time.Duration
+	query   repository.MenuQuery
+	tenants repository.TenantRepository
+	cache   cache.Cache
+	ttl     time.Duration
 }
 
-func NewMenuUC(q repository.MenuQuery, rc cache.Cache, ttl time.Duration) MenuUC {
-	return &menuUC{query: q, cache: rc, ttl: ttl}
+func NewMenuUC(q repository.MenuQuery, tenants repository.TenantRepository, rc cache.Cache, ttl time.Duration) MenuUC {
+	return &menuUC{query: q, tenants: tenants, cache: rc, ttl: ttl}
 }
 
 func (u *menuUC) GetMenuByTenantCode(code string) (*domain.MenuResponse, error) {
@@ -42,6 +44,7 @@ func (u *menuUC) GetMenuByTenantCode(code string) (*domain.MenuResponse, error)
 			var resp domain.MenuResponse
 			if err := json.Unmarshal([]byte(cached), &resp); err == nil {
 				logging.UsecaseInfo("Menu.GetMenuByTenantCode", "cache hit", "cache_hit", "tenant_code", code)
+				markAvailability(&resp)
 				return &resp, nil
 			}
 		}
@@ -63,6 +66,8 @@ func (u *menuUC) GetMenuByTenantCode(code string) (*domain.MenuResponse, error)
 		}
 	}
 
+	// Availability is marked after caching so a cached menu never carries stale flags.
+	markAvailability(menu)
 	logging.UsecaseInfo("Menu.GetMenuByTenantCode", "menu fetched", "menu_fetched", "tenant_code", code, "categories", len(menu.Categories), "items", len(menu.Items))
 	return menu, nil
 }
@@ -78,3 +83,27 @@ func (u *menuUC) InvalidateTenantMenu(code string) {
 	}
 	logging.UsecaseInfo("Menu.InvalidateTenantMenu", "cache invalidated", "cache_invalidated", "tenant_code", code)
 }
+
+// MenuChanged receives menu-change events raised by admin mutations. Admin requests
+// only carry the tenant ID, so the tenant code used in the cache key is resolved first.
+func (u *menuUC) MenuChanged(tenantID string) {
+	if tenantID == "" || u.cache == nil {
+		return
+	}
+	t, err := u.tenants.FindByID(tenantID)
+	if err != nil {
+		logging.UsecaseError("Menu.MenuChanged", "tenant lookup failed", "tenant_lookup_failed", err, "tenant_id", tenantID)
+		return
+	}
+	u.InvalidateTenantMenu(t.Code)
+}
+
+// markAvailability flags the menu's available entries at the current time in the
+// tenant's timezone, falling back to the server's zone when it cannot be loaded.
+func markAvailability(menu *domain.MenuResponse) {
+	loc, err := domain.LoadTimezone(menu.Timezone)
+	if err != nil {
+		logging.UsecaseError("Menu.MarkAvailability", "unknown tenant timezone, using the server time zone", "timezone_invalid", err, "tenant_code", menu.Tenant, "timezone", menu.Timezone)
+	}
+	menu.MarkAvailability(time.Now().In(loc))
+}
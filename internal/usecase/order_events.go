@@ -0,0 +1,37 @@
+package usecase
+
+import (
+	"context"
+
+	"qrmenu/internal/domain"
+	"qrmenu/internal/platform/logging"
+)
+
+// OrderEventBus publishes order events and serves live, resumable feeds of them.
+type OrderEventBus interface {
+	Publish(ctx context.Context, ev domain.OrderEvent) (domain.OrderEvent, error)
+	Subscribe(ctx context.Context, tenantID, lastID string) (<-chan domain.OrderEvent, error)
+	SubscribeOrder(ctx context.Context, tenantID, orderID, lastID string) (<-chan domain.OrderEvent, error)
+}
+
+// publishOrderEvent announces a committed order change. Failures are logged only:
+// the change itself already succeeded and feeds can be resynced from the order list.
+func publishOrderEvent(bus OrderEventBus, t domain.OrderEventType, o *domain.Order) {
+	if o == nil {
+		return
+	}
+	publishEvent(bus, domain.NewOrderEvent(t, o))
+}
+
+// publishEvent publishes a prepared event, logging failures like publishOrderEvent.
+func publishEvent(bus OrderEventBus, ev domain.OrderEvent) {
+	if bus == nil {
+		return
+	}
+	out, err := bus.Publish(context.Background(), ev)
+	if err != nil {
+		logging.UsecaseError("OrderEvents.Publish", "publish failed", "order_event_publish_failed", err, "tenant_id", ev.TenantID, "order_id", ev.OrderID, "type", ev.Type)
+		return
+	}
+	logging.UsecaseInfo("OrderEvents.Publish", "event published", "order_event_published", "tenant_id", ev.TenantID, "order_id", ev.OrderID, "type", ev.Type, "event_id", out.ID)
+}
@@ -1,26 +1,205 @@
 package usecase
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
 	"qrmenu/internal/domain"
+	"qrmenu/internal/platform/cache"
 	"qrmenu/internal/platform/logging"
 	"qrmenu/internal/repository"
 )
 
+const (
+	idempotencyTTL = 24 * time.Hour
+	// idempotencyLockTTL bounds how long a claimed key blocks retries when the
+	// request never completes (e.g. the process dies before storing the result).
+	idempotencyLockTTL   = 30 * time.Second
+	maxIdempotencyKeyLen = 255
+)
+
 type OrderUC struct {
-	repo repository.OrderRepository
+	repo   repository.OrderRepository
+	events OrderEventBus
+	cache  cache.Cache
+}
+
+func NewOrderUC(r repository.OrderRepository, ev OrderEventBus, rc cache.Cache) *OrderUC {
+	return &OrderUC{repo: r, events: ev, cache: rc}
+}
+
+// idempotencyRecord is stored under an Idempotency-Key while the order is being
+// created (OrderID empty, for idempotencyLockTTL) and afterwards to answer
+// replays (for idempotencyTTL).
+type idempotencyRecord struct {
+	Hash    string `json:"hash"`
+	OrderID string `json:"order_id,omitempty"`
+	Status  string `json:"status,omitempty"`
 }
 
-func NewOrderUC(r repository.OrderRepository) *OrderUC { return &OrderUC{repo: r} }
+// CreateGuestOrder forwards the domain payload to the repository and announces
+// the new order on the tenant's live feed.
+//
+// With an idempotency key (scoped to tenant and guest session) the first request
+// creates the order and later requests with the same payload get the original
+// result back with replayed=true; the same key with a different payload, or
+// while the first request is still running, is rejected.
+func (u *OrderUC) CreateGuestOrder(req domain.OrderCreateRequest, idempotencyKey string) (orderID, status string, replayed bool, err error) {
+	logging.UsecaseInfo("Order.CreateGuestOrder", "creating guest order", "order_create_requested", "tenant", req.Tenant, "table_token", req.TableToken, "items", len(req.Items), "idempotent", idempotencyKey != "")
+	if idempotencyKey == "" || u.cache == nil {
+		id, st, err := u.createGuestOrder(req)
+		return id, st, false, err
+	}
+	if len(idempotencyKey) > maxIdempotencyKeyLen {
+		logging.UsecaseError("Order.CreateGuestOrder", "idempotency key too long", "invalid_idempotency_key", domain.ErrInvalidIdempotencyKey, "tenant", req.Tenant)
+		return "", "", false, domain.ErrInvalidIdempotencyKey
+	}
+
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return "", "", false, err
+	}
+	sum := sha256.Sum256(payload)
+	rec := idempotencyRecord{Hash: hex.EncodeToString(sum[:])}
+	key := cache.KeyOrderIdempotency(req.Tenant, req.GuestSession, idempotencyKey)
+
+	raw, _ := json.Marshal(rec)
+	claimed, err := u.cache.SetNX(key, string(raw), idempotencyLockTTL)
+	if err != nil {
+		logging.UsecaseError("Order.CreateGuestOrder", "idempotency store unavailable", "idempotency_store_failed", err, "tenant", req.Tenant)
+		return "", "", false, err
+	}
+	if !claimed {
+		return u.replayGuestOrder(key, rec.Hash, req)
+	}
 
-// CreateGuestOrder forwards the domain payload straight to the repository.
-// Return values: orderID, status(string), error.
-func (u *OrderUC) CreateGuestOrder(req domain.OrderCreateRequest) (string, string, error) {
-	logging.UsecaseInfo("Order.CreateGuestOrder", "creating guest order", "order_create_requested", "tenant", req.Tenant, "table_token", req.TableToken, "items", len(req.Items))
-	id, st, err := u.repo.CreateGuestOrder(req)
+	id, st, err := u.createGuestOrder(req)
+	if err != nil {
+		// Release the key so the guest can retry once the problem is fixed.
+		if derr := u.cache.Del(key); derr != nil {
+			logging.UsecaseError("Order.CreateGuestOrder", "idempotency key release failed", "idempotency_release_failed", derr, "tenant", req.Tenant)
+		}
+		return "", "", false, err
+	}
+	rec.OrderID, rec.Status = id, st
+	raw, _ = json.Marshal(rec)
+	if err := u.cache.Set(key, string(raw), idempotencyTTL); err != nil {
+		// Without a stored result the in-progress claim would reject every retry;
+		// drop it so the key behaves as if it had never been used.
+		logging.UsecaseError("Order.CreateGuestOrder", "idempotency result not stored", "idempotency_store_failed", err, "tenant", req.Tenant, "order_id", id)
+		if derr := u.cache.Del(key); derr != nil {
+			logging.UsecaseError("Order.CreateGuestOrder", "idempotency key release failed", "idempotency_release_failed", derr, "tenant", req.Tenant)
+		}
+	}
+	return id, st, false, nil
+}
+
+// replayGuestOrder answers a request whose idempotency key was already used.
+func (u *OrderUC) replayGuestOrder(key, hash string, req domain.OrderCreateRequest) (string, string, bool, error) {
+	stored, err := u.cache.Get(key)
+	if err != nil {
+		logging.UsecaseError("Order.CreateGuestOrder", "idempotency store unavailable", "idempotency_store_failed", err, "tenant", req.Tenant)
+		return "", "", false, err
+	}
+	var prev idempotencyRecord
+	if stored == "" || json.Unmarshal([]byte(stored), &prev) != nil {
+		// Expired or released between SETNX and GET: treat as in progress so the client retries.
+		return "", "", false, domain.ErrIdempotencyInProgress
+	}
+	if prev.Hash != hash {
+		logging.UsecaseError("Order.CreateGuestOrder", "idempotency key reused with different payload", "idempotency_key_reused", domain.ErrIdempotencyKeyReused, "tenant", req.Tenant)
+		return "", "", false, domain.ErrIdempotencyKeyReused
+	}
+	if prev.OrderID == "" {
+		logging.UsecaseError("Order.CreateGuestOrder", "original request still running", "idempotency_in_progress", domain.ErrIdempotencyInProgress, "tenant", req.Tenant)
+		return "", "", false, domain.ErrIdempotencyInProgress
+	}
+	logging.UsecaseInfo("Order.CreateGuestOrder", "replaying guest order", "order_replayed", "tenant", req.Tenant, "order_id", prev.OrderID)
+	return prev.OrderID, prev.Status, true, nil
+}
+
+func (u *OrderUC) createGuestOrder(req domain.OrderCreateRequest) (string, string, error) {
+	ord, err := u.repo.CreateGuestOrder(req)
 	if err != nil {
 		logging.UsecaseError("Order.CreateGuestOrder", "repository error", "order_create_failed", err, "tenant", req.Tenant, "table_token", req.TableToken)
 		return "", "", err
 	}
-	logging.UsecaseInfo("Order.CreateGuestOrder", "guest order created", "order_created", "tenant", req.Tenant, "order_id", id, "status", st)
-	return id, string(st), nil
+	publishOrderEvent(u.events, domain.OrderEventCreated, ord)
+	logging.UsecaseInfo("Order.CreateGuestOrder", "guest order created", "order_created", "tenant", req.Tenant, "order_id", ord.ID, "status", ord.Status)
+	return ord.ID, string(ord.Status), nil
+}
+
+// GetForGuest returns an order to the guest session that placed it.
+func (u *OrderUC) GetForGuest(id, guestSession string) (*domain.Order, error) {
+	logging.UsecaseInfo("Order.GetForGuest", "loading order", "order_track_requested", "order_id", id)
+	ord, err := u.repo.FindForGuest(id, guestSession)
+	if err != nil {
+		logging.UsecaseError("Order.GetForGuest", "repository error", "order_track_failed", err, "order_id", id)
+		return nil, err
+	}
+	logging.UsecaseInfo("Order.GetForGuest", "order loaded", "order_tracked", "order_id", id, "status", ord.Status)
+	return ord, nil
+}
+
+// AddItemsForGuest adds lines to the guest's waiting order within the tenant's change window.
+func (u *OrderUC) AddItemsForGuest(id, guestSession string, items []domain.OrderItemCreate) (*domain.Order, error) {
+	logging.UsecaseInfo("Order.AddItemsForGuest", "adding items", "order_amend_requested", "order_id", id, "items", len(items))
+	if len(items) == 0 {
+		logging.UsecaseError("Order.AddItemsForGuest", "no items", "order_amend_invalid", domain.ErrInvalidQuantity, "order_id", id)
+		return nil, domain.ErrInvalidQuantity
+	}
+	ord, err := u.repo.AddItemsForGuest(id, guestSession, items)
+	if err != nil {
+		logging.UsecaseError("Order.AddItemsForGuest", "repository error", "order_amend_failed", err, "order_id", id)
+		return nil, err
+	}
+	publishOrderEvent(u.events, domain.OrderEventAmended, ord)
+	logging.UsecaseInfo("Order.AddItemsForGuest", "items added", "order_amended", "order_id", id, "total", ord.Total)
+	return ord, nil
+}
+
+// SetItemQtyForGuest changes (or with qty 0 removes) one line of the guest's waiting order.
+func (u *OrderUC) SetItemQtyForGuest(id, guestSession, itemID string, qty int) (*domain.Order, error) {
+	logging.UsecaseInfo("Order.SetItemQtyForGuest", "changing quantity", "order_amend_requested", "order_id", id, "order_item_id", itemID, "qty", qty)
+	ord, err := u.repo.SetItemQtyForGuest(id, guestSession, itemID, qty)
+	if err != nil {
+		logging.UsecaseError("Order.SetItemQtyForGuest", "repository error", "order_amend_failed", err, "order_id", id, "order_item_id", itemID)
+		return nil, err
+	}
+	publishOrderEvent(u.events, domain.OrderEventAmended, ord)
+	logging.UsecaseInfo("Order.SetItemQtyForGuest", "quantity changed", "order_amended", "order_id", id, "total", ord.Total)
+	return ord, nil
+}
+
+// CancelForGuest cancels the guest's waiting order within the tenant's change window.
+func (u *OrderUC) CancelForGuest(id, guestSession, reason string) (*domain.Order, error) {
+	logging.UsecaseInfo("Order.CancelForGuest", "canceling order", "order_cancel_requested", "order_id", id)
+	ord, err := u.repo.CancelForGuest(id, guestSession, reason)
+	if err != nil {
+		logging.UsecaseError("Order.CancelForGuest", "repository error", "order_cancel_failed", err, "order_id", id)
+		return nil, err
+	}
+	publishOrderEvent(u.events, domain.OrderEventStatusChanged, ord)
+	logging.UsecaseInfo("Order.CancelForGuest", "order canceled", "order_canceled", "order_id", id)
+	return ord, nil
+}
+
+// StreamForGuest follows one order's events on its own feed until ctx is canceled.
+// The guest session must match the one that placed the order.
+func (u *OrderUC) StreamForGuest(ctx context.Context, id, guestSession, lastEventID string) (<-chan domain.OrderEvent, error) {
+	logging.UsecaseInfo("Order.StreamForGuest", "opening order feed", "order_stream_requested", "order_id", id, "last_event_id", lastEventID)
+	ord, err := u.repo.FindForGuest(id, guestSession)
+	if err != nil {
+		logging.UsecaseError("Order.StreamForGuest", "repository error", "order_track_failed", err, "order_id", id)
+		return nil, err
+	}
+	feed, err := u.events.SubscribeOrder(ctx, ord.TenantID, ord.ID, lastEventID)
+	if err != nil {
+		logging.UsecaseError("Order.StreamForGuest", "subscribe failed", "order_stream_failed", err, "order_id", id)
+		return nil, err
+	}
+	return feed, nil
 }
@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"qrmenu/internal/domain"
+	"qrmenu/internal/platform/logging"
+	"qrmenu/internal/repository"
+)
+
+type PaymentUC struct {
+	payments repository.PaymentRepository
+}
+
+func NewPaymentUC(r repository.PaymentRepository) *PaymentUC { return &PaymentUC{payments: r} }
+
+// PayOrder records a cashier payment for a single order.
+func (u *PaymentUC) PayOrder(tenantID, orderID string, req domain.PaymentRequest) (*domain.Payment, error) {
+	logging.UsecaseInfo("Payment.PayOrder", "recording payment", "payment_requested", "tenant_id", tenantID, "order_id", orderID, "method", req.Method, "amount", req.Amount)
+	if err := validatePaymentRequest(req); err != nil {
+		logging.UsecaseError("Payment.PayOrder", "invalid payment", "payment_invalid", err, "tenant_id", tenantID, "order_id", orderID, "method", req.Method)
+		return nil, err
+	}
+	p, err := u.payments.Record(tenantID, "", []string{orderID}, req)
+	if err != nil {
+		logging.UsecaseError("Payment.PayOrder", "repository error", "payment_failed", err, "tenant_id", tenantID, "order_id", orderID)
+		return nil, err
+	}
+	logging.UsecaseInfo("Payment.PayOrder", "payment recorded", "payment_recorded", "tenant_id", tenantID, "order_id", orderID, "payment_id", p.ID, "amount", p.Amount)
+	return p, nil
+}
+
+// PayTable records one payment across several orders of a table. When orderIDs is
+// empty every order of the table with an outstanding balance is included.
+func (u *PaymentUC) PayTable(tenantID, tableID string, orderIDs []string, req domain.PaymentRequest) (*domain.Payment, error) {
+	logging.UsecaseInfo("Payment.PayTable", "recording table payment", "table_payment_requested", "tenant_id", tenantID, "table_id", tableID, "method", req.Method, "amount", req.Amount)
+	if err := validatePaymentRequest(req); err != nil {
+		logging.UsecaseError("Payment.PayTable", "invalid payment", "payment_invalid", err, "tenant_id", tenantID, "table_id", tableID, "method", req.Method)
+		return nil, err
+	}
+	if len(orderIDs) == 0 {
+		ids, err := u.payments.OutstandingOrderIDsByTable(tenantID, tableID)
+		if err != nil {
+			logging.UsecaseError("Payment.PayTable", "repository error", "outstanding_orders_failed", err, "tenant_id", tenantID, "table_id", tableID)
+			return nil, err
+		}
+		if len(ids) == 0 {
+			logging.UsecaseError("Payment.PayTable", "nothing to pay", "nothing_to_pay", domain.ErrNothingToPay, "tenant_id", tenantID, "table_id", tableID)
+			return nil, domain.ErrNothingToPay
+		}
+		orderIDs = ids
+	}
+	p, err := u.payments.Record(tenantID, tableID, orderIDs, req)
+	if err != nil {
+		logging.UsecaseError("Payment.PayTable", "repository error", "payment_failed", err, "tenant_id", tenantID, "table_id", tableID)
+		return nil, err
+	}
+	logging.UsecaseInfo("Payment.PayTable", "table payment recorded", "table_payment_recorded", "tenant_id", tenantID, "table_id", tableID, "payment_id", p.ID, "amount", p.Amount, "orders", len(p.Allocations))
+	return p, nil
+}
+
+func (u *PaymentUC) ListOrderPayments(tenantID, orderID string) ([]domain.Payment, error) {
+	logging.UsecaseInfo("Payment.ListOrderPayments", "listing payments", "payments_list_requested", "tenant_id", tenantID, "order_id", orderID)
+	xs, err := u.payments.ListByOrder(tenantID, orderID)
+	if err != nil {
+		logging.UsecaseError("Payment.ListOrderPayments", "repository error", "payments_list_failed", err, "tenant_id", tenantID, "order_id", orderID)
+		return nil, err
+	}
+	logging.UsecaseInfo("Payment.ListOrderPayments", "payments loaded", "payments_listed", "tenant_id", tenantID, "order_id", orderID, "count", len(xs))
+	return xs, nil
+}
+
+func validatePaymentRequest(req domain.PaymentRequest) error {
+	if !req.Method.Valid() {
+		return domain.ErrInvalidPaymentMethod
+	}
+	if req.Amount < 0 || req.Tendered < 0 {
+		return domain.ErrInvalidPaymentAmount
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"qrmenu/internal/domain"
+	"qrmenu/internal/platform/cache"
+	"qrmenu/internal/platform/logging"
+	"qrmenu/internal/repository"
+)
+
+// serviceRequestCooldown is how long a table must wait before sending the same
+// kind of request again.
+const serviceRequestCooldown = 60 * time.Second
+
+// maxServiceNoteLen caps the guest's free-text note, in characters.
+const maxServiceNoteLen = 500
+
+// ServiceRequestUC handles guest calls for staff and their admin follow-up.
+type ServiceRequestUC struct {
+	tables   repository.TableRepository
+	requests repository.ServiceRequestRepository
+	cache    cache.Cache
+}
+
+func NewServiceRequestUC(t repository.TableRepository, r repository.ServiceRequestRepository, rc cache.Cache) *ServiceRequestUC {
+	return &ServiceRequestUC{tables: t, requests: r, cache: rc}
+}
+
+// Create records a service request for the table behind token. A table can send
+// each request type once per cooldown; repeats are rejected as throttled.
+func (u *ServiceRequestUC) Create(token string, typ domain.ServiceRequestType, note, guestSession string) (*domain.ServiceRequest, error) {
+	logging.UsecaseInfo("ServiceRequest.Create", "creating service request", "service_request_requested", "token", token, "type", typ)
+	if !typ.Valid() {
+		logging.UsecaseError("ServiceRequest.Create", "invalid type", "service_request_invalid", domain.ErrInvalidServiceRequest, "token", token, "type", typ)
+		return nil, domain.ErrInvalidServiceRequest
+	}
+	if note = strings.TrimSpace(note); utf8.RuneCountInString(note) > maxServiceNoteLen {
+		logging.UsecaseError("ServiceRequest.Create", "note too long", "service_request_invalid", domain.ErrServiceNoteTooLong, "token", token)
+		return nil, domain.ErrServiceNoteTooLong
+	}
+	_, table, err := u.tables.ResolveByToken(token)
+	if err != nil {
+		logging.UsecaseError("ServiceRequest.Create", "table resolve failed", "table_resolve_failed", err, "token", token)
+		return nil, err
+	}
+
+	throttleKey := cache.KeyServiceRequestThrottle(table.ID, string(typ))
+	claimed := false
+	if u.cache != nil {
+		ok, err := u.cache.SetNX(throttleKey, "1", serviceRequestCooldown)
+		if err != nil {
+			// Throttling is best effort; a cache outage must not stop guests from calling staff.
+			logging.UsecaseError("ServiceRequest.Create", "throttle check failed", "throttle_check_failed", err, "table_id", table.ID)
+		} else if !ok {
+			logging.UsecaseError("ServiceRequest.Create", "request throttled", "service_request_throttled", domain.ErrServiceRequestThrottled, "table_id", table.ID, "type", typ)
+			return nil, domain.ErrServiceRequestThrottled
+		}
+		claimed = ok
+	}
+
+	sr := &domain.ServiceRequest{
+		TenantID:     table.TenantID,
+		TableID:      table.ID,
+		Type:         typ,
+		GuestSession: guestSession,
+		Status:       domain.ServiceOpen,
+	}
+	if note != "" {
+		sr.Note = &note
+	}
+	if err := u.requests.Create(sr); err != nil {
+		logging.UsecaseError("ServiceRequest.Create", "repository error", "service_request_create_failed", err, "table_id", table.ID)
+		// Nothing was recorded, so the guest must be able to try again right away.
+		if claimed {
+			if derr := u.cache.Del(throttleKey); derr != nil {
+				logging.UsecaseError("ServiceRequest.Create", "throttle release failed", "throttle_release_failed", derr, "table_id", table.ID)
+			}
+		}
+		return nil, err
+	}
+	logging.UsecaseInfo("ServiceRequest.Create", "service request created", "service_request_created", "tenant_id", sr.TenantID, "table_id", sr.TableID, "request_id", sr.ID)
+	return sr, nil
+}
+
+// List returns the tenant's requests filtered by status: a single status, "all",
+// or by default the ones still waiting for staff (open and acknowledged).
+func (u *ServiceRequestUC) List(tenantID, status string) ([]domain.ServiceRequest, error) {
+	logging.UsecaseInfo("ServiceRequest.List", "listing service requests", "service_requests_list_requested", "tenant_id", tenantID, "status", status)
+	var statuses []domain.ServiceRequestStatus
+	switch s := domain.ServiceRequestStatus(status); s {
+	case "":
+		statuses = []domain.ServiceRequestStatus{domain.ServiceOpen, domain.ServiceAcknowledged}
+	case "all":
+	case domain.ServiceOpen, domain.ServiceAcknowledged, domain.ServiceResolved:
+		statuses = []domain.ServiceRequestStatus{s}
+	default:
+		err := fmt.Errorf("unknown service request status %q", status)
+		logging.UsecaseError("ServiceRequest.List", "invalid status", "service_requests_list_invalid", err, "tenant_id", tenantID, "status", status)
+		return nil, err
+	}
+	xs, err := u.requests.List(tenantID, statuses)
+	if err != nil {
+		logging.UsecaseError("ServiceRequest.List", "repository error", "service_requests_list_failed", err, "tenant_id", tenantID)
+		return nil, err
+	}
+	return xs, nil
+}
+
+func (u *ServiceRequestUC) Acknowledge(tenantID, id, adminID string) (*domain.ServiceRequest, error) {
+	return u.setStatus("ServiceRequest.Acknowledge", tenantID, id, domain.ServiceAcknowledged, adminID)
+}
+
+func (u *ServiceRequestUC) Resolve(tenantID, id, adminID string) (*domain.ServiceRequest, error) {
+	return u.setStatus("ServiceRequest.Resolve", tenantID, id, domain.ServiceResolved, adminID)
+}
+
+func (u *ServiceRequestUC) setStatus(scope, tenantID, id string, to domain.ServiceRequestStatus, adminID string) (*domain.ServiceRequest, error) {
+	logging.UsecaseInfo(scope, "updating service request", "service_request_update_requested", "tenant_id", tenantID, "request_id", id, "status", to)
+	sr, err := u.requests.SetStatus(tenantID, id, to, adminID)
+	if err != nil {
+		logging.UsecaseError(scope, "repository error", "service_request_update_failed", err, "tenant_id", tenantID, "request_id", id)
+		return nil, err
+	}
+	return sr, nil
+}
@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"fmt"
+
+	"qrmenu/internal/domain"
+	"qrmenu/internal/platform/logging"
+	"qrmenu/internal/repository"
+)
+
+// TableSessionUC manages table sessions (open tabs) from the admin side.
+type TableSessionUC struct {
+	sessions repository.TableSessionRepository
+	events   OrderEventBus
+}
+
+func NewTableSessionUC(s repository.TableSessionRepository, ev OrderEventBus) *TableSessionUC {
+	return &TableSessionUC{sessions: s, events: ev}
+}
+
+func (u *TableSessionUC) CurrentTab(tenantID, tableID string) (*domain.TableTab, error) {
+	logging.UsecaseInfo("TableSession.CurrentTab", "loading current tab", "tab_requested", "tenant_id", tenantID, "table_id", tableID)
+	tab, err := u.sessions.Current(tenantID, tableID)
+	if err != nil {
+		logging.UsecaseError("TableSession.CurrentTab", "repository error", "tab_failed", err, "tenant_id", tenantID, "table_id", tableID)
+		return nil, err
+	}
+	logging.UsecaseInfo("TableSession.CurrentTab", "tab loaded", "tab_loaded", "tenant_id", tenantID, "session_id", tab.Session.ID, "outstanding", tab.Outstanding)
+	return tab, nil
+}
+
+func (u *TableSessionUC) SessionTab(tenantID, sessionID string) (*domain.TableTab, error) {
+	logging.UsecaseInfo("TableSession.SessionTab", "loading session tab", "tab_requested", "tenant_id", tenantID, "session_id", sessionID)
+	tab, err := u.sessions.Tab(tenantID, sessionID)
+	if err != nil {
+		logging.UsecaseError("TableSession.SessionTab", "repository error", "tab_failed", err, "tenant_id", tenantID, "session_id", sessionID)
+		return nil, err
+	}
+	logging.UsecaseInfo("TableSession.SessionTab", "tab loaded", "tab_loaded", "tenant_id", tenantID, "session_id", sessionID, "outstanding", tab.Outstanding)
+	return tab, nil
+}
+
+// RequestBill locks the table's session so no new guest orders join it.
+func (u *TableSessionUC) RequestBill(tenantID, tableID string) (*domain.TableSession, error) {
+	logging.UsecaseInfo("TableSession.RequestBill", "requesting bill", "bill_requested", "tenant_id", tenantID, "table_id", tableID)
+	s, err := u.sessions.RequestBill(tenantID, tableID)
+	if err != nil {
+		logging.UsecaseError("TableSession.RequestBill", "repository error", "bill_request_failed", err, "tenant_id", tenantID, "table_id", tableID)
+		return nil, err
+	}
+	return s, nil
+}
+
+// Reopen takes the table's session out of billing so guests can order again.
+func (u *TableSessionUC) Reopen(tenantID, tableID string) (*domain.TableSession, error) {
+	logging.UsecaseInfo("TableSession.Reopen", "reopening session", "session_reopen_requested", "tenant_id", tenantID, "table_id", tableID)
+	s, err := u.sessions.Reopen(tenantID, tableID)
+	if err != nil {
+		logging.UsecaseError("TableSession.Reopen", "repository error", "session_reopen_failed", err, "tenant_id", tenantID, "table_id", tableID)
+		return nil, err
+	}
+	return s, nil
+}
+
+// Close ends the table's session; force closes it despite an unpaid balance.
+func (u *TableSessionUC) Close(tenantID, tableID, adminID string, force bool) (*domain.TableSession, error) {
+	logging.UsecaseInfo("TableSession.Close", "closing session", "session_close_requested", "tenant_id", tenantID, "table_id", tableID, "force", force)
+	s, err := u.sessions.Close(tenantID, tableID, adminID, force)
+	if err != nil {
+		logging.UsecaseError("TableSession.Close", "repository error", "session_close_failed", err, "tenant_id", tenantID, "table_id", tableID)
+		return nil, err
+	}
+	return s, nil
+}
+
+// StartFresh closes whatever session the table has and opens a new one.
+func (u *TableSessionUC) StartFresh(tenantID, tableID, adminID string, force bool) (*domain.TableSession, error) {
+	logging.UsecaseInfo("TableSession.StartFresh", "starting fresh session", "session_start_requested", "tenant_id", tenantID, "table_id", tableID, "force", force)
+	s, err := u.sessions.Start(tenantID, tableID, adminID, force)
+	if err != nil {
+		logging.UsecaseError("TableSession.StartFresh", "repository error", "session_start_failed", err, "tenant_id", tenantID, "table_id", tableID)
+		return nil, err
+	}
+	return s, nil
+}
+
+// MoveOrders transfers orders to another table, e.g. when guests change tables.
+func (u *TableSessionUC) MoveOrders(tenantID string, orderIDs []string, toTableID, adminID, reason string) (*domain.TableTransfer, error) {
+	logging.UsecaseInfo("TableSession.MoveOrders", "moving orders", "orders_move_requested", "tenant_id", tenantID, "to_table_id", toTableID, "orders", len(orderIDs))
+	if len(orderIDs) == 0 {
+		err := fmt.Errorf("order_ids must not be empty")
+		logging.UsecaseError("TableSession.MoveOrders", "invalid payload", "orders_move_invalid", err, "tenant_id", tenantID)
+		return nil, err
+	}
+	res, err := u.sessions.MoveOrders(tenantID, orderIDs, toTableID, adminID, reason)
+	if err != nil {
+		logging.UsecaseError("TableSession.MoveOrders", "repository error", "orders_move_failed", err, "tenant_id", tenantID, "to_table_id", toTableID)
+		return nil, err
+	}
+	u.publishMoves(res)
+	return res, nil
+}
+
+// Merge moves every order of fromTableID's running tab onto toTableID's tab so
+// the combined party pays one bill.
+func (u *TableSessionUC) Merge(tenantID, fromTableID, toTableID, adminID, reason string) (*domain.TableTransfer, error) {
+	logging.UsecaseInfo("TableSession.Merge", "merging tables", "tables_merge_requested", "tenant_id", tenantID, "from_table_id", fromTableID, "to_table_id", toTableID)
+	if fromTableID == "" {
+		err := fmt.Errorf("from_table_id is required")
+		logging.UsecaseError("TableSession.Merge", "invalid payload", "tables_merge_invalid", err, "tenant_id", tenantID)
+		return nil, err
+	}
+	res, err := u.sessions.Merge(tenantID, fromTableID, toTableID, adminID, reason)
+	if err != nil {
+		logging.UsecaseError("TableSession.Merge", "repository error", "tables_merge_failed", err, "tenant_id", tenantID, "from_table_id", fromTableID, "to_table_id", toTableID)
+		return nil, err
+	}
+	u.publishMoves(res)
+	return res, nil
+}
+
+func (u *TableSessionUC) publishMoves(res *domain.TableTransfer) {
+	for i := range res.Moves {
+		ev := domain.NewOrderEvent(domain.OrderEventMoved, &res.Moves[i].Order)
+		ev.FromTableID = res.Moves[i].FromTableID
+		publishEvent(u.events, ev)
+	}
+}
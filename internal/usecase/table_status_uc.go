@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"qrmenu/internal/domain"
+	"qrmenu/internal/platform/logging"
+	"qrmenu/internal/repository"
+)
+
+// TableStatusUC derives table occupancy for staff and records their manual overrides.
+type TableStatusUC struct {
+	status repository.TableStatusRepository
+}
+
+func NewTableStatusUC(s repository.TableStatusRepository) *TableStatusUC {
+	return &TableStatusUC{status: s}
+}
+
+// List returns every active table with its status, open order count and running
+// total, optionally limited to one area.
+func (u *TableStatusUC) List(tenantID, areaID string) ([]domain.TableOccupancy, error) {
+	logging.UsecaseInfo("TableStatus.List", "listing table status", "table_status_list_requested", "tenant_id", tenantID, "area_id", areaID)
+	acts, err := u.status.Activities(tenantID, "")
+	if err != nil {
+		logging.UsecaseError("TableStatus.List", "repository error", "table_status_list_failed", err, "tenant_id", tenantID)
+		return nil, err
+	}
+	out := make([]domain.TableOccupancy, 0, len(acts))
+	for _, a := range acts {
+		if areaID != "" && (a.Table.AreaID == nil || *a.Table.AreaID != areaID) {
+			continue
+		}
+		out = append(out, domain.NewTableOccupancy(a))
+	}
+	logging.UsecaseInfo("TableStatus.List", "table status listed", "table_status_listed", "tenant_id", tenantID, "count", len(out))
+	return out, nil
+}
+
+func (u *TableStatusUC) Get(tenantID, tableID string) (*domain.TableOccupancy, error) {
+	acts, err := u.status.Activities(tenantID, tableID)
+	if err != nil {
+		logging.UsecaseError("TableStatus.Get", "repository error", "table_status_failed", err, "tenant_id", tenantID, "table_id", tableID)
+		return nil, err
+	}
+	occ := domain.NewTableOccupancy(acts[0])
+	return &occ, nil
+}
+
+// Override sets the status staff see for a table until its sitting or derived
+// status changes (e.g. "free" once a table that needs cleaning is cleaned).
+// Setting the derived status itself just drops the override.
+func (u *TableStatusUC) Override(tenantID, tableID, adminID string, status domain.TableStatus) (*domain.TableOccupancy, error) {
+	logging.UsecaseInfo("TableStatus.Override", "overriding table status", "table_status_override_requested", "tenant_id", tenantID, "table_id", tableID, "status", status)
+	if !status.Valid() {
+		logging.UsecaseError("TableStatus.Override", "unknown status", "invalid_table_status", domain.ErrInvalidTableStatus, "tenant_id", tenantID, "table_id", tableID, "status", status)
+		return nil, domain.ErrInvalidTableStatus
+	}
+	acts, err := u.status.Activities(tenantID, tableID)
+	if err != nil {
+		logging.UsecaseError("TableStatus.Override", "repository error", "table_status_override_failed", err, "tenant_id", tenantID, "table_id", tableID)
+		return nil, err
+	}
+	a := acts[0]
+	derived := domain.DeriveTableStatus(a)
+	if status == derived {
+		return u.ClearOverride(tenantID, tableID)
+	}
+	o := &domain.TableStatusOverride{TableID: tableID, TenantID: tenantID, Status: status, BaseStatus: derived}
+	if a.Session != nil {
+		o.SessionID = &a.Session.ID
+	}
+	if adminID != "" {
+		o.SetBy = &adminID
+	}
+	if err := u.status.SetOverride(o); err != nil {
+		logging.UsecaseError("TableStatus.Override", "repository error", "table_status_override_failed", err, "tenant_id", tenantID, "table_id", tableID)
+		return nil, err
+	}
+	a.Override = o
+	occ := domain.NewTableOccupancy(a)
+	logging.UsecaseInfo("TableStatus.Override", "table status overridden", "table_status_overridden", "tenant_id", tenantID, "table_id", tableID, "status", status, "derived", derived)
+	return &occ, nil
+}
+
+// ClearOverride drops a manual status so the derived one shows again.
+func (u *TableStatusUC) ClearOverride(tenantID, tableID string) (*domain.TableOccupancy, error) {
+	logging.UsecaseInfo("TableStatus.ClearOverride", "clearing table status override", "table_status_clear_requested", "tenant_id", tenantID, "table_id", tableID)
+	if err := u.status.ClearOverride(tenantID, tableID); err != nil {
+		logging.UsecaseError("TableStatus.ClearOverride", "repository error", "table_status_clear_failed", err, "tenant_id", tenantID, "table_id", tableID)
+		return nil, err
+	}
+	return u.Get(tenantID, tableID)
+}
@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"qrmenu/internal/domain"
+	"qrmenu/internal/platform/logging"
+	"qrmenu/internal/repository"
+)
+
+// maxAmendWindowSeconds caps the guest change window at one hour.
+const maxAmendWindowSeconds = 3600
+
+// TenantSettingsUC reads and updates the tenant-wide settings admins can change.
+type TenantSettingsUC struct {
+	tenants  repository.TenantRepository
+	notifier MenuChangeNotifier
+}
+
+func NewTenantSettingsUC(t repository.TenantRepository, n MenuChangeNotifier) *TenantSettingsUC {
+	return &TenantSettingsUC{tenants: t, notifier: n}
+}
+
+func (u *TenantSettingsUC) Get(tenantID string) (*domain.Tenant, error) {
+	logging.UsecaseInfo("TenantSettings.Get", "loading settings", "settings_requested", "tenant_id", tenantID)
+	t, err := u.tenants.FindByID(tenantID)
+	if err != nil {
+		logging.UsecaseError("TenantSettings.Get", "repository error", "settings_failed", err, "tenant_id", tenantID)
+		return nil, err
+	}
+	return t, nil
+}
+
+// Patch updates the settings present in body; unknown keys are ignored.
+func (u *TenantSettingsUC) Patch(tenantID string, body map[string]any) (*domain.Tenant, error) {
+	logging.UsecaseInfo("TenantSettings.Patch", "patching settings", "settings_patch_requested", "tenant_id", tenantID)
+	fields := map[string]any{}
+	if v, ok := body["amend_window_seconds"]; ok {
+		n, ok := v.(float64)
+		if !ok || n < 0 || n > maxAmendWindowSeconds || n != float64(int(n)) {
+			err := fmt.Errorf("%w: amend_window_seconds must be a whole number between 0 and %d", domain.ErrInvalidSettings, maxAmendWindowSeconds)
+			logging.UsecaseError("TenantSettings.Patch", "invalid payload", "settings_invalid", err, "tenant_id", tenantID)
+			return nil, err
+		}
+		fields["amend_window_seconds"] = int(n)
+	}
+	if v, ok := body["guest_url_template"]; ok {
+		tpl, ok := v.(string)
+		if !ok {
+			err := fmt.Errorf("%w: guest_url_template must be a string", domain.ErrInvalidSettings)
+			logging.UsecaseError("TenantSettings.Patch", "invalid payload", "settings_invalid", err, "tenant_id", tenantID)
+			return nil, err
+		}
+		// An empty template falls back to the server default.
+		if tpl = strings.TrimSpace(tpl); tpl != "" {
+			if err := domain.ValidateGuestURLTemplate(tpl); err != nil {
+				err = fmt.Errorf("%w: %v", domain.ErrInvalidSettings, err)
+				logging.UsecaseError("TenantSettings.Patch", "invalid payload", "settings_invalid", err, "tenant_id", tenantID)
+				return nil, err
+			}
+		}
+		fields["guest_url_template"] = tpl
+	}
+	if v, ok := body["timezone"]; ok {
+		tz, ok := v.(string)
+		if !ok {
+			err := fmt.Errorf("%w: timezone must be a string", domain.ErrInvalidSettings)
+			logging.UsecaseError("TenantSettings.Patch", "invalid payload", "settings_invalid", err, "tenant_id", tenantID)
+			return nil, err
+		}
+		// An empty timezone falls back to the server's.
+		if tz = strings.TrimSpace(tz); tz != "" {
+			if _, err := time.LoadLocation(tz); err != nil {
+				err = fmt.Errorf("%w: timezone must be an IANA time zone name such as Asia/Jakarta", domain.ErrInvalidSettings)
+				logging.UsecaseError("TenantSettings.Patch", "invalid payload", "settings_invalid", err, "tenant_id", tenantID)
+				return nil, err
+			}
+		}
+		fields["timezone"] = tz
+	}
+	if len(fields) == 0 {
+		return u.tenants.FindByID(tenantID)
+	}
+	t, err := u.tenants.UpdateSettings(tenantID, fields)
+	if err != nil {
+		logging.UsecaseError("TenantSettings.Patch", "repository error", "settings_patch_failed", err, "tenant_id", tenantID)
+		return nil, err
+	}
+	// The timezone is published with the cached menu.
+	if _, ok := fields["timezone"]; ok && u.notifier != nil {
+		u.notifier.MenuChanged(tenantID)
+	}
+	logging.UsecaseInfo("TenantSettings.Patch", "settings patched", "settings_patched", "tenant_id", tenantID)
+	return t, nil
+}
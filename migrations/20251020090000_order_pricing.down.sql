@@ -0,0 +1,10 @@
+DROP TABLE IF EXISTS order_item_selections;
+
+ALTER TABLE order_items
+  DROP COLUMN IF EXISTS line_total,
+  DROP COLUMN IF EXISTS options_price;
+
+ALTER TABLE orders
+  DROP COLUMN IF EXISTS total,
+  DROP COLUMN IF EXISTS options_total,
+  DROP COLUMN IF EXISTS subtotal;
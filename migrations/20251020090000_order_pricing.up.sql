@@ -0,0 +1,31 @@
+ALTER TABLE orders
+  ADD COLUMN IF NOT EXISTS subtotal BIGINT NOT NULL DEFAULT 0,
+  ADD COLUMN IF NOT EXISTS options_total BIGINT NOT NULL DEFAULT 0,
+  ADD COLUMN IF NOT EXISTS total BIGINT NOT NULL DEFAULT 0;
+
+ALTER TABLE order_items
+  ADD COLUMN IF NOT EXISTS options_price BIGINT NOT NULL DEFAULT 0,
+  ADD COLUMN IF NOT EXISTS line_total BIGINT NOT NULL DEFAULT 0;
+
+-- Backfill existing rows: options were never priced, so the base price is the total.
+UPDATE order_items SET line_total = unit_price * qty WHERE line_total = 0;
+UPDATE orders o SET
+  subtotal = s.subtotal,
+  total = s.subtotal
+FROM (
+  SELECT order_id, SUM(unit_price * qty) AS subtotal
+  FROM order_items
+  GROUP BY order_id
+) s
+WHERE s.order_id = o.id;
+
+CREATE TABLE IF NOT EXISTS order_item_selections (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  order_item_id UUID NOT NULL REFERENCES order_items(id) ON DELETE CASCADE,
+  option_id UUID NOT NULL,
+  option_name TEXT NOT NULL,
+  value_id UUID NOT NULL,
+  value_label TEXT NOT NULL,
+  delta_price BIGINT NOT NULL DEFAULT 0
+);
+CREATE INDEX IF NOT EXISTS idx_order_item_selections_item ON order_item_selections(order_item_id);
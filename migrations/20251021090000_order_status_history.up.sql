@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS order_status_history (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+  tenant_id UUID NOT NULL REFERENCES tenants(id),
+  from_status TEXT NOT NULL,
+  to_status TEXT NOT NULL,
+  changed_by TEXT NULL,
+  reason TEXT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_order_status_history_order ON order_status_history(order_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_order_status_history_tenant ON order_status_history(tenant_id);
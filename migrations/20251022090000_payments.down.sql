@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS payment_allocations;
+DROP TABLE IF EXISTS payments;
+
+UPDATE orders SET paid_status = 'unpaid' WHERE paid_status = 'partially_paid';
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS orders_paid_status_check;
+ALTER TABLE orders ADD CONSTRAINT orders_paid_status_check
+  CHECK (paid_status IN ('unpaid','paid'));
+
+ALTER TABLE orders DROP COLUMN IF EXISTS paid_amount;
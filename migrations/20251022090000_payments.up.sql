@@ -0,0 +1,30 @@
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS paid_amount BIGINT NOT NULL DEFAULT 0;
+UPDATE orders SET paid_amount = total WHERE paid_status = 'paid';
+
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS orders_paid_status_check;
+ALTER TABLE orders ADD CONSTRAINT orders_paid_status_check
+  CHECK (paid_status IN ('unpaid','partially_paid','paid'));
+
+CREATE TABLE IF NOT EXISTS payments (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  tenant_id UUID NOT NULL REFERENCES tenants(id),
+  table_id UUID NOT NULL REFERENCES tables(id),
+  method TEXT NOT NULL CHECK (method IN ('cash','card','qris','transfer')),
+  amount BIGINT NOT NULL CHECK (amount > 0),
+  tendered BIGINT NOT NULL,
+  change BIGINT NOT NULL DEFAULT 0,
+  reference TEXT NULL,
+  received_by TEXT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_payments_tenant ON payments(tenant_id);
+CREATE INDEX IF NOT EXISTS idx_payments_table ON payments(table_id);
+
+CREATE TABLE IF NOT EXISTS payment_allocations (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  payment_id UUID NOT NULL REFERENCES payments(id) ON DELETE CASCADE,
+  order_id UUID NOT NULL REFERENCES orders(id),
+  amount BIGINT NOT NULL CHECK (amount > 0)
+);
+CREATE INDEX IF NOT EXISTS idx_payment_allocations_payment ON payment_allocations(payment_id);
+CREATE INDEX IF NOT EXISTS idx_payment_allocations_order ON payment_allocations(order_id);
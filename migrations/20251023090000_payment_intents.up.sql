@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS payment_intents (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  tenant_id UUID NOT NULL REFERENCES tenants(id),
+  order_id UUID NOT NULL REFERENCES orders(id),
+  provider TEXT NOT NULL,
+  provider_ref TEXT NOT NULL,
+  method TEXT NOT NULL CHECK (method IN ('cash','card','qris','transfer')),
+  amount BIGINT NOT NULL CHECK (amount > 0),
+  status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending','succeeded','failed','expired')),
+  checkout_url TEXT NOT NULL DEFAULT '',
+  payment_id UUID NULL REFERENCES payments(id),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_payment_intents_provider_ref ON payment_intents(provider, provider_ref);
+CREATE INDEX IF NOT EXISTS idx_payment_intents_order ON payment_intents(order_id);
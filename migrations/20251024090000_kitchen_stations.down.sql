@@ -0,0 +1,5 @@
+ALTER TABLE order_items DROP COLUMN IF EXISTS ticket_id;
+DROP TABLE IF EXISTS kitchen_tickets;
+ALTER TABLE items DROP COLUMN IF EXISTS station_id;
+ALTER TABLE categories DROP COLUMN IF EXISTS station_id;
+DROP TABLE IF EXISTS kitchen_stations;
@@ -0,0 +1,29 @@
+CREATE TABLE IF NOT EXISTS kitchen_stations (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+  name TEXT NOT NULL,
+  sort INT NOT NULL DEFAULT 0,
+  is_active BOOLEAN NOT NULL DEFAULT TRUE,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_kitchen_stations_tenant ON kitchen_stations(tenant_id);
+
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS station_id UUID NULL REFERENCES kitchen_stations(id) ON DELETE SET NULL;
+ALTER TABLE items ADD COLUMN IF NOT EXISTS station_id UUID NULL REFERENCES kitchen_stations(id) ON DELETE SET NULL;
+
+CREATE TABLE IF NOT EXISTS kitchen_tickets (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  tenant_id UUID NOT NULL REFERENCES tenants(id),
+  order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+  table_id UUID NOT NULL REFERENCES tables(id),
+  station_id UUID NULL REFERENCES kitchen_stations(id) ON DELETE SET NULL,
+  status TEXT NOT NULL DEFAULT 'open' CHECK (status IN ('open','bumped')),
+  bumped_at TIMESTAMPTZ NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_kitchen_tickets_tenant_status ON kitchen_tickets(tenant_id, status);
+CREATE INDEX IF NOT EXISTS idx_kitchen_tickets_order ON kitchen_tickets(order_id);
+CREATE INDEX IF NOT EXISTS idx_kitchen_tickets_station ON kitchen_tickets(station_id);
+
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS ticket_id UUID NULL REFERENCES kitchen_tickets(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_order_items_ticket ON order_items(ticket_id);
@@ -0,0 +1,3 @@
+ALTER TABLE order_items DROP CONSTRAINT IF EXISTS order_items_status_check;
+ALTER TABLE order_items DROP COLUMN IF EXISTS void_reason;
+ALTER TABLE order_items DROP COLUMN IF EXISTS status;
@@ -0,0 +1,13 @@
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS status TEXT NOT NULL DEFAULT 'queued';
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS void_reason TEXT NULL;
+ALTER TABLE order_items DROP CONSTRAINT IF EXISTS order_items_status_check;
+ALTER TABLE order_items ADD CONSTRAINT order_items_status_check
+  CHECK (status IN ('queued','cooking','ready','served','voided'));
+
+-- Lines of orders that already left the kitchen take the matching status.
+UPDATE order_items oi SET status = 'served'
+  FROM orders o WHERE o.id = oi.order_id AND o.status = 'done';
+UPDATE order_items oi SET status = 'voided'
+  FROM orders o WHERE o.id = oi.order_id AND o.status = 'canceled';
+UPDATE order_items oi SET status = 'ready'
+  FROM orders o WHERE o.id = oi.order_id AND o.status = 'delivering';
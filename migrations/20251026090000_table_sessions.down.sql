@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_orders_session;
+ALTER TABLE orders DROP COLUMN IF EXISTS session_id;
+DROP TABLE IF EXISTS table_sessions;
@@ -0,0 +1,29 @@
+CREATE TABLE IF NOT EXISTS table_sessions (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+  table_id UUID NOT NULL REFERENCES tables(id) ON DELETE CASCADE,
+  status TEXT NOT NULL DEFAULT 'open' CHECK (status IN ('open','billing','closed')),
+  opened_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  billing_at TIMESTAMPTZ NULL,
+  closed_at TIMESTAMPTZ NULL,
+  closed_by UUID NULL REFERENCES admin_users(id) ON DELETE SET NULL
+);
+CREATE INDEX IF NOT EXISTS idx_table_sessions_tenant ON table_sessions(tenant_id);
+CREATE INDEX IF NOT EXISTS idx_table_sessions_status ON table_sessions(status);
+-- At most one open or billing session per table.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_table_sessions_active_table
+  ON table_sessions(table_id) WHERE status <> 'closed';
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS session_id UUID NULL REFERENCES table_sessions(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_orders_session ON orders(session_id);
+
+-- Tables with orders still running or unpaid get an open session holding them.
+INSERT INTO table_sessions (tenant_id, table_id, opened_at)
+SELECT tenant_id, table_id, MIN(created_at)
+  FROM orders
+ WHERE status <> 'canceled' AND (status <> 'done' OR paid_status <> 'paid')
+ GROUP BY tenant_id, table_id;
+UPDATE orders o SET session_id = s.id
+  FROM table_sessions s
+ WHERE s.table_id = o.table_id AND s.status = 'open'
+   AND o.status <> 'canceled' AND (o.status <> 'done' OR o.paid_status <> 'paid');
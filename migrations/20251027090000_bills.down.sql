@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_payments_bill;
+ALTER TABLE payments DROP COLUMN IF EXISTS bill_id;
+DROP TABLE IF EXISTS bill_lines;
+DROP TABLE IF EXISTS bills;
@@ -0,0 +1,30 @@
+CREATE TABLE IF NOT EXISTS bills (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+  session_id UUID NOT NULL REFERENCES table_sessions(id) ON DELETE CASCADE,
+  table_id UUID NOT NULL REFERENCES tables(id),
+  mode TEXT NOT NULL CHECK (mode IN ('items','guests','even')),
+  label TEXT NOT NULL,
+  total BIGINT NOT NULL DEFAULT 0,
+  paid_amount BIGINT NOT NULL DEFAULT 0,
+  paid_status TEXT NOT NULL DEFAULT 'unpaid' CHECK (paid_status IN ('unpaid','partially_paid','paid')),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_bills_tenant ON bills(tenant_id);
+CREATE INDEX IF NOT EXISTS idx_bills_session ON bills(session_id);
+CREATE INDEX IF NOT EXISTS idx_bills_table ON bills(table_id);
+
+CREATE TABLE IF NOT EXISTS bill_lines (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  bill_id UUID NOT NULL REFERENCES bills(id) ON DELETE CASCADE,
+  order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+  order_item_id UUID NULL REFERENCES order_items(id) ON DELETE SET NULL,
+  description TEXT NOT NULL,
+  amount BIGINT NOT NULL CHECK (amount > 0),
+  paid_amount BIGINT NOT NULL DEFAULT 0
+);
+CREATE INDEX IF NOT EXISTS idx_bill_lines_bill ON bill_lines(bill_id);
+CREATE INDEX IF NOT EXISTS idx_bill_lines_order ON bill_lines(order_id);
+
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS bill_id UUID NULL REFERENCES bills(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_payments_bill ON payments(bill_id);
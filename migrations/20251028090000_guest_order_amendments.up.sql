@@ -0,0 +1,2 @@
+ALTER TABLE tenants ADD COLUMN IF NOT EXISTS amend_window_seconds INT NOT NULL DEFAULT 300
+  CHECK (amend_window_seconds >= 0);
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS service_requests (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+  table_id UUID NOT NULL REFERENCES tables(id) ON DELETE CASCADE,
+  type TEXT NOT NULL CHECK (type IN ('call_waiter','request_bill','need_cutlery','other')),
+  note TEXT NULL,
+  guest_session_id TEXT NOT NULL DEFAULT '',
+  status TEXT NOT NULL DEFAULT 'open' CHECK (status IN ('open','acknowledged','resolved')),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  acknowledged_at TIMESTAMPTZ NULL,
+  acknowledged_by UUID NULL REFERENCES admin_users(id) ON DELETE SET NULL,
+  resolved_at TIMESTAMPTZ NULL,
+  resolved_by UUID NULL REFERENCES admin_users(id) ON DELETE SET NULL
+);
+CREATE INDEX IF NOT EXISTS idx_service_requests_tenant_status ON service_requests(tenant_id, status);
+CREATE INDEX IF NOT EXISTS idx_service_requests_table ON service_requests(table_id);
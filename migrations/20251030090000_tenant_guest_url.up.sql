@@ -0,0 +1 @@
+ALTER TABLE tenants ADD COLUMN IF NOT EXISTS guest_url_template TEXT NOT NULL DEFAULT '';
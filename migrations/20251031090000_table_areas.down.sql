@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS idx_tables_area;
+ALTER TABLE tables
+  DROP COLUMN IF EXISTS rotation,
+  DROP COLUMN IF EXISTS height,
+  DROP COLUMN IF EXISTS width,
+  DROP COLUMN IF EXISTS pos_y,
+  DROP COLUMN IF EXISTS pos_x,
+  DROP COLUMN IF EXISTS shape,
+  DROP COLUMN IF EXISTS capacity,
+  DROP COLUMN IF EXISTS area_id;
+DROP TABLE IF EXISTS table_areas;
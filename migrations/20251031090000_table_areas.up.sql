@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS table_areas (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+  name TEXT NOT NULL,
+  sort INT NOT NULL DEFAULT 0,
+  plan_width INT NOT NULL DEFAULT 0 CHECK (plan_width >= 0),
+  plan_height INT NOT NULL DEFAULT 0 CHECK (plan_height >= 0),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_table_areas_tenant ON table_areas(tenant_id);
+
+ALTER TABLE tables
+  ADD COLUMN IF NOT EXISTS area_id UUID NULL REFERENCES table_areas(id) ON DELETE SET NULL,
+  ADD COLUMN IF NOT EXISTS capacity INT NOT NULL DEFAULT 0 CHECK (capacity >= 0),
+  ADD COLUMN IF NOT EXISTS shape TEXT NOT NULL DEFAULT 'square' CHECK (shape IN ('square','round','rectangle')),
+  ADD COLUMN IF NOT EXISTS pos_x INT NULL,
+  ADD COLUMN IF NOT EXISTS pos_y INT NULL,
+  ADD COLUMN IF NOT EXISTS width INT NOT NULL DEFAULT 80 CHECK (width > 0),
+  ADD COLUMN IF NOT EXISTS height INT NOT NULL DEFAULT 80 CHECK (height > 0),
+  ADD COLUMN IF NOT EXISTS rotation INT NOT NULL DEFAULT 0;
+CREATE INDEX IF NOT EXISTS idx_tables_area ON tables(area_id);
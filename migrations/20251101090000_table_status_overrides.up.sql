@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS table_status_overrides (
+  table_id UUID PRIMARY KEY REFERENCES tables(id) ON DELETE CASCADE,
+  tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+  status TEXT NOT NULL CHECK (status IN ('free','ordering','eating','awaiting_payment','needs_cleaning')),
+  base_status TEXT NOT NULL CHECK (base_status IN ('free','ordering','eating','awaiting_payment','needs_cleaning')),
+  session_id UUID NULL REFERENCES table_sessions(id) ON DELETE SET NULL,
+  set_by UUID NULL REFERENCES admin_users(id) ON DELETE SET NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_table_status_overrides_tenant ON table_status_overrides(tenant_id);
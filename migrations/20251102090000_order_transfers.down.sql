@@ -0,0 +1,3 @@
+ALTER TABLE order_status_history
+  DROP COLUMN IF EXISTS to_table_id,
+  DROP COLUMN IF EXISTS from_table_id;
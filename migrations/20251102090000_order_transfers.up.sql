@@ -0,0 +1,3 @@
+ALTER TABLE order_status_history
+  ADD COLUMN IF NOT EXISTS from_table_id UUID NULL REFERENCES tables(id) ON DELETE SET NULL,
+  ADD COLUMN IF NOT EXISTS to_table_id UUID NULL REFERENCES tables(id) ON DELETE SET NULL;
@@ -0,0 +1,2 @@
+ALTER TABLE item_option_values DROP COLUMN IF EXISTS sort;
+ALTER TABLE item_options DROP COLUMN IF EXISTS sort;
@@ -0,0 +1,2 @@
+ALTER TABLE item_options ADD COLUMN IF NOT EXISTS sort INT NOT NULL DEFAULT 0;
+ALTER TABLE item_option_values ADD COLUMN IF NOT EXISTS sort INT NOT NULL DEFAULT 0;
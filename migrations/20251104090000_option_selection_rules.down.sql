@@ -0,0 +1,9 @@
+ALTER TABLE order_item_selections DROP COLUMN IF EXISTS qty;
+
+ALTER TABLE item_option_values
+  DROP COLUMN IF EXISTS is_default,
+  DROP COLUMN IF EXISTS max_qty;
+
+ALTER TABLE item_options
+  DROP COLUMN IF EXISTS max_select,
+  DROP COLUMN IF EXISTS min_select;
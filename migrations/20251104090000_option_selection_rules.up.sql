@@ -0,0 +1,9 @@
+ALTER TABLE item_options
+  ADD COLUMN IF NOT EXISTS min_select INT NOT NULL DEFAULT 0 CHECK (min_select >= 0),
+  ADD COLUMN IF NOT EXISTS max_select INT NOT NULL DEFAULT 0 CHECK (max_select >= 0);
+
+ALTER TABLE item_option_values
+  ADD COLUMN IF NOT EXISTS max_qty INT NOT NULL DEFAULT 1 CHECK (max_qty >= 1),
+  ADD COLUMN IF NOT EXISTS is_default BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE order_item_selections ADD COLUMN IF NOT EXISTS qty INT NOT NULL DEFAULT 1;
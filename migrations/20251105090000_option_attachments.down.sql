@@ -0,0 +1,22 @@
+-- Each option goes back to the first item it was attached to; options only
+-- attached to categories (or to nothing) are dropped.
+ALTER TABLE item_options ADD COLUMN IF NOT EXISTS item_id UUID REFERENCES items(id);
+UPDATE item_options o SET item_id = a.item_id
+FROM (
+  SELECT DISTINCT ON (option_id) option_id, item_id
+  FROM option_attachments
+  WHERE item_id IS NOT NULL
+  ORDER BY option_id, created_at
+) a
+WHERE a.option_id = o.id;
+DELETE FROM item_options WHERE item_id IS NULL;
+ALTER TABLE item_options ALTER COLUMN item_id SET NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_item_options_item ON item_options(item_id);
+
+DROP TABLE IF EXISTS option_attachments;
+
+ALTER TABLE item_options DROP COLUMN IF EXISTS tenant_id;
+
+ALTER TABLE item_option_values DROP CONSTRAINT IF EXISTS item_option_values_option_id_fkey;
+ALTER TABLE item_option_values ADD CONSTRAINT item_option_values_option_id_fkey
+  FOREIGN KEY (option_id) REFERENCES item_options(id);
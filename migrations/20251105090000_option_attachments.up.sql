@@ -0,0 +1,35 @@
+-- Options become tenant-wide modifier groups attached to items or categories.
+ALTER TABLE item_options ADD COLUMN IF NOT EXISTS tenant_id UUID REFERENCES tenants(id) ON DELETE CASCADE;
+UPDATE item_options o SET tenant_id = i.tenant_id FROM items i WHERE i.id = o.item_id AND o.tenant_id IS NULL;
+ALTER TABLE item_options ALTER COLUMN tenant_id SET NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_item_options_tenant ON item_options(tenant_id);
+
+CREATE TABLE IF NOT EXISTS option_attachments (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+  option_id UUID NOT NULL REFERENCES item_options(id) ON DELETE CASCADE,
+  item_id UUID NULL REFERENCES items(id) ON DELETE CASCADE,
+  category_id UUID NULL REFERENCES categories(id) ON DELETE CASCADE,
+  required BOOLEAN NULL,
+  min_select INT NULL CHECK (min_select >= 0),
+  max_select INT NULL CHECK (max_select >= 0),
+  sort INT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  CHECK ((item_id IS NULL) <> (category_id IS NULL))
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_option_attachments_item ON option_attachments(option_id, item_id) WHERE item_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_option_attachments_category ON option_attachments(option_id, category_id) WHERE category_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_option_attachments_tenant ON option_attachments(tenant_id);
+CREATE INDEX IF NOT EXISTS idx_option_attachments_item_id ON option_attachments(item_id);
+CREATE INDEX IF NOT EXISTS idx_option_attachments_category_id ON option_attachments(category_id);
+
+-- Every existing option keeps being offered on the item that owned it.
+INSERT INTO option_attachments (tenant_id, option_id, item_id)
+SELECT tenant_id, id, item_id FROM item_options WHERE item_id IS NOT NULL;
+
+ALTER TABLE item_options DROP COLUMN IF EXISTS item_id;
+
+-- Values now go with their option.
+ALTER TABLE item_option_values DROP CONSTRAINT IF EXISTS item_option_values_option_id_fkey;
+ALTER TABLE item_option_values ADD CONSTRAINT item_option_values_option_id_fkey
+  FOREIGN KEY (option_id) REFERENCES item_options(id) ON DELETE CASCADE;
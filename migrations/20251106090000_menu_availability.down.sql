@@ -0,0 +1,4 @@
+ALTER TABLE tenants DROP COLUMN IF EXISTS timezone;
+
+ALTER TABLE items DROP COLUMN IF EXISTS availability;
+ALTER TABLE categories DROP COLUMN IF EXISTS availability;
@@ -0,0 +1,4 @@
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS availability JSONB NOT NULL DEFAULT '[]';
+ALTER TABLE items ADD COLUMN IF NOT EXISTS availability JSONB NOT NULL DEFAULT '[]';
+
+ALTER TABLE tenants ADD COLUMN IF NOT EXISTS timezone TEXT NOT NULL DEFAULT '';